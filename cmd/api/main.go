@@ -3,16 +3,33 @@ package main
 import (
 	"context"
 	"fmt"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"os"
+	"strconv"
 	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/infrastructure/password"
+	"tenderSystem/internal/infrastructure/postgres"
+	"tenderSystem/internal/infrastructure/repositories/attachment"
 	"tenderSystem/internal/infrastructure/repositories/bid"
 	"tenderSystem/internal/infrastructure/repositories/bid/decision"
 	"tenderSystem/internal/infrastructure/repositories/bid/feedback"
+	"tenderSystem/internal/infrastructure/repositories/category"
 	"tenderSystem/internal/infrastructure/repositories/employee"
+	"tenderSystem/internal/infrastructure/repositories/employee/credential"
+	"tenderSystem/internal/infrastructure/repositories/idempotency"
+	"tenderSystem/internal/infrastructure/repositories/organization"
+	"tenderSystem/internal/infrastructure/repositories/organization/apikey"
+	"tenderSystem/internal/infrastructure/repositories/organization/invitation"
+	"tenderSystem/internal/infrastructure/repositories/refreshtoken"
 	"tenderSystem/internal/infrastructure/repositories/tender"
+	"tenderSystem/internal/infrastructure/scheduler"
 	"tenderSystem/internal/infrastructure/server"
+	"tenderSystem/internal/infrastructure/server/middleware"
+	"tenderSystem/internal/infrastructure/storage"
+	"tenderSystem/internal/infrastructure/token"
 	"tenderSystem/internal/usecase"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -30,6 +47,59 @@ func inner() error {
 	postgresPassword := os.Getenv("POSTGRES_PASSWORD")
 	postgresDatabase := os.Getenv("POSTGRES_DATABASE")
 
+	jwtAlgorithm := os.Getenv("AUTH_JWT_ALGORITHM")
+	jwtSecret := os.Getenv("AUTH_JWT_SECRET")
+	jwtPublicKeyPath := os.Getenv("AUTH_JWT_PUBLIC_KEY_PATH")
+	jwtPrivateKeyPath := os.Getenv("AUTH_JWT_PRIVATE_KEY_PATH")
+	allowLegacyUsername := os.Getenv("AUTH_ALLOW_LEGACY_USERNAME") == "true"
+
+	accessTokenTTL, err := durationFromEnv("AUTH_ACCESS_TOKEN_TTL", 15*time.Minute)
+	if err != nil {
+		return err
+	}
+	refreshTokenTTL, err := durationFromEnv("AUTH_REFRESH_TOKEN_TTL", 30*24*time.Hour)
+	if err != nil {
+		return err
+	}
+	lockoutDuration, err := durationFromEnv("AUTH_LOCKOUT_DURATION", 15*time.Minute)
+	if err != nil {
+		return err
+	}
+	maxLoginAttempts, err := intFromEnv("AUTH_MAX_LOGIN_ATTEMPTS", 5)
+	if err != nil {
+		return err
+	}
+	invitationTTL, err := durationFromEnv("INVITATION_TTL", 72*time.Hour)
+	if err != nil {
+		return err
+	}
+	tenderSchedulerInterval, err := durationFromEnv("TENDER_SCHEDULER_INTERVAL", time.Minute)
+	if err != nil {
+		return err
+	}
+	idempotencyKeyTTL, err := durationFromEnv("IDEMPOTENCY_KEY_TTL", 24*time.Hour)
+	if err != nil {
+		return err
+	}
+	idempotencyPurgeInterval, err := durationFromEnv("IDEMPOTENCY_PURGE_INTERVAL", time.Hour)
+	if err != nil {
+		return err
+	}
+
+	attachmentStorage := os.Getenv("ATTACHMENT_STORAGE")
+	attachmentLocalDir := os.Getenv("ATTACHMENT_LOCAL_DIR")
+	attachmentMaxSize, err := intFromEnv("ATTACHMENT_MAX_SIZE", 20<<20)
+	if err != nil {
+		return err
+	}
+	s3Config := storage.S3Config{
+		Endpoint:  os.Getenv("S3_ENDPOINT"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+		Bucket:    os.Getenv("S3_BUCKET"),
+		UseSSL:    os.Getenv("S3_USE_SSL") == "true",
+	}
+
 	postgresURL := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", postgresHost, postgresPort, postgresUsername, postgresPassword, postgresDatabase)
 
 	// Split the server address into host and port
@@ -38,36 +108,163 @@ func inner() error {
 	host, port = addressParts[0], addressParts[1]
 
 	// Connect to the database
-	pgxConn, err := pgx.Connect(context.Background(), postgresURL)
+	pgxPool, err := pgxpool.New(context.Background(), postgresURL)
 	if err != nil {
 		return err
 	}
-	defer pgxConn.Close(context.Background())
+	defer pgxPool.Close()
 
 	// Ping the database
-	err = pgxConn.Ping(context.Background())
+	err = pgxPool.Ping(context.Background())
 	if err != nil {
 		return err
 	}
 
+	db := postgres.NewDB(pgxPool)
+	transactionManager := postgres.NewTransactionManager(db)
+
 	// Init repositories
-	tenderRepo := tender.NewPGXRepository(pgxConn)
-	bidRepo := bid.NewPGXRepository(pgxConn)
-	bidFeedbackRepo := feedback.NewPGXRepository(pgxConn)
-	bidDecisionRepo := decision.NewPGXRepository(pgxConn)
+	tenderRepo := tender.NewPGXRepository(db)
+	bidRepo := bid.NewPGXRepository(db)
+	bidFeedbackRepo := feedback.NewPGXRepository(db)
+	bidDecisionRepo := decision.NewPGXRepository(db)
+
+	employeeRepo := employee.NewPGXRepository(db)
+	credentialRepo := credential.NewPGXRepository(db)
+	refreshTokenRepo := refreshtoken.NewPGXRepository(db)
 
-	employeeRepo := employee.NewPGXRepository(pgxConn)
+	organizationRepo := organization.NewPGXRepository(db)
+	apiKeyRepo := apikey.NewPGXRepository(db)
+	invitationRepo := invitation.NewPGXRepository(db)
+	attachmentRepo := attachment.NewPGXRepository(db)
+	categoryRepo := category.NewPGXRepository(db)
+	idempotencyRepo := idempotency.NewPGXRepository(db)
+
+	// Init blob storage for attachments
+	var blobStorage abstraction.BlobStorage
+	switch attachmentStorage {
+	case "", "local":
+		if attachmentLocalDir == "" {
+			attachmentLocalDir = "data/attachments"
+		}
+
+		blobStorage, err = storage.NewLocalStorage(attachmentLocalDir)
+	case "s3":
+		blobStorage, err = storage.NewS3Storage(context.Background(), s3Config)
+	default:
+		err = fmt.Errorf("unknown ATTACHMENT_STORAGE %s", attachmentStorage)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Init token manager
+	jwtConfig := token.JWTConfig{
+		Algorithm:      jwtAlgorithm,
+		Secret:         []byte(jwtSecret),
+		AccessTokenTTL: accessTokenTTL,
+	}
+	if jwtConfig.Algorithm == "" {
+		jwtConfig.Algorithm = token.AlgorithmHS256
+	}
+	if jwtConfig.Algorithm == token.AlgorithmRS256 {
+		jwtConfig.PublicKey, err = token.LoadRSAPublicKey(jwtPublicKeyPath)
+		if err != nil {
+			return err
+		}
+
+		// Without a private key the service only verifies tokens of an external issuer
+		if jwtPrivateKeyPath != "" {
+			jwtConfig.PrivateKey, err = token.LoadRSAPrivateKey(jwtPrivateKeyPath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	tokenManager, err := token.NewJWTManager(jwtConfig)
+	if err != nil {
+		return err
+	}
+
+	if allowLegacyUsername {
+		fmt.Println("WARNING: legacy username authentication is enabled, do not use it outside of local development")
+	}
 
 	// Init use cases
-	tenderUseCase := usecase.NewTenderUseCase(tenderRepo, employeeRepo)
-	bidUseCase := usecase.NewBidUseCase(employeeRepo, tenderRepo, bidRepo, bidFeedbackRepo, bidDecisionRepo)
+	attachmentService := usecase.NewAttachmentService(attachmentRepo, blobStorage, int64(attachmentMaxSize))
+	tenderUseCase := usecase.NewTenderUseCase(tenderRepo, employeeRepo, organizationRepo, categoryRepo, transactionManager, attachmentService)
+	bidUseCase := usecase.NewBidUseCase(employeeRepo, organizationRepo, tenderRepo, bidRepo, bidFeedbackRepo, bidDecisionRepo, transactionManager, attachmentService)
+	passwordHasher := password.NewBcryptHasher(0)
+	authUseCase := usecase.NewAuthUseCase(
+		employeeRepo, credentialRepo, refreshTokenRepo, tokenManager, passwordHasher,
+		usecase.AuthConfig{
+			RefreshTokenTTL:  refreshTokenTTL,
+			MaxLoginAttempts: maxLoginAttempts,
+			LockoutDuration:  lockoutDuration,
+		},
+	)
+
+	apiKeyUseCase := usecase.NewAPIKeyUseCase(apiKeyRepo, organizationRepo, employeeRepo)
+	organizationUseCase := usecase.NewOrganizationUseCase(organizationRepo, employeeRepo)
+	employeeUseCase := usecase.NewEmployeeUseCase(
+		employeeRepo, invitationRepo, refreshTokenRepo, passwordHasher, transactionManager,
+		usecase.EmployeeConfig{InvitationTTL: invitationTTL},
+	)
+
+	categoryUseCase := usecase.NewCategoryUseCase(categoryRepo)
+	idempotencyUseCase := usecase.NewIdempotencyUseCase(idempotencyRepo, usecase.IdempotencyConfig{KeyTTL: idempotencyKeyTTL})
+
+	// Start background jobs
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+
+	tenderCloser := scheduler.NewTenderCloser(tenderUseCase, tenderSchedulerInterval)
+	go tenderCloser.Run(schedulerCtx)
+
+	idempotencyKeyPurger := scheduler.NewIdempotencyKeyPurger(idempotencyUseCase, idempotencyPurgeInterval)
+	go idempotencyKeyPurger.Run(schedulerCtx)
 
 	// Init server
-	srv := server.NewServer(tenderUseCase, bidUseCase, host, port)
+	srv := server.NewServer(
+		tenderUseCase, bidUseCase, authUseCase, apiKeyUseCase, organizationUseCase, employeeUseCase, categoryUseCase, idempotencyUseCase,
+		tokenManager, employeeRepo, middleware.AuthConfig{AllowLegacyUsername: allowLegacyUsername},
+		// uploads with a key are buffered up to the attachment limit and the multipart framing
+		middleware.IdempotencyConfig{MaxBodySize: int64(attachmentMaxSize) + 1<<20},
+		host, port,
+	)
 
 	return srv.Start()
 }
 
+func durationFromEnv(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return duration, nil
+}
+
+func intFromEnv(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	return number, nil
+}
+
 func main() {
 	err := inner()
 	if err != nil {
@@ -0,0 +1,112 @@
+// Command categories imports the procurement classifier from a CSV file.
+//
+// The file starts with the header code,parent_code,name and lists one category per line,
+// parent_code is empty for root categories. Existing categories are updated.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"io"
+	"os"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/infrastructure/postgres"
+	"tenderSystem/internal/infrastructure/repositories/category"
+	"tenderSystem/internal/usecase"
+
+	"github.com/joho/godotenv"
+)
+
+var csvHeader = []string{"code", "parent_code", "name"}
+
+func readCategories(r io.Reader) ([]dto.ImportCategoryDTO, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	for i, column := range csvHeader {
+		if header[i] != column {
+			return nil, fmt.Errorf("unexpected header %v, want %v", header, csvHeader)
+		}
+	}
+
+	var categories []dto.ImportCategoryDTO
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return categories, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, dto.ImportCategoryDTO{
+			Code:       record[0],
+			ParentCode: record[1],
+			Name:       record[2],
+		})
+	}
+}
+
+func inner() error {
+	filePath := flag.String("file", "", "path to the CSV file with categories")
+	flag.Parse()
+
+	if *filePath == "" {
+		return errors.New("-file is required")
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		fmt.Println("Error loading .env file")
+	}
+
+	postgresURL := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		os.Getenv("POSTGRES_HOST"), os.Getenv("POSTGRES_PORT"), os.Getenv("POSTGRES_USERNAME"),
+		os.Getenv("POSTGRES_PASSWORD"), os.Getenv("POSTGRES_DATABASE"),
+	)
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	categories, err := readCategories(file)
+	if err != nil {
+		return err
+	}
+
+	pgxPool, err := pgxpool.New(context.Background(), postgresURL)
+	if err != nil {
+		return err
+	}
+	defer pgxPool.Close()
+
+	categoryUseCase := usecase.NewCategoryUseCase(category.NewPGXRepository(postgres.NewDB(pgxPool)))
+
+	imported, err := categoryUseCase.Import(context.Background(), categories)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d categories\n", imported)
+
+	return nil
+}
+
+func main() {
+	err := inner()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
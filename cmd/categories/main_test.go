@@ -0,0 +1,47 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"tenderSystem/internal/domain/dto"
+	"testing"
+)
+
+func TestReadCategories(t *testing.T) {
+	file := "code,parent_code,name\n" +
+		"41.20,41,Здания и работы по возведению зданий\n" +
+		"41,construction,\"Здания, работы\"\n" +
+		"services,,Услуги\n"
+
+	got, err := readCategories(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []dto.ImportCategoryDTO{
+		{Code: "41.20", ParentCode: "41", Name: "Здания и работы по возведению зданий"},
+		{Code: "41", ParentCode: "construction", Name: "Здания, работы"},
+		{Code: "services", Name: "Услуги"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestReadCategoriesRejectsMalformedFiles(t *testing.T) {
+	files := map[string]string{
+		"empty":          "",
+		"other header":   "code,parent,name\n41,,Здания\n",
+		"missing column": "code,parent_code,name\n41,Здания\n",
+		"extra column":   "code,parent_code,name\n41,,Здания,1\n",
+	}
+
+	for name, file := range files {
+		t.Run(name, func(t *testing.T) {
+			_, err := readCategories(strings.NewReader(file))
+			if err == nil {
+				t.Fatal("read a malformed file")
+			}
+		})
+	}
+}
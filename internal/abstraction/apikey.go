@@ -0,0 +1,24 @@
+package abstraction
+
+import (
+	"context"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+type APIKeyUseCaseInterface interface {
+	// Create returns the stored key and its plaintext value, which is never retrievable again
+	Create(ctx context.Context, data *dto.CreateAPIKeyDTO) (models.APIKey, string, error)
+	GetByOrganizationID(ctx context.Context, organizationID models.ID) ([]models.APIKey, error)
+	Revoke(ctx context.Context, organizationID, id models.ID) error
+	Authenticate(ctx context.Context, key string) (models.Principal, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, data *models.APIKey) (models.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (models.APIKey, error)
+	GetByOrganizationID(ctx context.Context, organizationID models.ID) ([]models.APIKey, error)
+	Revoke(ctx context.Context, organizationID, id models.ID) error
+	TouchLastUsed(ctx context.Context, id models.ID, usedAt time.Time) error
+}
@@ -0,0 +1,24 @@
+package abstraction
+
+import (
+	"context"
+	"io"
+	"tenderSystem/internal/domain/models"
+)
+
+// BlobStorage keeps the contents of attachments by key
+type BlobStorage interface {
+	// Put stores the content under the key, size is -1 when unknown
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Get returns the content stored under the key, the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type AttachmentRepository interface {
+	// Create stores the attachment and links it to the current version of its owner
+	Create(ctx context.Context, data *models.Attachment) (models.Attachment, error)
+	GetByID(ctx context.Context, id models.ID) (models.Attachment, error)
+	// GetByOwner returns the attachments of the owner version, of the current one when version is nil
+	GetByOwner(ctx context.Context, ownerType models.AttachmentOwnerType, ownerID models.ID, version *int) ([]models.Attachment, error)
+}
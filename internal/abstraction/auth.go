@@ -0,0 +1,46 @@
+package abstraction
+
+import (
+	"context"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+type TokenClaims struct {
+	Subject string
+	// OrganizationID is the optional `org` claim binding the token to an acting organization
+	OrganizationID string
+	ExpiresAt      time.Time
+}
+
+type TokenManager interface {
+	Parse(token string) (TokenClaims, error)
+	Issue(subject string) (string, time.Time, error)
+}
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) error
+}
+
+type AuthUseCaseInterface interface {
+	Login(ctx context.Context, username, password string) (models.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (models.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	SetPassword(ctx context.Context, currentPassword, newPassword string) error
+}
+
+type CredentialRepository interface {
+	GetByEmployeeID(ctx context.Context, employeeID models.ID) (models.EmployeeCredentials, error)
+	SetPasswordHash(ctx context.Context, employeeID models.ID, passwordHash string) error
+	RegisterFailedAttempt(ctx context.Context, employeeID models.ID, maxAttempts int, lockedUntil time.Time) error
+	ResetFailedAttempts(ctx context.Context, employeeID models.ID) error
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, data *models.RefreshToken) (models.RefreshToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+	Revoke(ctx context.Context, id models.ID) (bool, error)
+	RevokeFamily(ctx context.Context, familyID models.ID) error
+	RevokeByEmployeeID(ctx context.Context, employeeID models.ID) error
+}
@@ -2,32 +2,166 @@ package abstraction
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"tenderSystem/internal/domain"
 	"tenderSystem/internal/domain/dto"
 	"tenderSystem/internal/domain/models"
 )
 
+type BidSortOrder string
+
+const (
+	BidSortCreatedAtDesc BidSortOrder = "created_at_desc"
+	BidSortCreatedAtAsc  BidSortOrder = "created_at_asc"
+	BidSortNameAsc       BidSortOrder = "name_asc"
+	BidSortNameDesc      BidSortOrder = "name_desc"
+	BidSortPriceAsc      BidSortOrder = "price_asc"
+	BidSortPriceDesc     BidSortOrder = "price_desc"
+)
+
+func (b BidSortOrder) String() string {
+	return string(b)
+}
+
+func NewBidSortOrder(b string) (BidSortOrder, error) {
+	switch b {
+	case "created_at_desc":
+		return BidSortCreatedAtDesc, nil
+	case "created_at_asc":
+		return BidSortCreatedAtAsc, nil
+	case "name_asc":
+		return BidSortNameAsc, nil
+	case "name_desc":
+		return BidSortNameDesc, nil
+	case "price_asc":
+		return BidSortPriceAsc, nil
+	case "price_desc":
+		return BidSortPriceDesc, nil
+	default:
+		return "", fmt.Errorf("unknown bid sort order: %w", domain.ErrInvalidArgument)
+	}
+}
+
+type GetBidsOptions struct {
+	PaginationOptions *PaginationOptions
+	SortOrder         BidSortOrder
+	LotID             *models.ID
+	// Statuses match any of the statuses, all statuses when empty
+	Statuses []models.BidStatus
+	// OrganizationID matches the bids submitted on behalf of the organization
+	OrganizationID *models.ID
+	CreatedAt      TimeRange
+	Version        VersionRange
+}
+
+type GetBidsOptFunc func(*GetBidsOptions) error
+
+func WithBidsPaginationOptions(paginationOptions *PaginationOptions) GetBidsOptFunc {
+	return func(o *GetBidsOptions) error {
+		o.PaginationOptions = paginationOptions
+		return nil
+	}
+}
+
+func WithBidsSortOrder(sortOrder BidSortOrder) GetBidsOptFunc {
+	return func(o *GetBidsOptions) error {
+		o.SortOrder = sortOrder
+		return nil
+	}
+}
+
+func WithBidsLotID(lotID models.ID) GetBidsOptFunc {
+	return func(o *GetBidsOptions) error {
+		o.LotID = &lotID
+		return nil
+	}
+}
+
+func WithBidStatus(status models.BidStatus) GetBidsOptFunc {
+	return func(o *GetBidsOptions) error {
+		o.Statuses = append(o.Statuses, status)
+		return nil
+	}
+}
+
+func WithBidsOrganizationID(organizationID models.ID) GetBidsOptFunc {
+	return func(o *GetBidsOptions) error {
+		o.OrganizationID = &organizationID
+		return nil
+	}
+}
+
+func WithBidsCreatedAt(createdAt TimeRange) GetBidsOptFunc {
+	return func(o *GetBidsOptions) error {
+		o.CreatedAt = createdAt
+		return nil
+	}
+}
+
+func WithBidsVersion(version VersionRange) GetBidsOptFunc {
+	return func(o *GetBidsOptions) error {
+		o.Version = version
+		return nil
+	}
+}
+
+func NewGetBidsOptions(options ...GetBidsOptFunc) (*GetBidsOptions, error) {
+	paginationOpts, _ := NewPaginationOptions()
+	opts := &GetBidsOptions{
+		PaginationOptions: paginationOpts,
+		SortOrder:         BidSortCreatedAtDesc,
+	}
+	for _, opt := range options {
+		if err := opt(opts); err != nil {
+			return nil, err
+		}
+	}
+	return opts, nil
+}
+
 type BidUseCaseInterface interface {
 	Create(ctx context.Context, data *dto.CreateBidDTO) (models.Bid, error)
-	GetMy(ctx context.Context, username string, options ...PaginationOptFunc) ([]models.Bid, error)
-	GetByTenderID(ctx context.Context, tenderID models.ID, username string, options ...PaginationOptFunc) ([]models.Bid, error)
-	GetStatus(ctx context.Context, id models.ID, username string) (models.BidStatus, error)
-	SetStatus(ctx context.Context, id models.ID, username string, status models.BidStatus) (models.Bid, error)
-	Update(ctx context.Context, id models.ID, username string, data *dto.UpdateBidDTO) (models.Bid, error)
-	SubmitDecision(ctx context.Context, id models.ID, username string, decision models.BidDecisionType) (models.Bid, error)
-	LeaveFeedback(ctx context.Context, id models.ID, username string, feedback string) (models.Bid, error)
-	Rollback(ctx context.Context, id models.ID, username string, version int) (models.Bid, error)
-	GetAuthorsFeedback(ctx context.Context, id models.ID, requesterUsername, authorUsername string, options ...PaginationOptFunc) ([]models.BidFeedback, error)
+	GetMy(ctx context.Context, options ...GetBidsOptFunc) ([]models.Bid, error)
+	GetByTenderID(ctx context.Context, tenderID models.ID, options ...GetBidsOptFunc) ([]models.Bid, error)
+	SearchByTenderID(ctx context.Context, tenderID models.ID, text string, options ...GetBidsOptFunc) ([]models.BidSearchResult, error)
+	// GetStatus returns the status and the current revision of the bid
+	GetStatus(ctx context.Context, id models.ID) (models.BidStatus, int, error)
+	// SetStatus, Update and Rollback fail with ErrPreconditionFailed unless the current revision is
+	// one of expectedRevisions, nil expectedRevisions skip the check
+	SetStatus(ctx context.Context, id models.ID, status models.BidStatus, expectedRevisions []int) (models.Bid, error)
+	GetAllowedStatuses(ctx context.Context, id models.ID) (models.BidStatus, []models.BidStatus, error)
+	Update(ctx context.Context, id models.ID, data *dto.UpdateBidDTO, expectedRevisions []int) (models.Bid, error)
+	SubmitDecision(ctx context.Context, id models.ID, decision models.BidDecisionType) (models.Bid, error)
+	LeaveFeedback(ctx context.Context, id models.ID, feedback string) (models.Bid, error)
+	Rollback(ctx context.Context, id models.ID, version int, expectedRevisions []int) (models.Bid, error)
+	// GetVersions returns the bid history, the latest version first
+	GetVersions(ctx context.Context, id models.ID, options ...PaginationOptFunc) ([]models.Bid, error)
+	GetVersion(ctx context.Context, id models.ID, version int) (models.Bid, error)
+	// Diff returns the versioned fields that changed from one version to the other
+	Diff(ctx context.Context, id models.ID, from, to int) ([]models.FieldChange, error)
+	GetAuthorsFeedback(ctx context.Context, id models.ID, authorUsername string, options ...PaginationOptFunc) ([]models.BidFeedback, error)
+	UploadAttachment(ctx context.Context, id models.ID, data *dto.UploadAttachmentDTO) (models.Attachment, error)
+	GetAttachments(ctx context.Context, id models.ID, version *int) ([]models.Attachment, error)
+	// DownloadAttachment returns the attachment and its content, the caller closes the content
+	DownloadAttachment(ctx context.Context, id, attachmentID models.ID) (models.Attachment, io.ReadCloser, error)
 }
 
 type BidRepository interface {
 	Create(ctx context.Context, data *models.Bid) (models.Bid, error)
 	GetByID(ctx context.Context, id models.ID) (models.Bid, error)
+	LockByID(ctx context.Context, id models.ID) (models.Bid, error)
 	GetAll(ctx context.Context, options ...PaginationOptFunc) ([]models.Bid, error)
-	GetByAuthorID(ctx context.Context, authorID models.ID, options ...PaginationOptFunc) ([]models.Bid, error)
-	GetByTenderID(ctx context.Context, tenderID models.ID, options ...PaginationOptFunc) ([]models.Bid, error)
+	GetByAuthorID(ctx context.Context, authorID models.ID, options ...GetBidsOptFunc) ([]models.Bid, error)
+	GetByTenderID(ctx context.Context, tenderID models.ID, options ...GetBidsOptFunc) ([]models.Bid, error)
+	// SearchByTenderID returns the bids matching the full-text query ranked by relevance, the sort order is ignored
+	SearchByTenderID(ctx context.Context, tenderID models.ID, text string, options ...GetBidsOptFunc) ([]models.BidSearchResult, error)
 	SetStatus(ctx context.Context, id models.ID, status models.BidStatus) (models.Bid, error)
 	Update(ctx context.Context, id models.ID, data *models.Bid) (models.Bid, error)
-	Rollback(ctx context.Context, id models.ID, version int) (models.Bid, error)
+	GetVersions(ctx context.Context, id models.ID, options ...PaginationOptFunc) ([]models.Bid, error)
+	GetSpecificVersion(ctx context.Context, id models.ID, version int) (models.Bid, error)
+	// Rollback copies the version into a new current version created by the author
+	Rollback(ctx context.Context, id models.ID, version int, author *models.VersionAuthor) (models.Bid, error)
 	GetLatestVersionNumber(ctx context.Context, id models.ID) (int, error)
 }
 
@@ -0,0 +1,26 @@
+package abstraction
+
+import (
+	"context"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+)
+
+type CategoryUseCaseInterface interface {
+	// GetChildren returns the children of the category, the root categories when parentCode is nil
+	GetChildren(ctx context.Context, parentCode *models.CategoryCode) ([]models.Category, error)
+	// Get returns the category and its path from the root, the category itself is the last element
+	Get(ctx context.Context, code models.CategoryCode) (models.Category, []models.Category, error)
+	// Import creates or updates the categories, returns how many were imported
+	Import(ctx context.Context, categories []dto.ImportCategoryDTO) (int, error)
+}
+
+type CategoryRepository interface {
+	GetByCode(ctx context.Context, code models.CategoryCode) (models.Category, error)
+	GetAll(ctx context.Context) ([]models.Category, error)
+	GetChildren(ctx context.Context, parentCode *models.CategoryCode) ([]models.Category, error)
+	// GetPath returns the ancestors of the category starting from the root followed by the category
+	GetPath(ctx context.Context, code models.CategoryCode) ([]models.Category, error)
+	// Upsert creates the categories or updates the name and parent of existing ones
+	Upsert(ctx context.Context, categories []models.Category) error
+}
@@ -1,8 +1,64 @@
 package abstraction
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"tenderSystem/internal/domain"
+	"time"
+)
+
 type PaginationOptions struct {
 	Limit  int
 	Offset int
+	// Cursor continues the list right after the previous page, Offset is ignored with it
+	Cursor *Cursor
+	// PageInfo receives the cursor of the next page and, with CountTotal, the number of items
+	PageInfo   *PageInfo
+	CountTotal bool
+}
+
+// Cursor is the position of the last item of a page in a list order. Clients get it
+// encoded by String and pass it back untouched.
+type Cursor struct {
+	// Order identifies the order the cursor was issued for
+	Order string `json:"o"`
+	// Key is the sort key of the item formatted as text, nil for a NULL key
+	Key *string `json:"k,omitempty"`
+	ID  string  `json:"i"`
+}
+
+func (c Cursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func ParseCursor(s string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("malformed cursor: %w", domain.ErrInvalidArgument)
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil || cursor.Order == "" {
+		return Cursor{}, fmt.Errorf("malformed cursor: %w", domain.ErrInvalidArgument)
+	}
+
+	if _, err = uuid.Parse(cursor.ID); err != nil {
+		return Cursor{}, fmt.Errorf("malformed cursor: %w", domain.ErrInvalidArgument)
+	}
+
+	return cursor, nil
+}
+
+// PageInfo describes the page returned by a list
+type PageInfo struct {
+	// NextCursor continues the list, empty on the last page
+	NextCursor string
+	// Total is the number of items matching the filters, set when counted
+	Total *int
 }
 
 type PaginationOptFunc func(*PaginationOptions) error
@@ -21,6 +77,30 @@ func WithOffset(offset int) PaginationOptFunc {
 	}
 }
 
+// WithPagination replaces the pagination options by options
+func WithPagination(options PaginationOptions) PaginationOptFunc {
+	return func(o *PaginationOptions) error {
+		*o = options
+		return nil
+	}
+}
+
+func WithCursor(cursor Cursor) PaginationOptFunc {
+	return func(o *PaginationOptions) error {
+		o.Cursor = &cursor
+		return nil
+	}
+}
+
+// WithPageInfo fills info with the next page cursor, and with the total count when countTotal is set
+func WithPageInfo(info *PageInfo, countTotal bool) PaginationOptFunc {
+	return func(o *PaginationOptions) error {
+		o.PageInfo = info
+		o.CountTotal = countTotal
+		return nil
+	}
+}
+
 func NewPaginationOptions(options ...PaginationOptFunc) (*PaginationOptions, error) {
 	opts := &PaginationOptions{
 		Limit:  10,
@@ -33,3 +113,31 @@ func NewPaginationOptions(options ...PaginationOptFunc) (*PaginationOptions, err
 	}
 	return opts, nil
 }
+
+// TimeRange limits a timestamp inclusively, nil bounds are not limited
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+func NewTimeRange(from, to *time.Time) (TimeRange, error) {
+	if from != nil && to != nil && from.After(*to) {
+		return TimeRange{}, fmt.Errorf("start of the time range is after its end: %w", domain.ErrInvalidArgument)
+	}
+
+	return TimeRange{From: from, To: to}, nil
+}
+
+// VersionRange limits the current version number inclusively, nil bounds are not limited
+type VersionRange struct {
+	Min *int
+	Max *int
+}
+
+func NewVersionRange(min, max *int) (VersionRange, error) {
+	if min != nil && max != nil && *min > *max {
+		return VersionRange{}, fmt.Errorf("minimum version is greater than maximum: %w", domain.ErrInvalidArgument)
+	}
+
+	return VersionRange{Min: min, Max: max}, nil
+}
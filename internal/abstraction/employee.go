@@ -2,11 +2,34 @@ package abstraction
 
 import (
 	"context"
+	"tenderSystem/internal/domain/dto"
 	"tenderSystem/internal/domain/models"
+	"time"
 )
 
+type EmployeeUseCaseInterface interface {
+	Create(ctx context.Context, data *dto.CreateEmployeeDTO) (models.Employee, error)
+	GetByID(ctx context.Context, id models.ID) (models.Employee, error)
+	Update(ctx context.Context, id models.ID, data *dto.UpdateEmployeeDTO) (models.Employee, error)
+	Deactivate(ctx context.Context, id models.ID) error
+	GetMyOrganizations(ctx context.Context) ([]models.OrganizationMember, error)
+	CreateInvitation(ctx context.Context, data *dto.CreateInvitationDTO) (models.Invitation, string, error)
+	RedeemInvitation(ctx context.Context, data *dto.RedeemInvitationDTO) (models.Employee, error)
+}
+
 type EmployeeRepository interface {
+	Create(ctx context.Context, data *models.Employee, passwordHash string, organizationID models.ID, role models.OrganizationRole) (models.Employee, error)
+	GetByID(ctx context.Context, id models.ID) (models.Employee, error)
 	GetByUsername(ctx context.Context, username string) (models.Employee, error)
-	GetOrganization(ctx context.Context, userID models.ID) (models.Organization, error)
+	Update(ctx context.Context, id models.ID, data *models.Employee) (models.Employee, error)
+	Deactivate(ctx context.Context, id models.ID, deactivatedAt time.Time) error
+	GetMemberships(ctx context.Context, userID models.ID) ([]models.OrganizationMember, error)
+	GetMembership(ctx context.Context, userID, organizationID models.ID) (models.OrganizationMember, error)
 	GetByOrganizationID(ctx context.Context, organizationID models.ID) ([]models.Employee, error)
 }
+
+type InvitationRepository interface {
+	Create(ctx context.Context, data *models.Invitation) (models.Invitation, error)
+	GetByHash(ctx context.Context, tokenHash string) (models.Invitation, error)
+	Redeem(ctx context.Context, invitation *models.Invitation, employee *models.Employee, passwordHash string) error
+}
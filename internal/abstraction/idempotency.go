@@ -0,0 +1,26 @@
+package abstraction
+
+import (
+	"context"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+type IdempotencyUseCaseInterface interface {
+	// Begin reserves the key of the caller for the request. It returns the stored response when
+	// the request has already been completed.
+	Begin(ctx context.Context, key, fingerprint string) (*models.StoredResponse, error)
+	Complete(ctx context.Context, key string, response models.StoredResponse) error
+	// Abort releases the key, so the request can be retried
+	Abort(ctx context.Context, key string) error
+	PurgeExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+type IdempotencyRepository interface {
+	// Reserve stores the request unless its key is taken and has not expired. It reports whether
+	// the request was stored and returns the request stored under the key.
+	Reserve(ctx context.Context, data *models.IdempotentRequest) (models.IdempotentRequest, bool, error)
+	SetResponse(ctx context.Context, scope, key string, response models.StoredResponse) error
+	Delete(ctx context.Context, scope, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,31 @@
+package abstraction
+
+import (
+	"context"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+)
+
+type OrganizationUseCaseInterface interface {
+	Create(ctx context.Context, data *dto.CreateOrganizationDTO) (models.Organization, error)
+	GetAll(ctx context.Context, options ...PaginationOptFunc) ([]models.Organization, error)
+	GetByID(ctx context.Context, id models.ID) (models.Organization, error)
+	Update(ctx context.Context, id models.ID, data *dto.UpdateOrganizationDTO) (models.Organization, error)
+	GetMembers(ctx context.Context, organizationID models.ID) ([]models.OrganizationMember, error)
+	AddMember(ctx context.Context, organizationID, employeeID models.ID, role models.OrganizationRole) (models.OrganizationMember, error)
+	RemoveMember(ctx context.Context, organizationID, employeeID models.ID) error
+	GrantRole(ctx context.Context, organizationID, employeeID models.ID, role models.OrganizationRole) (models.OrganizationMember, error)
+	RevokeRole(ctx context.Context, organizationID, employeeID models.ID) (models.OrganizationMember, error)
+}
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, data *models.Organization, ownerID models.ID) (models.Organization, error)
+	GetAll(ctx context.Context, options ...PaginationOptFunc) ([]models.Organization, error)
+	GetByID(ctx context.Context, id models.ID) (models.Organization, error)
+	Update(ctx context.Context, id models.ID, data *models.Organization) (models.Organization, error)
+	GetMembers(ctx context.Context, organizationID models.ID) ([]models.OrganizationMember, error)
+	GetMember(ctx context.Context, organizationID, employeeID models.ID) (models.OrganizationMember, error)
+	AddMember(ctx context.Context, organizationID, employeeID models.ID, role models.OrganizationRole) error
+	RemoveMember(ctx context.Context, organizationID, employeeID models.ID) error
+	SetMemberRole(ctx context.Context, organizationID, employeeID models.ID, role models.OrganizationRole) error
+}
@@ -2,13 +2,70 @@ package abstraction
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"tenderSystem/internal/domain"
 	"tenderSystem/internal/domain/dto"
 	"tenderSystem/internal/domain/models"
+	"time"
 )
 
+type TenderSortOrder string
+
+const (
+	TenderSortCreatedAtDesc TenderSortOrder = "created_at_desc"
+	TenderSortCreatedAtAsc  TenderSortOrder = "created_at_asc"
+	TenderSortNameAsc       TenderSortOrder = "name_asc"
+	TenderSortNameDesc      TenderSortOrder = "name_desc"
+	TenderSortBudgetAsc     TenderSortOrder = "budget_asc"
+	TenderSortBudgetDesc    TenderSortOrder = "budget_desc"
+)
+
+func (t TenderSortOrder) String() string {
+	return string(t)
+}
+
+func NewTenderSortOrder(t string) (TenderSortOrder, error) {
+	switch t {
+	case "created_at_desc":
+		return TenderSortCreatedAtDesc, nil
+	case "created_at_asc":
+		return TenderSortCreatedAtAsc, nil
+	case "name_asc":
+		return TenderSortNameAsc, nil
+	case "name_desc":
+		return TenderSortNameDesc, nil
+	case "budget_asc":
+		return TenderSortBudgetAsc, nil
+	case "budget_desc":
+		return TenderSortBudgetDesc, nil
+	default:
+		return "", fmt.Errorf("unknown tender sort order: %w", domain.ErrInvalidArgument)
+	}
+}
+
 type GetTendersOptions struct {
 	PaginationOptions *PaginationOptions
-	ServiceTypes      []models.TenderType
+	SortOrder         TenderSortOrder
+	// ServiceTypes match the categories and all their descendants
+	ServiceTypes []models.CategoryCode
+	Budget       *BudgetRange
+	// Statuses match any of the statuses, all statuses when empty
+	Statuses           []models.TenderStatus
+	OrganizationID     *models.ID
+	CreatedAt          TimeRange
+	SubmissionDeadline TimeRange
+	DecisionDeadline   TimeRange
+	Version            VersionRange
+	// Viewer hides the invite-only tenders the viewer is not invited to, nil shows all tenders
+	Viewer *models.TenderViewer
+}
+
+// BudgetRange filters tenders by the budget in the currency. Nil bounds are not limited.
+type BudgetRange struct {
+	Min      *int64
+	Max      *int64
+	Currency models.Currency
 }
 
 type GetTendersOptFunc func(*GetTendersOptions) error
@@ -20,18 +77,90 @@ func WithPaginationOptions(paginationOptions *PaginationOptions) GetTendersOptFu
 	}
 }
 
-func WithServiceType(serviceType models.TenderType) GetTendersOptFunc {
+func WithServiceType(serviceType models.CategoryCode) GetTendersOptFunc {
 	return func(o *GetTendersOptions) error {
 		o.ServiceTypes = append(o.ServiceTypes, serviceType)
 		return nil
 	}
 }
 
+func WithTendersSortOrder(sortOrder TenderSortOrder) GetTendersOptFunc {
+	return func(o *GetTendersOptions) error {
+		o.SortOrder = sortOrder
+		return nil
+	}
+}
+
+func WithTenderStatus(status models.TenderStatus) GetTendersOptFunc {
+	return func(o *GetTendersOptions) error {
+		o.Statuses = append(o.Statuses, status)
+		return nil
+	}
+}
+
+func WithOrganizationID(organizationID models.ID) GetTendersOptFunc {
+	return func(o *GetTendersOptions) error {
+		o.OrganizationID = &organizationID
+		return nil
+	}
+}
+
+func WithTendersCreatedAt(createdAt TimeRange) GetTendersOptFunc {
+	return func(o *GetTendersOptions) error {
+		o.CreatedAt = createdAt
+		return nil
+	}
+}
+
+func WithSubmissionDeadline(submissionDeadline TimeRange) GetTendersOptFunc {
+	return func(o *GetTendersOptions) error {
+		o.SubmissionDeadline = submissionDeadline
+		return nil
+	}
+}
+
+func WithDecisionDeadline(decisionDeadline TimeRange) GetTendersOptFunc {
+	return func(o *GetTendersOptions) error {
+		o.DecisionDeadline = decisionDeadline
+		return nil
+	}
+}
+
+func WithTendersVersion(version VersionRange) GetTendersOptFunc {
+	return func(o *GetTendersOptions) error {
+		o.Version = version
+		return nil
+	}
+}
+
+func WithViewer(viewer models.TenderViewer) GetTendersOptFunc {
+	return func(o *GetTendersOptions) error {
+		o.Viewer = &viewer
+		return nil
+	}
+}
+
+func WithBudgetRange(min, max *int64, currency models.Currency) GetTendersOptFunc {
+	return func(o *GetTendersOptions) error {
+		if min != nil && max != nil && *min > *max {
+			return fmt.Errorf("minimum budget is greater than maximum: %w", domain.ErrInvalidArgument)
+		}
+
+		o.Budget = &BudgetRange{
+			Min:      min,
+			Max:      max,
+			Currency: currency,
+		}
+		return nil
+	}
+}
+
 func NewGetTendersOptions(options ...GetTendersOptFunc) (*GetTendersOptions, error) {
 	paginationOpts, _ := NewPaginationOptions()
 	opts := &GetTendersOptions{
 		PaginationOptions: paginationOpts,
-		ServiceTypes:      make([]models.TenderType, 0),
+		SortOrder:         TenderSortCreatedAtDesc,
+		ServiceTypes:      make([]models.CategoryCode, 0),
 	}
 	for _, opt := range options {
 		if err := opt(opts); err != nil {
@@ -43,23 +172,54 @@ func NewGetTendersOptions(options ...GetTendersOptFunc) (*GetTendersOptions, err
 
 type TenderUseCaseInterface interface {
 	GetAll(ctx context.Context, options ...GetTendersOptFunc) ([]models.Tender, error)
+	Search(ctx context.Context, text string, options ...GetTendersOptFunc) ([]models.TenderSearchResult, error)
 	Create(ctx context.Context, data *dto.CreateTenderDTO) (models.Tender, error)
-	GetMy(ctx context.Context, username string, options ...PaginationOptFunc) ([]models.Tender, error)
-	GetStatus(ctx context.Context, id models.ID, username string) (models.TenderStatus, error)
-	SetStatus(ctx context.Context, id models.ID, username string, status models.TenderStatus) (models.Tender, error)
-	Update(ctx context.Context, id models.ID, username string, data *dto.UpdateTenderDTO) (models.Tender, error)
-	Rollback(ctx context.Context, id models.ID, username string, version int) (models.Tender, error)
+	GetMy(ctx context.Context, options ...GetTendersOptFunc) ([]models.Tender, error)
+	// GetStatus returns the status and the current revision of the tender
+	GetStatus(ctx context.Context, id models.ID) (models.TenderStatus, int, error)
+	// SetStatus, Update and Rollback fail with ErrPreconditionFailed unless the current revision is
+	// one of expectedRevisions, nil expectedRevisions skip the check
+	SetStatus(ctx context.Context, id models.ID, status models.TenderStatus, expectedRevisions []int) (models.Tender, error)
+	GetAllowedStatuses(ctx context.Context, id models.ID) (models.TenderStatus, []models.TenderStatus, error)
+	Update(ctx context.Context, id models.ID, data *dto.UpdateTenderDTO, expectedRevisions []int) (models.Tender, error)
+	Rollback(ctx context.Context, id models.ID, version int, expectedRevisions []int) (models.Tender, error)
+	// GetVersions returns the tender history without the lots, the latest version first
+	GetVersions(ctx context.Context, id models.ID, options ...PaginationOptFunc) ([]models.Tender, error)
+	GetVersion(ctx context.Context, id models.ID, version int) (models.Tender, error)
+	// Diff returns the versioned fields that changed from one version to the other
+	Diff(ctx context.Context, id models.ID, from, to int) ([]models.FieldChange, error)
+	CancelLot(ctx context.Context, tenderID, lotID models.ID) (models.Tender, error)
+	SetVisibility(ctx context.Context, id models.ID, visibility models.TenderVisibility) (models.Tender, error)
+	GetInvitees(ctx context.Context, id models.ID) ([]models.TenderInvitee, error)
+	// AddInvitees and RemoveInvitee return the invitees left after the change
+	AddInvitees(ctx context.Context, id models.ID, invitees []models.TenderInvitee) ([]models.TenderInvitee, error)
+	RemoveInvitee(ctx context.Context, id models.ID, invitee models.TenderInvitee) ([]models.TenderInvitee, error)
+	CloseExpired(ctx context.Context, now time.Time) (int, error)
+	UploadAttachment(ctx context.Context, id models.ID, data *dto.UploadAttachmentDTO) (models.Attachment, error)
+	GetAttachments(ctx context.Context, id models.ID, version *int) ([]models.Attachment, error)
+	// DownloadAttachment returns the attachment and its content, the caller closes the content
+	DownloadAttachment(ctx context.Context, id, attachmentID models.ID) (models.Attachment, io.ReadCloser, error)
 }
 
 type TenderRepository interface {
 	Create(ctx context.Context, data *models.Tender) (models.Tender, error)
 	GetByID(ctx context.Context, id models.ID) (models.Tender, error)
+	LockByID(ctx context.Context, id models.ID) (models.Tender, error)
 	GetAll(ctx context.Context, options ...GetTendersOptFunc) ([]models.Tender, error)
-	GetByOrganizationID(ctx context.Context, organizationID models.ID, options ...PaginationOptFunc) ([]models.Tender, error)
+	// Search returns the tenders matching the full-text query ranked by relevance
+	Search(ctx context.Context, text string, options ...GetTendersOptFunc) ([]models.TenderSearchResult, error)
+	GetExpired(ctx context.Context, now time.Time, options ...PaginationOptFunc) ([]models.Tender, error)
 	SetStatus(ctx context.Context, id models.ID, status models.TenderStatus) (models.Tender, error)
+	SetLotStatus(ctx context.Context, id models.ID, status models.LotStatus, awardedBidID *models.ID) error
 	Update(ctx context.Context, id models.ID, data *models.Tender) (models.Tender, error)
 	GetVersions(ctx context.Context, id models.ID, options ...PaginationOptFunc) ([]models.Tender, error)
 	GetSpecificVersion(ctx context.Context, id models.ID, version int) (models.Tender, error)
-	Rollback(ctx context.Context, id models.ID, version int) (models.Tender, error)
+	SetVisibility(ctx context.Context, id models.ID, visibility models.TenderVisibility) (models.Tender, error)
+	GetInvitees(ctx context.Context, id models.ID) ([]models.TenderInvitee, error)
+	// AddInvitees skips the invitees already invited
+	AddInvitees(ctx context.Context, id models.ID, invitees []models.TenderInvitee) error
+	RemoveInvitee(ctx context.Context, id models.ID, invitee models.TenderInvitee) error
+	// Rollback copies the version with its lots into a new current version created by the author
+	Rollback(ctx context.Context, id models.ID, version int, author *models.VersionAuthor) (models.Tender, error)
 	GetLatestVersionNumber(ctx context.Context, id models.ID) (int, error)
 }
@@ -0,0 +1,9 @@
+package abstraction
+
+import "context"
+
+// TransactionManager runs several repository calls as one unit of work.
+// Repositories called with the context passed to fn take part in the transaction.
+type TransactionManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
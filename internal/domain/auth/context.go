@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+)
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated principal
+func WithPrincipal(ctx context.Context, principal models.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal stored in ctx
+func PrincipalFromContext(ctx context.Context) (models.Principal, error) {
+	principal, ok := ctx.Value(principalKey{}).(models.Principal)
+	if !ok {
+		return models.Principal{}, fmt.Errorf("no authenticated principal in context: %w", domain.ErrUnauthorized)
+	}
+
+	return principal, nil
+}
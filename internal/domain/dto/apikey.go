@@ -0,0 +1,13 @@
+package dto
+
+import (
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+type CreateAPIKeyDTO struct {
+	OrganizationID models.ID
+	Name           string
+	Scopes         []models.APIKeyScope
+	ExpiresAt      *time.Time
+}
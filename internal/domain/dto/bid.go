@@ -16,11 +16,15 @@ type CreateBidDTO struct {
 	Name        string
 	Description string
 	TenderID    models.ID
-	AuthorID    models.ID
-	AuthorType  models.BidAuthorType
+	// LotID may be omitted for single-lot tenders
+	LotID      *models.ID
+	AuthorID   models.ID
+	AuthorType models.BidAuthorType
+	Price      *models.Money
 }
 
 type UpdateBidDTO struct {
 	Name        *string
 	Description *string
+	Price       *models.Money
 }
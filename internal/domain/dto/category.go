@@ -0,0 +1,8 @@
+package dto
+
+type ImportCategoryDTO struct {
+	Code string
+	// ParentCode is empty for root categories
+	ParentCode string
+	Name       string
+}
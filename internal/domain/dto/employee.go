@@ -0,0 +1,29 @@
+package dto
+
+import "tenderSystem/internal/domain/models"
+
+type CreateEmployeeDTO struct {
+	Username  string
+	FirstName string
+	LastName  string
+	Password  string
+	Role      models.OrganizationRole
+}
+
+type UpdateEmployeeDTO struct {
+	FirstName *string
+	LastName  *string
+}
+
+type CreateInvitationDTO struct {
+	OrganizationID models.ID
+	Role           models.OrganizationRole
+}
+
+type RedeemInvitationDTO struct {
+	Token     string
+	Username  string
+	FirstName string
+	LastName  string
+	Password  string
+}
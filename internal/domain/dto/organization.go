@@ -0,0 +1,15 @@
+package dto
+
+import "tenderSystem/internal/domain/models"
+
+type CreateOrganizationDTO struct {
+	Name        string
+	Description string
+	Type        models.OrganizationType
+}
+
+type UpdateOrganizationDTO struct {
+	Name        *string
+	Description *string
+	Type        *models.OrganizationType
+}
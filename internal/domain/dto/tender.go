@@ -1,6 +1,10 @@
 package dto
 
-import "tenderSystem/internal/domain/models"
+import (
+	"io"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
 
 //type TenderResponseDTO struct {
 //	ID          string
@@ -13,15 +17,53 @@ import "tenderSystem/internal/domain/models"
 //}
 
 type CreateTenderDTO struct {
-	Name            string
-	Description     string
-	ServiceType     models.TenderType
-	OrganizationID  models.ID
-	CreatorUsername string
+	Name           string
+	Description    string
+	ServiceType    models.CategoryCode
+	OrganizationID models.ID
+	QuorumPolicy   *models.QuorumPolicy
+	Budget         *models.Money
+	// Lots of the tender, a single lot repeating the tender is created when empty
+	Lots []CreateLotDTO
+	// Visibility is public when not set
+	Visibility *models.TenderVisibility
+	Invitees   []models.TenderInvitee
+
+	SubmissionDeadline *time.Time
+	BidOpeningAt       *time.Time
+	DecisionDeadline   *time.Time
 }
 
 type UpdateTenderDTO struct {
 	Name        *string
 	Description *string
-	ServiceType *models.TenderType
+	ServiceType *models.CategoryCode
+	Budget      *models.Money
+	// Lots are edited when ID is set and added otherwise
+	Lots []UpdateLotDTO
+
+	SubmissionDeadline *time.Time
+	BidOpeningAt       *time.Time
+	DecisionDeadline   *time.Time
+}
+
+type CreateLotDTO struct {
+	Name        string
+	Description string
+	Budget      *models.Money
+}
+
+type UpdateLotDTO struct {
+	ID          *models.ID
+	Name        *string
+	Description *string
+	Budget      *models.Money
+}
+
+type UploadAttachmentDTO struct {
+	FileName    string
+	ContentType string
+	// Size is -1 when unknown
+	Size    int64
+	Content io.Reader
 }
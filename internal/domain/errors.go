@@ -18,6 +18,12 @@ var (
 	// ErrForbidden is an error for forbidden
 	ErrForbidden = errors.New("forbidden")
 
+	// ErrPreconditionFailed is an error for a change based on a stale version
+	ErrPreconditionFailed = errors.New("precondition failed")
+
+	// ErrUnprocessable is an error for a well-formed request that cannot be processed
+	ErrUnprocessable = errors.New("unprocessable entity")
+
 	// ErrInternal is an error for internal server error
 	ErrInternal = errors.New("internal server error")
 )
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"tenderSystem/internal/domain"
+	"time"
+)
+
+type APIKeyScope string
+
+const (
+	APIKeyScopeUnknown      APIKeyScope = "unknown"
+	APIKeyScopeTendersRead  APIKeyScope = "tenders:read"
+	APIKeyScopeTendersWrite APIKeyScope = "tenders:write"
+	APIKeyScopeBidsRead     APIKeyScope = "bids:read"
+	APIKeyScopeBidsWrite    APIKeyScope = "bids:write"
+)
+
+func (a APIKeyScope) String() string {
+	return string(a)
+}
+
+func NewAPIKeyScope(a string) (APIKeyScope, error) {
+	switch a {
+	case "tenders:read":
+		return APIKeyScopeTendersRead, nil
+	case "tenders:write":
+		return APIKeyScopeTendersWrite, nil
+	case "bids:read":
+		return APIKeyScopeBidsRead, nil
+	case "bids:write":
+		return APIKeyScopeBidsWrite, nil
+	default:
+		return APIKeyScopeUnknown, fmt.Errorf("unknown API key scope: %w", domain.ErrInvalidArgument)
+	}
+}
+
+type APIKey struct {
+	ID             ID
+	OrganizationID ID
+	Name           string
+	Prefix         string
+	KeyHash        string
+	Scopes         []APIKeyScope
+	ExpiresAt      *time.Time
+	LastUsedAt     *time.Time
+	CreatedBy      ID
+	CreatedAt      time.Time
+	RevokedAt      *time.Time
+}
+
+func NewAPIKey(organizationID ID, name, prefix, keyHash string, scopes []APIKeyScope, expiresAt *time.Time, createdBy ID) APIKey {
+	return APIKey{
+		ID:             NewID(),
+		OrganizationID: organizationID,
+		Name:           name,
+		Prefix:         prefix,
+		KeyHash:        keyHash,
+		Scopes:         scopes,
+		ExpiresAt:      expiresAt,
+		CreatedBy:      createdBy,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func (a APIKey) IsActive(now time.Time) bool {
+	if a.RevokedAt != nil {
+		return false
+	}
+
+	return a.ExpiresAt == nil || a.ExpiresAt.After(now)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type AttachmentOwnerType string
+
+const (
+	AttachmentOwnerTypeTender AttachmentOwnerType = "tender"
+	AttachmentOwnerTypeBid    AttachmentOwnerType = "bid"
+)
+
+func (a AttachmentOwnerType) String() string {
+	return string(a)
+}
+
+// Attachment is a document attached to a tender or bid version. The content is kept
+// in the blob storage under StorageKey, the versions it belongs to are linked separately.
+type Attachment struct {
+	ID          ID
+	OwnerType   AttachmentOwnerType
+	OwnerID     ID
+	FileName    string
+	ContentType string
+	Size        int64
+	// SHA256 is the hex encoded checksum of the content
+	SHA256     string
+	StorageKey string
+	// UploadedBy is empty for uploads made with an API key
+	UploadedBy *ID
+	CreatedAt  time.Time
+}
+
+func NewAttachment(ownerType AttachmentOwnerType, ownerID ID, fileName, contentType string, size int64, uploadedBy *ID) Attachment {
+	id := NewID()
+	return Attachment{
+		ID:          id,
+		OwnerType:   ownerType,
+		OwnerID:     ownerID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  fmt.Sprintf("%ss/%s/%s", ownerType, ownerID, id),
+		UploadedBy:  uploadedBy,
+		CreatedAt:   time.Now(),
+	}
+}
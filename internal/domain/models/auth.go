@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"tenderSystem/internal/domain"
+	"time"
+)
+
+type EmployeeCredentials struct {
+	EmployeeID          ID
+	PasswordHash        string
+	FailedLoginAttempts int
+	LockedUntil         *time.Time
+}
+
+func (c EmployeeCredentials) IsLocked(now time.Time) bool {
+	return c.LockedUntil != nil && c.LockedUntil.After(now)
+}
+
+// ErrInvalidCredentials is the only login error the caller sees for a wrong username or password
+var ErrInvalidCredentials = fmt.Errorf("invalid username or password: %w", domain.ErrUnauthorized)
+
+// AccountLockedError rejects a login to a locked account. It reads as ErrInvalidCredentials,
+// so the response does not tell existing usernames apart, the lockout is for the server logs.
+type AccountLockedError struct {
+	Username    string
+	LockedUntil time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return ErrInvalidCredentials.Error()
+}
+
+func (e *AccountLockedError) Unwrap() error {
+	return ErrInvalidCredentials
+}
+
+type RefreshToken struct {
+	ID         ID
+	EmployeeID ID
+	FamilyID   ID
+	TokenHash  string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+func NewRefreshToken(employeeID, familyID ID, tokenHash string, ttl time.Duration) RefreshToken {
+	now := time.Now()
+	return RefreshToken{
+		ID:         NewID(),
+		EmployeeID: employeeID,
+		FamilyID:   familyID,
+		TokenHash:  tokenHash,
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+	}
+}
+
+func (r RefreshToken) IsRevoked() bool {
+	return r.RevokedAt != nil
+}
+
+func (r RefreshToken) IsExpired(now time.Time) bool {
+	return !r.ExpiresAt.After(now)
+}
+
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
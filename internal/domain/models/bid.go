@@ -64,29 +64,112 @@ func NewBidAuthorType(b string) (BidAuthorType, error) {
 type Bid struct {
 	ID          ID
 	TenderID    ID
+	LotID       ID
 	Status      BidStatus
 	AuthorType  BidAuthorType
 	AuthorID    ID
 	Name        string
 	Description string
-	Version     int
-	CreatedAt   time.Time
+	// Price is the offered price of the bid version, nil if not set
+	Price   *Money
+	Version int
+	// VersionAuthor created the current version, nil when it was not recorded
+	VersionAuthor *VersionAuthor
+	// RolledBackFrom is the version the current version was copied from by a rollback
+	RolledBackFrom *int
+	// Revision changes with every change of the bid, new versions and status changes alike
+	Revision  int
+	CreatedAt time.Time
 }
 
-func NewBid(tenderID ID, authorType BidAuthorType, authorID ID, name, description string) Bid {
+func NewBid(tenderID, lotID ID, authorType BidAuthorType, authorID ID, name, description string, price *Money) Bid {
 	return Bid{
 		ID:          NewID(),
 		TenderID:    tenderID,
+		LotID:       lotID,
 		Status:      BidStatusCreated,
 		AuthorType:  authorType,
 		AuthorID:    authorID,
 		Name:        name,
 		Description: description,
+		Price:       price,
 		Version:     1,
+		Revision:    1,
 		CreatedAt:   time.Now(),
 	}
 }
 
+// bidAuthorTransitions are the moves available to the bid author
+var bidAuthorTransitions = map[BidStatus][]BidStatus{
+	BidStatusCreated:   {BidStatusPublished, BidStatusCanceled},
+	BidStatusPublished: {BidStatusCanceled},
+}
+
+// bidDecisionTransitions are the moves made by the tender organization's decisions
+var bidDecisionTransitions = map[BidStatus][]BidStatus{
+	BidStatusPublished: {BidStatusApproved, BidStatusRejected},
+}
+
+func containsBidStatus(statuses []BidStatus, status BidStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuthorNextStatuses returns the statuses the bid author can move the bid to
+func (b BidStatus) AuthorNextStatuses() []BidStatus {
+	return bidAuthorTransitions[b]
+}
+
+// DecisionNextStatuses returns the statuses a decision can move the bid to
+func (b BidStatus) DecisionNextStatuses() []BidStatus {
+	return bidDecisionTransitions[b]
+}
+
+func (b *Bid) transition(table map[BidStatus][]BidStatus, next BidStatus) error {
+	if !containsBidStatus(table[b.Status], next) {
+		return fmt.Errorf("bid cannot move from %s to %s: %w", b.Status, next, domain.ErrInvalidArgument)
+	}
+
+	b.Status = next
+	return nil
+}
+
+func (b *Bid) Publish() error {
+	return b.transition(bidAuthorTransitions, BidStatusPublished)
+}
+
+func (b *Bid) Cancel() error {
+	return b.transition(bidAuthorTransitions, BidStatusCanceled)
+}
+
+func (b *Bid) Approve() error {
+	return b.transition(bidDecisionTransitions, BidStatusApproved)
+}
+
+func (b *Bid) Reject() error {
+	return b.transition(bidDecisionTransitions, BidStatusRejected)
+}
+
+// TransitionTo applies a status change requested by the bid author.
+// Approval and rejection only happen through decisions.
+func (b *Bid) TransitionTo(status BidStatus) error {
+	switch status {
+	case BidStatusPublished:
+		return b.Publish()
+	case BidStatusCanceled:
+		return b.Cancel()
+	case BidStatusApproved, BidStatusRejected:
+		return fmt.Errorf("bid status %s can only be set by a decision of the tender organization: %w", status, domain.ErrInvalidArgument)
+	default:
+		return fmt.Errorf("bid cannot move from %s to %s: %w", b.Status, status, domain.ErrInvalidArgument)
+	}
+}
+
 type BidFeedback struct {
 	ID          ID
 	BidID       ID
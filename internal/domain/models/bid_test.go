@@ -0,0 +1,75 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"tenderSystem/internal/domain"
+	"testing"
+)
+
+var bidStatuses = []BidStatus{BidStatusCreated, BidStatusPublished, BidStatusCanceled, BidStatusApproved, BidStatusRejected}
+
+func TestBidAuthorTransitions(t *testing.T) {
+	allowed := map[BidStatus][]BidStatus{
+		BidStatusCreated:   {BidStatusPublished, BidStatusCanceled},
+		BidStatusPublished: {BidStatusCanceled},
+	}
+
+	for _, from := range bidStatuses {
+		if got := from.AuthorNextStatuses(); !slices.Equal(got, allowed[from]) {
+			t.Errorf("%s: author next statuses are %v, want %v", from, got, allowed[from])
+		}
+
+		for _, to := range bidStatuses {
+			bid := Bid{Status: from}
+			err := bid.TransitionTo(to)
+
+			if slices.Contains(allowed[from], to) {
+				if err != nil || bid.Status != to {
+					t.Errorf("%s -> %s: got %v and status %s, want the move", from, to, err, bid.Status)
+				}
+				continue
+			}
+
+			if !errors.Is(err, domain.ErrInvalidArgument) {
+				t.Errorf("%s -> %s: got %v, want invalid argument", from, to, err)
+			}
+			if bid.Status != from {
+				t.Errorf("%s -> %s: status changed to %s by a refused move", from, to, bid.Status)
+			}
+		}
+	}
+}
+
+func TestBidDecisionTransitions(t *testing.T) {
+	decisions := map[BidStatus]func(*Bid) error{
+		BidStatusApproved: (*Bid).Approve,
+		BidStatusRejected: (*Bid).Reject,
+	}
+
+	for _, from := range bidStatuses {
+		for to, decide := range decisions {
+			bid := Bid{Status: from}
+			err := decide(&bid)
+
+			if from == BidStatusPublished {
+				if err != nil || bid.Status != to {
+					t.Errorf("%s -> %s: got %v and status %s, want the move", from, to, err, bid.Status)
+				}
+				continue
+			}
+
+			if !errors.Is(err, domain.ErrInvalidArgument) {
+				t.Errorf("%s -> %s: got %v, want invalid argument", from, to, err)
+			}
+		}
+
+		want := []BidStatus(nil)
+		if from == BidStatusPublished {
+			want = []BidStatus{BidStatusApproved, BidStatusRejected}
+		}
+		if got := from.DecisionNextStatuses(); !slices.Equal(got, want) {
+			t.Errorf("%s: decision next statuses are %v, want %v", from, got, want)
+		}
+	}
+}
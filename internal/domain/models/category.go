@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"tenderSystem/internal/domain"
+	"time"
+)
+
+// CategoryCode identifies a procurement category, e.g. an OKPD2 code such as 41.20.1
+type CategoryCode string
+
+func (c CategoryCode) String() string {
+	return string(c)
+}
+
+var categoryCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// NewCategoryCode normalizes the code to lower case and checks its format, not that the category exists
+func NewCategoryCode(c string) (CategoryCode, error) {
+	code := strings.ToLower(strings.TrimSpace(c))
+	if !categoryCodePattern.MatchString(code) {
+		return "", fmt.Errorf("invalid category code %q: %w", c, domain.ErrInvalidArgument)
+	}
+
+	return CategoryCode(code), nil
+}
+
+// Root categories that replaced the former fixed tender types
+const (
+	CategoryConstruction CategoryCode = "construction"
+	CategoryDelivery     CategoryCode = "delivery"
+	CategoryManufacture  CategoryCode = "manufacture"
+)
+
+// Category is a node of the procurement classifier tree
+type Category struct {
+	Code CategoryCode
+	// ParentCode is nil for root categories
+	ParentCode *CategoryCode
+	Name       string
+	CreatedAt  time.Time
+}
+
+func NewCategory(code CategoryCode, parentCode *CategoryCode, name string) (Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Category{}, fmt.Errorf("name of category %s is required: %w", code, domain.ErrInvalidArgument)
+	}
+
+	if parentCode != nil && *parentCode == code {
+		return Category{}, fmt.Errorf("category %s can't be its own parent: %w", code, domain.ErrInvalidArgument)
+	}
+
+	return Category{
+		Code:       code,
+		ParentCode: parentCode,
+		Name:       name,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func (c Category) IsRoot() bool {
+	return c.ParentCode == nil
+}
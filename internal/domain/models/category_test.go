@@ -0,0 +1,54 @@
+package models
+
+import (
+	"errors"
+	"tenderSystem/internal/domain"
+	"testing"
+)
+
+func TestNewCategoryCode(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    CategoryCode
+		wantErr error
+	}{
+		{code: "41.20.1", want: "41.20.1"},
+		{code: " Construction ", want: "construction"},
+		{code: "it_services-2", want: "it_services-2"},
+		{code: "", wantErr: domain.ErrInvalidArgument},
+		{code: ".41", wantErr: domain.ErrInvalidArgument},
+		{code: "41 20", wantErr: domain.ErrInvalidArgument},
+		{code: "строительство", wantErr: domain.ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, err := NewCategoryCode(tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCategory(t *testing.T) {
+	code := CategoryCode("41")
+
+	if _, err := NewCategory(code, nil, "  "); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v without a name, want invalid argument", err)
+	}
+	if _, err := NewCategory(code, &code, "Здания"); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v for its own parent, want invalid argument", err)
+	}
+
+	category, err := NewCategory(code, nil, " Здания ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category.Name != "Здания" || !category.IsRoot() {
+		t.Fatalf("got %#v, want a root category with a trimmed name", category)
+	}
+}
@@ -0,0 +1,248 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type WordChangeType string
+
+const (
+	WordChangeEqual  WordChangeType = "equal"
+	WordChangeInsert WordChangeType = "insert"
+	WordChangeDelete WordChangeType = "delete"
+)
+
+// WordChange is a run of words kept, inserted or deleted between two texts, the text keeps
+// the whitespace preceding every word
+type WordChange struct {
+	Type WordChangeType
+	Text string
+}
+
+// FieldChange is a versioned field that differs between two versions. Old and New hold
+// the field values: strings, a lot number, a CategoryCode, an optional *Money or the *Lot
+// added or removed by the version, in which case the other value is nil.
+type FieldChange struct {
+	Field string
+	Old   any
+	New   any
+	// Words is the word-level diff of long text fields, nil for the other fields
+	Words []WordChange
+}
+
+// DiffTenderVersions returns the versioned fields of the tender that changed from one version to the other
+func DiffTenderVersions(from, to Tender) []FieldChange {
+	var changes []FieldChange
+
+	if from.Name != to.Name {
+		changes = append(changes, FieldChange{Field: "name", Old: from.Name, New: to.Name})
+	}
+
+	if from.Description != to.Description {
+		changes = append(changes, FieldChange{
+			Field: "description", Old: from.Description, New: to.Description,
+			Words: DiffWords(from.Description, to.Description),
+		})
+	}
+
+	if from.ServiceType != to.ServiceType {
+		changes = append(changes, FieldChange{Field: "serviceType", Old: from.ServiceType, New: to.ServiceType})
+	}
+
+	if !moneyEqual(from.Budget, to.Budget) {
+		changes = append(changes, FieldChange{Field: "budget", Old: from.Budget, New: to.Budget})
+	}
+
+	return append(changes, diffLots(from.Lots, to.Lots)...)
+}
+
+// diffLots matches the lots of two versions by ID. Added and removed lots are reported whole as
+// "lots[<number>]", the changed fields of the other lots as "lots[<number>].<field>" by the new number.
+func diffLots(from, to []Lot) []FieldChange {
+	var changes []FieldChange
+
+	fromLots := make(map[ID]Lot, len(from))
+	for _, lot := range from {
+		fromLots[lot.ID] = lot
+	}
+
+	toLots := make(map[ID]struct{}, len(to))
+	for _, lot := range to {
+		toLots[lot.ID] = struct{}{}
+
+		old, ok := fromLots[lot.ID]
+		if !ok {
+			changes = append(changes, FieldChange{Field: lotField(lot.Number, ""), New: &lot})
+			continue
+		}
+
+		if old.Number != lot.Number {
+			changes = append(changes, FieldChange{Field: lotField(lot.Number, "number"), Old: old.Number, New: lot.Number})
+		}
+
+		if old.Name != lot.Name {
+			changes = append(changes, FieldChange{Field: lotField(lot.Number, "name"), Old: old.Name, New: lot.Name})
+		}
+
+		if old.Description != lot.Description {
+			changes = append(changes, FieldChange{
+				Field: lotField(lot.Number, "description"), Old: old.Description, New: lot.Description,
+				Words: DiffWords(old.Description, lot.Description),
+			})
+		}
+
+		if !moneyEqual(old.Budget, lot.Budget) {
+			changes = append(changes, FieldChange{Field: lotField(lot.Number, "budget"), Old: old.Budget, New: lot.Budget})
+		}
+	}
+
+	for _, lot := range from {
+		if _, ok := toLots[lot.ID]; !ok {
+			changes = append(changes, FieldChange{Field: lotField(lot.Number, ""), Old: &lot})
+		}
+	}
+
+	return changes
+}
+
+func lotField(number int, field string) string {
+	if field == "" {
+		return fmt.Sprintf("lots[%d]", number)
+	}
+
+	return fmt.Sprintf("lots[%d].%s", number, field)
+}
+
+// DiffBidVersions returns the versioned fields of the bid that changed from one version to the other
+func DiffBidVersions(from, to Bid) []FieldChange {
+	var changes []FieldChange
+
+	if from.Name != to.Name {
+		changes = append(changes, FieldChange{Field: "name", Old: from.Name, New: to.Name})
+	}
+
+	if from.Description != to.Description {
+		changes = append(changes, FieldChange{
+			Field: "description", Old: from.Description, New: to.Description,
+			Words: DiffWords(from.Description, to.Description),
+		})
+	}
+
+	if !moneyEqual(from.Price, to.Price) {
+		changes = append(changes, FieldChange{Field: "price", Old: from.Price, New: to.Price})
+	}
+
+	return changes
+}
+
+func moneyEqual(a, b *Money) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+var wordPattern = regexp.MustCompile(`\s*\S+`)
+
+// maxDiffCells bounds the LCS table, longer rewrites are reported as a deletion and an insertion
+const maxDiffCells = 1 << 20
+
+// DiffWords returns the word-level diff of two texts by their longest common subsequence of words.
+// Words are compared without the surrounding whitespace.
+func DiffWords(oldText, newText string) []WordChange {
+	oldWords := wordPattern.FindAllString(oldText, -1)
+	newWords := wordPattern.FindAllString(newText, -1)
+
+	var changes []WordChange
+	add := func(changeType WordChangeType, word string) {
+		if n := len(changes); n > 0 && changes[n-1].Type == changeType {
+			changes[n-1].Text += word
+			return
+		}
+		changes = append(changes, WordChange{Type: changeType, Text: word})
+	}
+
+	// the common prefix and suffix need no table
+	prefix := 0
+	for prefix < len(oldWords) && prefix < len(newWords) && sameWord(oldWords[prefix], newWords[prefix]) {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(oldWords)-prefix && suffix < len(newWords)-prefix &&
+		sameWord(oldWords[len(oldWords)-1-suffix], newWords[len(newWords)-1-suffix]) {
+		suffix++
+	}
+
+	for _, word := range newWords[:prefix] {
+		add(WordChangeEqual, word)
+	}
+
+	oldMiddle := oldWords[prefix : len(oldWords)-suffix]
+	newMiddle := newWords[prefix : len(newWords)-suffix]
+
+	if len(oldMiddle)*len(newMiddle) > maxDiffCells {
+		for _, word := range oldMiddle {
+			add(WordChangeDelete, word)
+		}
+		for _, word := range newMiddle {
+			add(WordChangeInsert, word)
+		}
+	} else {
+		diffMiddle(oldMiddle, newMiddle, add)
+	}
+
+	for _, word := range newWords[len(newWords)-suffix:] {
+		add(WordChangeEqual, word)
+	}
+
+	return changes
+}
+
+// diffMiddle walks the LCS table of the words, lcs[i][j] is the LCS length of oldWords[i:] and newWords[j:]
+func diffMiddle(oldWords, newWords []string, add func(WordChangeType, string)) {
+	lcs := make([][]int, len(oldWords)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newWords)+1)
+	}
+
+	for i := len(oldWords) - 1; i >= 0; i-- {
+		for j := len(newWords) - 1; j >= 0; j-- {
+			if sameWord(oldWords[i], newWords[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(oldWords) && j < len(newWords) {
+		switch {
+		case sameWord(oldWords[i], newWords[j]):
+			add(WordChangeEqual, newWords[j])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(WordChangeDelete, oldWords[i])
+			i++
+		default:
+			add(WordChangeInsert, newWords[j])
+			j++
+		}
+	}
+
+	for ; i < len(oldWords); i++ {
+		add(WordChangeDelete, oldWords[i])
+	}
+	for ; j < len(newWords); j++ {
+		add(WordChangeInsert, newWords[j])
+	}
+}
+
+func sameWord(a, b string) bool {
+	return strings.TrimSpace(a) == strings.TrimSpace(b)
+}
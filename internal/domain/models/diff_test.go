@@ -0,0 +1,217 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffWords(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []WordChange
+	}{
+		{name: "both empty", old: "", new: "", want: nil},
+		{
+			name: "identical",
+			old:  "supply of office paper",
+			new:  "supply of office paper",
+			want: []WordChange{{Type: WordChangeEqual, Text: "supply of office paper"}},
+		},
+		{
+			name: "from empty",
+			old:  "",
+			new:  "office paper",
+			want: []WordChange{{Type: WordChangeInsert, Text: "office paper"}},
+		},
+		{
+			name: "to empty",
+			old:  "office paper",
+			new:  "",
+			want: []WordChange{{Type: WordChangeDelete, Text: "office paper"}},
+		},
+		{
+			name: "prefix added",
+			old:  "office paper",
+			new:  "supply of office paper",
+			want: []WordChange{
+				{Type: WordChangeInsert, Text: "supply of"},
+				{Type: WordChangeEqual, Text: " office paper"},
+			},
+		},
+		{
+			name: "suffix added",
+			old:  "office paper",
+			new:  "office paper and toner",
+			want: []WordChange{
+				{Type: WordChangeEqual, Text: "office paper"},
+				{Type: WordChangeInsert, Text: " and toner"},
+			},
+		},
+		{
+			name: "middle replaced",
+			old:  "supply of office paper",
+			new:  "supply of printer paper",
+			want: []WordChange{
+				{Type: WordChangeEqual, Text: "supply of"},
+				{Type: WordChangeDelete, Text: " office"},
+				{Type: WordChangeInsert, Text: " printer"},
+				{Type: WordChangeEqual, Text: " paper"},
+			},
+		},
+		{
+			name: "words moved",
+			old:  "a b c d",
+			new:  "a c b d",
+			want: []WordChange{
+				{Type: WordChangeEqual, Text: "a"},
+				{Type: WordChangeDelete, Text: " b"},
+				{Type: WordChangeEqual, Text: " c"},
+				{Type: WordChangeInsert, Text: " b"},
+				{Type: WordChangeEqual, Text: " d"},
+			},
+		},
+		{
+			name: "whitespace only",
+			old:  "office  paper\n",
+			new:  "office\tpaper",
+			want: []WordChange{{Type: WordChangeEqual, Text: "office\tpaper"}},
+		},
+		{
+			name: "leading whitespace",
+			old:  "office paper",
+			new:  "  office paper",
+			want: []WordChange{{Type: WordChangeEqual, Text: "  office paper"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffWords(tt.old, tt.new)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffWordsFallback(t *testing.T) {
+	// both middles are longer than the square root of maxDiffCells and share a word
+	// that the LCS would keep, the fallback reports them as one deletion and one insertion
+	const n = 1025
+
+	oldWords := make([]string, n)
+	newWords := make([]string, n)
+	for i := range n {
+		oldWords[i] = fmt.Sprintf("old%d", i)
+		newWords[i] = fmt.Sprintf("new%d", i)
+	}
+	oldWords[n/2] = "shared"
+	newWords[n/2] = "shared"
+
+	oldMiddle := strings.Join(oldWords, " ")
+	newMiddle := strings.Join(newWords, " ")
+
+	got := DiffWords("begin "+oldMiddle+" end", "begin "+newMiddle+" end")
+	want := []WordChange{
+		{Type: WordChangeEqual, Text: "begin"},
+		{Type: WordChangeDelete, Text: " " + oldMiddle},
+		{Type: WordChangeInsert, Text: " " + newMiddle},
+		{Type: WordChangeEqual, Text: " end"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %d runs, want a deletion and an insertion between the equal prefix and suffix", len(got))
+	}
+}
+
+func TestDiffTenderVersionsLots(t *testing.T) {
+	budget := func(amount int64) *Money {
+		return &Money{Amount: amount, Currency: "RUB"}
+	}
+
+	kept := Lot{ID: NewID(), Number: 1, Name: "paper", Description: "office paper", Budget: budget(100)}
+	removed := Lot{ID: NewID(), Number: 2, Name: "toner"}
+	added := Lot{ID: NewID(), Number: 2, Name: "ink"}
+
+	changedKept := kept
+	changedKept.Name = "printer paper"
+	changedKept.Description = "office paper A4"
+	changedKept.Budget = budget(150)
+
+	from := Tender{Name: "supplies", Lots: []Lot{kept, removed}}
+	to := Tender{Name: "supplies", Lots: []Lot{changedKept, added}}
+
+	got := DiffTenderVersions(from, to)
+	want := []FieldChange{
+		{Field: "lots[1].name", Old: "paper", New: "printer paper"},
+		{
+			Field: "lots[1].description", Old: "office paper", New: "office paper A4",
+			Words: []WordChange{
+				{Type: WordChangeEqual, Text: "office paper"},
+				{Type: WordChangeInsert, Text: " A4"},
+			},
+		},
+		{Field: "lots[1].budget", Old: budget(100), New: budget(150)},
+		{Field: "lots[2]", New: &added},
+		{Field: "lots[2]", Old: &removed},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestDiffTenderVersionsLotsOnly(t *testing.T) {
+	lot := Lot{ID: NewID(), Number: 1, Name: "paper"}
+	renumbered := lot
+	renumbered.Number = 2
+
+	from := Tender{Name: "supplies", Lots: []Lot{lot}}
+
+	if got := DiffTenderVersions(from, from); len(got) != 0 {
+		t.Fatalf("got %#v for the same lots, want no changes", got)
+	}
+
+	to := Tender{Name: "supplies", Lots: []Lot{{ID: NewID(), Number: 1, Name: "toner"}, renumbered}}
+
+	got := DiffTenderVersions(from, to)
+	if len(got) != 2 {
+		t.Fatalf("got %#v, want a renumbered and an added lot", got)
+	}
+	if got[0].Field != "lots[1]" || got[0].Old != nil {
+		t.Errorf("got %#v, want the added lot 1", got[0])
+	}
+	if got[1].Field != "lots[2].number" || got[1].Old != 1 || got[1].New != 2 {
+		t.Errorf("got %#v, want lot 1 renumbered to 2", got[1])
+	}
+}
+
+func TestDiffBidVersions(t *testing.T) {
+	from := Bid{Name: "paper", Description: "office paper", Price: &Money{Amount: 100, Currency: "RUB"}}
+
+	if got := DiffBidVersions(from, from); len(got) != 0 {
+		t.Fatalf("got %#v for the same version, want no changes", got)
+	}
+
+	to := Bid{Name: "paper", Description: "office paper A4"}
+
+	got := DiffBidVersions(from, to)
+	want := []FieldChange{
+		{
+			Field: "description", Old: "office paper", New: "office paper A4",
+			Words: []WordChange{
+				{Type: WordChangeEqual, Text: "office paper"},
+				{Type: WordChangeInsert, Text: " A4"},
+			},
+		},
+		{Field: "price", Old: from.Price, New: (*Money)(nil)},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
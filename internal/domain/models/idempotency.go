@@ -0,0 +1,56 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// IdempotentRequest is a mutating request recorded under the idempotency key sent with it
+type IdempotentRequest struct {
+	// Scope is the caller that sent the key, keys of different callers never collide
+	Scope string
+	Key   string
+	// Fingerprint identifies the method, the URI and the body of the request
+	Fingerprint string
+	// Response is nil while the first request with the key is in progress
+	Response  *StoredResponse
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// StoredResponse is the response replayed to the repeated requests
+type StoredResponse struct {
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+}
+
+// Replayable reports whether the response may be stored and sent again. Responses with
+// credentials are marked with Cache-Control: no-store and never leave the memory.
+func (r StoredResponse) Replayable() bool {
+	for _, value := range r.Header["Cache-Control"] {
+		for _, directive := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// RequestFingerprint hashes what makes requests under one idempotency key the same request. The
+// If-Match value is a part of it, as a retry with another precondition must be checked again.
+// Requests without If-Match keep the fingerprint of the method, the URI and the body alone.
+func RequestFingerprint(method, uri, ifMatch string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + uri + "\n"))
+	if ifMatch != "" {
+		hash.Write([]byte("If-Match: " + ifMatch + "\n"))
+	}
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,49 @@
+package models
+
+import "testing"
+
+func TestStoredResponseReplayable(t *testing.T) {
+	tests := []struct {
+		name   string
+		header map[string][]string
+		want   bool
+	}{
+		{name: "without cache control", want: true},
+		{name: "cacheable", header: map[string][]string{"Cache-Control": {"max-age=60"}}, want: true},
+		{name: "no-store", header: map[string][]string{"Cache-Control": {"no-store"}}},
+		{name: "no-store among directives", header: map[string][]string{"Cache-Control": {"private, No-Store"}}},
+		{name: "no-store in repeated header", header: map[string][]string{"Cache-Control": {"private", "no-store"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (StoredResponse{StatusCode: 201, Header: tt.header}).Replayable(); got != tt.want {
+				t.Fatalf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	fingerprint := RequestFingerprint("POST", "/api/tenders/new", "", []byte(`{"name":"tender"}`))
+
+	if RequestFingerprint("POST", "/api/tenders/new", "", []byte(`{"name":"tender"}`)) != fingerprint {
+		t.Fatal("the same request has another fingerprint")
+	}
+
+	others := map[string]string{
+		"method":   RequestFingerprint("PUT", "/api/tenders/new", "", []byte(`{"name":"tender"}`)),
+		"uri":      RequestFingerprint("POST", "/api/tenders/new?x=1", "", []byte(`{"name":"tender"}`)),
+		"If-Match": RequestFingerprint("POST", "/api/tenders/new", `"4"`, []byte(`{"name":"tender"}`)),
+		"body":     RequestFingerprint("POST", "/api/tenders/new", "", []byte(`{"name":"other"}`)),
+	}
+	for name, other := range others {
+		if other == fingerprint {
+			t.Errorf("request with another %s has the same fingerprint", name)
+		}
+	}
+
+	if RequestFingerprint("PATCH", "/api/tenders/1/edit", `"4"`, nil) == RequestFingerprint("PATCH", "/api/tenders/1/edit", `"5"`, nil) {
+		t.Error("requests with different preconditions have the same fingerprint")
+	}
+}
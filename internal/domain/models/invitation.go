@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+type Invitation struct {
+	ID             ID
+	OrganizationID ID
+	Role           OrganizationRole
+	TokenHash      string
+	CreatedBy      ID
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	RedeemedAt     *time.Time
+	RedeemedBy     *ID
+}
+
+func NewInvitation(organizationID ID, role OrganizationRole, tokenHash string, ttl time.Duration, createdBy ID) Invitation {
+	now := time.Now()
+	return Invitation{
+		ID:             NewID(),
+		OrganizationID: organizationID,
+		Role:           role,
+		TokenHash:      tokenHash,
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(ttl),
+	}
+}
+
+func (i Invitation) IsRedeemed() bool {
+	return i.RedeemedAt != nil
+}
+
+func (i Invitation) IsExpired(now time.Time) bool {
+	return !i.ExpiresAt.After(now)
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"tenderSystem/internal/domain"
+	"time"
+)
+
+type LotStatus string
+
+const (
+	LotStatusUnknown  LotStatus = "unknown"
+	LotStatusOpen     LotStatus = "open"
+	LotStatusAwarded  LotStatus = "awarded"
+	LotStatusCanceled LotStatus = "canceled"
+)
+
+func (l LotStatus) String() string {
+	return string(l)
+}
+
+func NewLotStatus(l string) (LotStatus, error) {
+	switch l {
+	case "open":
+		return LotStatusOpen, nil
+	case "awarded":
+		return LotStatusAwarded, nil
+	case "canceled":
+		return LotStatusCanceled, nil
+	default:
+		return LotStatusUnknown, fmt.Errorf("unknown lot status: %w", domain.ErrInvalidArgument)
+	}
+}
+
+// Lot is a part of a tender that suppliers bid on separately. The name, description
+// and budget are versioned with the tender, the status is not.
+type Lot struct {
+	ID          ID
+	TenderID    ID
+	Number      int
+	Name        string
+	Description string
+	// Budget is the estimated budget of the lot, nil if not set
+	Budget *Money
+	Status LotStatus
+	// AwardedBidID is the bid that won the lot, nil unless the lot is awarded
+	AwardedBidID *ID
+	CreatedAt    time.Time
+}
+
+func NewLot(tenderID ID, number int, name, description string, budget *Money) Lot {
+	return Lot{
+		ID:          NewID(),
+		TenderID:    tenderID,
+		Number:      number,
+		Name:        name,
+		Description: description,
+		Budget:      budget,
+		Status:      LotStatusOpen,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func (l *Lot) IsOpen() bool {
+	return l.Status == LotStatusOpen
+}
+
+func (l *Lot) Award(bidID ID) error {
+	if !l.IsOpen() {
+		return fmt.Errorf("lot %d is already %s: %w", l.Number, l.Status, domain.ErrInvalidArgument)
+	}
+
+	l.Status = LotStatusAwarded
+	l.AwardedBidID = &bidID
+	return nil
+}
+
+func (l *Lot) Cancel() error {
+	if !l.IsOpen() {
+		return fmt.Errorf("lot %d is already %s: %w", l.Number, l.Status, domain.ErrInvalidArgument)
+	}
+
+	l.Status = LotStatusCanceled
+	return nil
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"tenderSystem/internal/domain"
+	"testing"
+)
+
+func TestLotAwardAndCancel(t *testing.T) {
+	bidID := NewID()
+
+	awarded := NewLot(NewID(), 1, "paper", "", nil)
+	if err := awarded.Award(bidID); err != nil {
+		t.Fatal(err)
+	}
+	if awarded.Status != LotStatusAwarded || awarded.AwardedBidID == nil || *awarded.AwardedBidID != bidID {
+		t.Fatalf("got %#v, want the lot awarded to the bid", awarded)
+	}
+
+	canceled := NewLot(NewID(), 2, "toner", "", nil)
+	if err := canceled.Cancel(); err != nil {
+		t.Fatal(err)
+	}
+	if canceled.Status != LotStatusCanceled || canceled.AwardedBidID != nil {
+		t.Fatalf("got %#v, want the lot canceled", canceled)
+	}
+
+	// a finished lot stays as it is
+	for _, lot := range []Lot{awarded, canceled} {
+		if err := lot.Award(NewID()); !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("awarded a %s lot: %v", lot.Status, err)
+		}
+		if err := lot.Cancel(); !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("canceled a %s lot: %v", lot.Status, err)
+		}
+	}
+}
+
+func TestTenderLots(t *testing.T) {
+	tender := NewTender("supplies", "", "delivery", NewID(), DefaultQuorumPolicy(), TenderDeadlines{}, &Money{Amount: 1000, Currency: "RUB"})
+
+	if _, err := tender.DefaultLot(); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v for a tender without lots, want invalid argument", err)
+	}
+
+	paper := tender.AddLot("paper", "", nil)
+	if paper.Number != 1 || paper.TenderID != tender.ID || !paper.IsOpen() {
+		t.Fatalf("got %#v, want open lot 1 of the tender", paper)
+	}
+
+	lot, err := tender.DefaultLot()
+	if err != nil || lot.ID != paper.ID {
+		t.Fatalf("got %v, %v, want the only lot", lot, err)
+	}
+
+	toner := tender.AddLot("toner", "", &Money{Amount: 100, Currency: "USD"})
+	if toner.Number != 2 {
+		t.Fatalf("got lot number %d, want 2", toner.Number)
+	}
+
+	if _, err := tender.DefaultLot(); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v for two lots, want invalid argument", err)
+	}
+	if _, err := tender.Lot(NewID()); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v for a lot of another tender, want not found", err)
+	}
+	if err := tender.CheckLotBudgets(); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v for a lot budget in another currency, want invalid argument", err)
+	}
+
+	lot, err = tender.Lot(toner.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lot.Budget.Currency = "RUB"
+	if err := tender.CheckLotBudgets(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := lot.Cancel(); err != nil {
+		t.Fatal(err)
+	}
+	if tender.AllLotsFinished() {
+		t.Fatal("all lots finished while the first one is open")
+	}
+
+	lot, err = tender.Lot(paper.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := lot.Award(NewID()); err != nil {
+		t.Fatal(err)
+	}
+	if !tender.AllLotsFinished() {
+		t.Fatal("lots are awarded or canceled, but not finished")
+	}
+}
@@ -63,10 +63,15 @@ type Organization struct {
 }
 
 type Employee struct {
-	ID        ID
-	Username  string
-	FirstName string
-	LastName  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            ID
+	Username      string
+	FirstName     string
+	LastName      string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeactivatedAt *time.Time
+}
+
+func (e Employee) IsActive() bool {
+	return e.DeactivatedAt == nil
 }
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"tenderSystem/internal/domain"
+)
+
+// Currency is an ISO 4217 alphabetic currency code, e.g. RUB or USD
+type Currency string
+
+func (c Currency) String() string {
+	return string(c)
+}
+
+// currencies are the active ISO 4217 codes, without the XTS testing code and XXX for no currency
+var currencies = map[Currency]struct{}{
+	"AED": {}, "AFN": {}, "ALL": {}, "AMD": {}, "ANG": {}, "AOA": {}, "ARS": {}, "AUD": {}, "AWG": {}, "AZN": {}, "BAM": {}, "BBD": {}, "BDT": {},
+	"BGN": {}, "BHD": {}, "BIF": {}, "BMD": {}, "BND": {}, "BOB": {}, "BOV": {}, "BRL": {}, "BSD": {}, "BTN": {}, "BWP": {}, "BYN": {}, "BZD": {},
+	"CAD": {}, "CDF": {}, "CHE": {}, "CHF": {}, "CHW": {}, "CLF": {}, "CLP": {}, "CNY": {}, "COP": {}, "COU": {}, "CRC": {}, "CUC": {}, "CUP": {},
+	"CVE": {}, "CZK": {}, "DJF": {}, "DKK": {}, "DOP": {}, "DZD": {}, "EGP": {}, "ERN": {}, "ETB": {}, "EUR": {}, "FJD": {}, "FKP": {}, "GBP": {},
+	"GEL": {}, "GHS": {}, "GIP": {}, "GMD": {}, "GNF": {}, "GTQ": {}, "GYD": {}, "HKD": {}, "HNL": {}, "HTG": {}, "HUF": {}, "IDR": {}, "ILS": {},
+	"INR": {}, "IQD": {}, "IRR": {}, "ISK": {}, "JMD": {}, "JOD": {}, "JPY": {}, "KES": {}, "KGS": {}, "KHR": {}, "KMF": {}, "KPW": {}, "KRW": {},
+	"KWD": {}, "KYD": {}, "KZT": {}, "LAK": {}, "LBP": {}, "LKR": {}, "LRD": {}, "LSL": {}, "LYD": {}, "MAD": {}, "MDL": {}, "MGA": {}, "MKD": {},
+	"MMK": {}, "MNT": {}, "MOP": {}, "MRU": {}, "MUR": {}, "MVR": {}, "MWK": {}, "MXN": {}, "MXV": {}, "MYR": {}, "MZN": {}, "NAD": {}, "NGN": {},
+	"NIO": {}, "NOK": {}, "NPR": {}, "NZD": {}, "OMR": {}, "PAB": {}, "PEN": {}, "PGK": {}, "PHP": {}, "PKR": {}, "PLN": {}, "PYG": {}, "QAR": {},
+	"RON": {}, "RSD": {}, "RUB": {}, "RWF": {}, "SAR": {}, "SBD": {}, "SCR": {}, "SDG": {}, "SEK": {}, "SGD": {}, "SHP": {}, "SLE": {}, "SLL": {},
+	"SOS": {}, "SRD": {}, "SSP": {}, "STN": {}, "SVC": {}, "SYP": {}, "SZL": {}, "THB": {}, "TJS": {}, "TMT": {}, "TND": {}, "TOP": {}, "TRY": {},
+	"TTD": {}, "TWD": {}, "TZS": {}, "UAH": {}, "UGX": {}, "USD": {}, "USN": {}, "UYI": {}, "UYU": {}, "UYW": {}, "UZS": {}, "VED": {}, "VES": {},
+	"VND": {}, "VUV": {}, "WST": {}, "XAF": {}, "XAG": {}, "XAU": {}, "XBA": {}, "XBB": {}, "XBC": {}, "XBD": {}, "XCD": {}, "XCG": {}, "XDR": {},
+	"XOF": {}, "XPD": {}, "XPF": {}, "XPT": {}, "XSU": {}, "XUA": {}, "YER": {}, "ZAR": {}, "ZMW": {}, "ZWG": {}, "ZWL": {},
+}
+
+// NewCurrency normalizes the code to upper case and checks that it is an active ISO 4217 code
+func NewCurrency(c string) (Currency, error) {
+	currency := Currency(strings.ToUpper(c))
+	if _, ok := currencies[currency]; !ok {
+		return "", fmt.Errorf("currency %q is not an ISO 4217 code: %w", c, domain.ErrInvalidArgument)
+	}
+
+	return currency, nil
+}
+
+// Money is an amount in minor units of the currency, e.g. kopecks for RUB or cents for USD
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func NewMoney(amount int64, currency Currency) (Money, error) {
+	if amount < 0 {
+		return Money{}, fmt.Errorf("amount must not be negative: %w", domain.ErrInvalidArgument)
+	}
+
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"tenderSystem/internal/domain"
+	"testing"
+)
+
+func TestNewCurrency(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    Currency
+		wantErr error
+	}{
+		{code: "RUB", want: "RUB"},
+		{code: "usd", want: "USD"},
+		{code: "", wantErr: domain.ErrInvalidArgument},
+		{code: "RU", wantErr: domain.ErrInvalidArgument},
+		{code: "RUBL", wantErr: domain.ErrInvalidArgument},
+		{code: "R1B", wantErr: domain.ErrInvalidArgument},
+		{code: "РУБ", wantErr: domain.ErrInvalidArgument},
+		{code: "XYZ", wantErr: domain.ErrInvalidArgument},
+		{code: "AAA", wantErr: domain.ErrInvalidArgument},
+		{code: "XXX", wantErr: domain.ErrInvalidArgument},
+		{code: "eur", want: "EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, err := NewCurrency(tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMoney(t *testing.T) {
+	if _, err := NewMoney(-1, "RUB"); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v for a negative amount, want invalid argument", err)
+	}
+
+	money, err := NewMoney(0, "RUB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if money != (Money{Amount: 0, Currency: "RUB"}) {
+		t.Fatalf("got %#v", money)
+	}
+}
@@ -0,0 +1,66 @@
+package models
+
+type PrincipalType string
+
+const (
+	PrincipalTypeEmployee     PrincipalType = "employee"
+	PrincipalTypeOrganization PrincipalType = "organization"
+)
+
+// Principal is an authenticated caller of the API: either an employee or
+// an organization authenticated by one of its API keys.
+// For employees Organization and Role are only set when an acting organization was selected.
+type Principal struct {
+	Type         PrincipalType
+	Employee     Employee
+	Organization Organization
+	Role         OrganizationRole
+	Scopes       []APIKeyScope
+}
+
+func NewEmployeePrincipal(employee Employee) Principal {
+	return Principal{
+		Type:     PrincipalTypeEmployee,
+		Employee: employee,
+	}
+}
+
+func NewOrganizationPrincipal(organization Organization, scopes []APIKeyScope) Principal {
+	return Principal{
+		Type:         PrincipalTypeOrganization,
+		Organization: organization,
+		Scopes:       scopes,
+	}
+}
+
+// WithMembership selects the organization the employee acts for
+func (p Principal) WithMembership(member OrganizationMember) Principal {
+	p.Organization = member.Organization
+	p.Role = member.Role
+	return p
+}
+
+// HasOrganization reports whether the principal acts for a known organization
+func (p Principal) HasOrganization() bool {
+	return p.Organization.ID != ID{}
+}
+
+func (p Principal) IsEmployee() bool {
+	return p.Type == PrincipalTypeEmployee
+}
+
+// HasScope reports whether the principal may perform actions guarded by scope.
+// Scopes only restrict API keys, employees are checked by their organization.
+func (p Principal) HasScope(scope APIKeyScope) bool {
+	if p.IsEmployee() {
+		return true
+	}
+
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
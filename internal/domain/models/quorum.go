@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"tenderSystem/internal/domain"
+)
+
+type QuorumPolicyType string
+
+const (
+	QuorumPolicyUnknown   QuorumPolicyType = "unknown"
+	QuorumPolicyFixed     QuorumPolicyType = "fixed"
+	QuorumPolicyMajority  QuorumPolicyType = "majority"
+	QuorumPolicyUnanimity QuorumPolicyType = "unanimity"
+	QuorumPolicyWeighted  QuorumPolicyType = "weighted"
+)
+
+// DefaultQuorum is the number of approvals required by the default fixed policy
+const DefaultQuorum = 3
+
+func (q QuorumPolicyType) String() string {
+	return string(q)
+}
+
+func NewQuorumPolicyType(q string) (QuorumPolicyType, error) {
+	switch q {
+	case "fixed":
+		return QuorumPolicyFixed, nil
+	case "majority":
+		return QuorumPolicyMajority, nil
+	case "unanimity":
+		return QuorumPolicyUnanimity, nil
+	case "weighted":
+		return QuorumPolicyWeighted, nil
+	default:
+		return QuorumPolicyUnknown, fmt.Errorf("unknown quorum policy: %w", domain.ErrInvalidArgument)
+	}
+}
+
+// roleVoteWeights are the vote weights of the roles allowed to decide on bids
+var roleVoteWeights = map[OrganizationRole]int{
+	OrganizationRoleOwner:              2,
+	OrganizationRoleProcurementManager: 2,
+	OrganizationRoleEvaluator:          1,
+}
+
+// VoteWeight returns the weight of the role's vote under the weighted policy
+func (o OrganizationRole) VoteWeight() int {
+	return roleVoteWeights[o]
+}
+
+// QuorumPolicy decides when a bid collects enough approvals to win the tender.
+// Threshold is the number of approvals for the fixed policy and the total
+// vote weight for the weighted policy, other policies ignore it.
+type QuorumPolicy struct {
+	Type      QuorumPolicyType
+	Threshold int
+}
+
+func NewQuorumPolicy(policyType QuorumPolicyType, threshold int) (QuorumPolicy, error) {
+	switch policyType {
+	case QuorumPolicyFixed, QuorumPolicyWeighted:
+		if threshold < 1 {
+			return QuorumPolicy{}, fmt.Errorf("%s quorum policy requires a positive threshold: %w", policyType, domain.ErrInvalidArgument)
+		}
+	case QuorumPolicyMajority, QuorumPolicyUnanimity:
+		threshold = 0
+	default:
+		return QuorumPolicy{}, fmt.Errorf("unknown quorum policy: %w", domain.ErrInvalidArgument)
+	}
+
+	return QuorumPolicy{Type: policyType, Threshold: threshold}, nil
+}
+
+// DefaultQuorumPolicy keeps the historical behaviour: three approvals,
+// or all eligible voters when the organization has fewer
+func DefaultQuorumPolicy() QuorumPolicy {
+	return QuorumPolicy{Type: QuorumPolicyFixed, Threshold: DefaultQuorum}
+}
+
+// IsReached reports whether the approvals, given by the roles of the approving
+// employees, satisfy the policy. Voters are the roles of everyone allowed to decide.
+func (q QuorumPolicy) IsReached(approvals []OrganizationRole, voters []OrganizationRole) bool {
+	if len(approvals) == 0 {
+		return false
+	}
+
+	switch q.Type {
+	case QuorumPolicyFixed:
+		return len(approvals) >= min(q.Threshold, len(voters))
+	case QuorumPolicyMajority:
+		return len(approvals)*2 > len(voters)
+	case QuorumPolicyUnanimity:
+		return len(approvals) >= len(voters)
+	case QuorumPolicyWeighted:
+		var weight, total int
+		for _, role := range approvals {
+			weight += role.VoteWeight()
+		}
+		for _, role := range voters {
+			total += role.VoteWeight()
+		}
+		return weight >= min(q.Threshold, total)
+	default:
+		return false
+	}
+}
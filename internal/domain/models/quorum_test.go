@@ -0,0 +1,78 @@
+package models
+
+import (
+	"errors"
+	"tenderSystem/internal/domain"
+	"testing"
+)
+
+func TestNewQuorumPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		policyType QuorumPolicyType
+		threshold  int
+		want       QuorumPolicy
+		wantErr    error
+	}{
+		{name: "fixed", policyType: QuorumPolicyFixed, threshold: 2, want: QuorumPolicy{Type: QuorumPolicyFixed, Threshold: 2}},
+		{name: "fixed without threshold", policyType: QuorumPolicyFixed, wantErr: domain.ErrInvalidArgument},
+		{name: "weighted", policyType: QuorumPolicyWeighted, threshold: 3, want: QuorumPolicy{Type: QuorumPolicyWeighted, Threshold: 3}},
+		{name: "weighted with negative threshold", policyType: QuorumPolicyWeighted, threshold: -1, wantErr: domain.ErrInvalidArgument},
+		{name: "majority ignores threshold", policyType: QuorumPolicyMajority, threshold: 5, want: QuorumPolicy{Type: QuorumPolicyMajority}},
+		{name: "unanimity ignores threshold", policyType: QuorumPolicyUnanimity, threshold: 5, want: QuorumPolicy{Type: QuorumPolicyUnanimity}},
+		{name: "unknown", policyType: QuorumPolicyUnknown, threshold: 1, wantErr: domain.ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewQuorumPolicy(tt.policyType, tt.threshold)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuorumPolicyIsReached(t *testing.T) {
+	const (
+		owner     = OrganizationRoleOwner
+		manager   = OrganizationRoleProcurementManager
+		evaluator = OrganizationRoleEvaluator
+	)
+
+	voters := []OrganizationRole{owner, manager, evaluator, evaluator, evaluator}
+
+	tests := []struct {
+		name      string
+		policy    QuorumPolicy
+		approvals []OrganizationRole
+		voters    []OrganizationRole
+		want      bool
+	}{
+		{name: "no approvals", policy: DefaultQuorumPolicy(), voters: voters, want: false},
+		{name: "fixed below threshold", policy: DefaultQuorumPolicy(), approvals: []OrganizationRole{owner, evaluator}, voters: voters, want: false},
+		{name: "fixed at threshold", policy: DefaultQuorumPolicy(), approvals: []OrganizationRole{evaluator, evaluator, evaluator}, voters: voters, want: true},
+		{name: "fixed capped by voters", policy: DefaultQuorumPolicy(), approvals: []OrganizationRole{owner, evaluator}, voters: []OrganizationRole{owner, evaluator}, want: true},
+		{name: "majority of five needs three", policy: QuorumPolicy{Type: QuorumPolicyMajority}, approvals: []OrganizationRole{owner, manager}, voters: voters, want: false},
+		{name: "majority reached", policy: QuorumPolicy{Type: QuorumPolicyMajority}, approvals: []OrganizationRole{owner, manager, evaluator}, voters: voters, want: true},
+		{name: "half is not a majority", policy: QuorumPolicy{Type: QuorumPolicyMajority}, approvals: []OrganizationRole{owner}, voters: []OrganizationRole{owner, evaluator}, want: false},
+		{name: "unanimity with one missing", policy: QuorumPolicy{Type: QuorumPolicyUnanimity}, approvals: voters[:4], voters: voters, want: false},
+		{name: "unanimity reached", policy: QuorumPolicy{Type: QuorumPolicyUnanimity}, approvals: voters, voters: voters, want: true},
+		{name: "weighted below threshold", policy: QuorumPolicy{Type: QuorumPolicyWeighted, Threshold: 4}, approvals: []OrganizationRole{owner, evaluator}, voters: voters, want: false},
+		{name: "weighted at threshold", policy: QuorumPolicy{Type: QuorumPolicyWeighted, Threshold: 4}, approvals: []OrganizationRole{owner, manager}, voters: voters, want: true},
+		{name: "weighted capped by total weight", policy: QuorumPolicy{Type: QuorumPolicyWeighted, Threshold: 10}, approvals: []OrganizationRole{evaluator, evaluator}, voters: []OrganizationRole{evaluator, evaluator}, want: true},
+		{name: "viewer votes weigh nothing", policy: QuorumPolicy{Type: QuorumPolicyWeighted, Threshold: 1}, approvals: []OrganizationRole{OrganizationRoleViewer}, voters: voters, want: false},
+		{name: "unknown policy", policy: QuorumPolicy{Type: QuorumPolicyUnknown}, approvals: voters, voters: voters, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.IsReached(tt.approvals, tt.voters); got != tt.want {
+				t.Fatalf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"fmt"
+	"tenderSystem/internal/domain"
+)
+
+type OrganizationRole string
+
+const (
+	OrganizationRoleUnknown            OrganizationRole = "unknown"
+	OrganizationRoleOwner              OrganizationRole = "owner"
+	OrganizationRoleProcurementManager OrganizationRole = "procurement_manager"
+	OrganizationRoleEvaluator          OrganizationRole = "evaluator"
+	OrganizationRoleViewer             OrganizationRole = "viewer"
+)
+
+func (o OrganizationRole) String() string {
+	return string(o)
+}
+
+func NewOrganizationRole(o string) (OrganizationRole, error) {
+	switch o {
+	case "owner":
+		return OrganizationRoleOwner, nil
+	case "procurement_manager":
+		return OrganizationRoleProcurementManager, nil
+	case "evaluator":
+		return OrganizationRoleEvaluator, nil
+	case "viewer":
+		return OrganizationRoleViewer, nil
+	default:
+		return OrganizationRoleUnknown, fmt.Errorf("unknown organization role: %w", domain.ErrInvalidArgument)
+	}
+}
+
+type Permission string
+
+const (
+	PermissionTenderRead   Permission = "tender:read"
+	PermissionTenderCreate Permission = "tender:create"
+	PermissionTenderEdit   Permission = "tender:edit"
+	PermissionTenderStatus Permission = "tender:status"
+	PermissionBidRead      Permission = "bid:read"
+	PermissionBidWrite     Permission = "bid:write"
+	PermissionBidDecide    Permission = "bid:decide"
+	PermissionBidFeedback  Permission = "bid:feedback"
+	PermissionOrgRead      Permission = "organization:read"
+	PermissionOrgEdit      Permission = "organization:edit"
+	PermissionManageRoles  Permission = "organization:roles"
+	PermissionManageStaff  Permission = "organization:employees"
+	PermissionManageKeys   Permission = "organization:api_keys"
+)
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+// RequiredScope returns the API key scope granting the permission.
+// Permissions without a scope are only available to employees.
+func (p Permission) RequiredScope() (APIKeyScope, bool) {
+	switch p {
+	case PermissionTenderRead:
+		return APIKeyScopeTendersRead, true
+	case PermissionTenderCreate, PermissionTenderEdit, PermissionTenderStatus:
+		return APIKeyScopeTendersWrite, true
+	case PermissionBidRead:
+		return APIKeyScopeBidsRead, true
+	case PermissionBidWrite, PermissionBidDecide, PermissionBidFeedback:
+		return APIKeyScopeBidsWrite, true
+	default:
+		return APIKeyScopeUnknown, false
+	}
+}
+
+var rolePermissions = map[OrganizationRole][]Permission{
+	OrganizationRoleOwner: {
+		PermissionTenderRead, PermissionTenderCreate, PermissionTenderEdit, PermissionTenderStatus,
+		PermissionBidRead, PermissionBidWrite, PermissionBidDecide, PermissionBidFeedback,
+		PermissionOrgRead, PermissionOrgEdit, PermissionManageRoles, PermissionManageStaff, PermissionManageKeys,
+	},
+	OrganizationRoleProcurementManager: {
+		PermissionTenderRead, PermissionTenderCreate, PermissionTenderEdit, PermissionTenderStatus,
+		PermissionBidRead, PermissionBidWrite, PermissionBidDecide, PermissionBidFeedback,
+		PermissionOrgRead,
+	},
+	OrganizationRoleEvaluator: {
+		PermissionTenderRead, PermissionBidRead, PermissionBidDecide, PermissionBidFeedback,
+		PermissionOrgRead,
+	},
+	OrganizationRoleViewer: {
+		PermissionTenderRead, PermissionBidRead, PermissionOrgRead,
+	},
+}
+
+func (o OrganizationRole) Can(permission Permission) bool {
+	for _, p := range rolePermissions[o] {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OrganizationMember is an employee responsible for an organization
+type OrganizationMember struct {
+	Organization Organization
+	Employee     Employee
+	Role         OrganizationRole
+}
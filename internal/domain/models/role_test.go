@@ -0,0 +1,64 @@
+package models
+
+import "testing"
+
+func TestRolePermissions(t *testing.T) {
+	roles := []OrganizationRole{
+		OrganizationRoleOwner, OrganizationRoleProcurementManager, OrganizationRoleEvaluator, OrganizationRoleViewer,
+	}
+
+	// allowed lists the roles granted each permission, in the order of roles
+	allowed := map[Permission][4]bool{
+		PermissionTenderRead:   {true, true, true, true},
+		PermissionTenderCreate: {true, true, false, false},
+		PermissionTenderEdit:   {true, true, false, false},
+		PermissionTenderStatus: {true, true, false, false},
+		PermissionBidRead:      {true, true, true, true},
+		PermissionBidWrite:     {true, true, false, false},
+		PermissionBidDecide:    {true, true, true, false},
+		PermissionBidFeedback:  {true, true, true, false},
+		PermissionOrgRead:      {true, true, true, true},
+		PermissionOrgEdit:      {true, false, false, false},
+		PermissionManageRoles:  {true, false, false, false},
+		PermissionManageStaff:  {true, false, false, false},
+		PermissionManageKeys:   {true, false, false, false},
+	}
+
+	for permission, want := range allowed {
+		for i, role := range roles {
+			if got := role.Can(permission); got != want[i] {
+				t.Errorf("%s can %s: got %t, want %t", role, permission, got, want[i])
+			}
+		}
+	}
+
+	for permission := range allowed {
+		if OrganizationRoleUnknown.Can(permission) {
+			t.Errorf("unknown role can %s", permission)
+		}
+	}
+}
+
+func TestPermissionRequiredScope(t *testing.T) {
+	tests := []struct {
+		permission Permission
+		scope      APIKeyScope
+		ok         bool
+	}{
+		{permission: PermissionTenderRead, scope: APIKeyScopeTendersRead, ok: true},
+		{permission: PermissionTenderEdit, scope: APIKeyScopeTendersWrite, ok: true},
+		{permission: PermissionBidRead, scope: APIKeyScopeBidsRead, ok: true},
+		{permission: PermissionBidDecide, scope: APIKeyScopeBidsWrite, ok: true},
+		{permission: PermissionManageRoles, ok: false},
+		{permission: PermissionManageKeys, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.permission.String(), func(t *testing.T) {
+			scope, ok := tt.permission.RequiredScope()
+			if ok != tt.ok || (ok && scope != tt.scope) {
+				t.Fatalf("got %s, %t, want %s, %t", scope, ok, tt.scope, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+// SearchHit describes how well a full-text search result matches the query
+type SearchHit struct {
+	Rank float32
+	// Snippet is an HTML-escaped fragment of the name and description with the matches marked by <mark> tags
+	Snippet string
+}
+
+type TenderSearchResult struct {
+	Tender Tender
+	SearchHit
+}
+
+type BidSearchResult struct {
+	Bid Bid
+	SearchHit
+}
@@ -32,60 +32,255 @@ func NewTenderStatus(t string) (TenderStatus, error) {
 	}
 }
 
-type TenderType string
-
-const (
-	TenderTypeUnknown      TenderType = "unknown"
-	TenderTypeConstruction TenderType = "construction"
-	TenderTypeDelivery     TenderType = "delivery"
-	TenderTypeManufacture  TenderType = "manufacture"
-)
-
-func (t TenderType) String() string {
-	return string(t)
-}
-
-func NewTenderType(t string) (TenderType, error) {
-	switch t {
-	case "construction":
-		return TenderTypeConstruction, nil
-	case "delivery":
-		return TenderTypeDelivery, nil
-	case "manufacture":
-		return TenderTypeManufacture, nil
-	default:
-		return TenderTypeUnknown, fmt.Errorf("unknown tender type: %w", domain.ErrInvalidArgument)
-	}
-}
-
 type Tender struct {
-	ID             ID
-	Name           string
-	Description    string
-	Status         TenderStatus
-	ServiceType    TenderType
+	ID          ID
+	Name        string
+	Description string
+	Status      TenderStatus
+	// ServiceType is the code of the procurement category
+	ServiceType    CategoryCode
 	OrganizationID ID
-	Version        int
-	CreatedAt      time.Time
+	// Visibility is not versioned, invite-only tenders are visible to their invitees only
+	Visibility   TenderVisibility
+	QuorumPolicy QuorumPolicy
+	Deadlines    TenderDeadlines
+	// Budget is the estimated budget of the tender, nil if not set
+	Budget *Money
+	// Lots are the parts of the tender ordered by number, every tender has at least one
+	Lots    []Lot
+	Version int
+	// VersionAuthor created the current version, nil when it was not recorded
+	VersionAuthor *VersionAuthor
+	// RolledBackFrom is the version the current version was copied from by a rollback
+	RolledBackFrom *int
+	// Revision changes with every change of the tender: a new version, the status, a lot status or the visibility
+	Revision  int
+	CreatedAt time.Time
 }
 
-func NewTender(name, description string, serviceType TenderType, organizationID ID) Tender {
+func NewTender(name, description string, serviceType CategoryCode, organizationID ID, quorumPolicy QuorumPolicy, deadlines TenderDeadlines, budget *Money) Tender {
 	return Tender{
 		ID:             NewID(),
 		Name:           name,
 		Description:    description,
 		ServiceType:    serviceType,
 		OrganizationID: organizationID,
+		Visibility:     TenderVisibilityPublic,
+		QuorumPolicy:   quorumPolicy,
+		Deadlines:      deadlines,
+		Budget:         budget,
 		Status:         TenderStatusCreated,
 		Version:        1,
+		Revision:       1,
 		CreatedAt:      time.Now(),
 	}
 }
 
-func (t *Tender) Publish() {
-	t.Status = TenderStatusPublished
+// tenderTransitions lists the statuses a tender can move to from each status
+var tenderTransitions = map[TenderStatus][]TenderStatus{
+	TenderStatusCreated:   {TenderStatusPublished, TenderStatusClosed},
+	TenderStatusPublished: {TenderStatusClosed},
+	TenderStatusClosed:    {},
+}
+
+// NextStatuses returns the statuses reachable from t
+func (t TenderStatus) NextStatuses() []TenderStatus {
+	return tenderTransitions[t]
+}
+
+func (t TenderStatus) CanTransitionTo(next TenderStatus) bool {
+	for _, status := range tenderTransitions[t] {
+		if status == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *Tender) transition(next TenderStatus) error {
+	if !t.Status.CanTransitionTo(next) {
+		return fmt.Errorf("tender cannot move from %s to %s: %w", t.Status, next, domain.ErrInvalidArgument)
+	}
+
+	t.Status = next
+	return nil
+}
+
+func (t *Tender) Publish() error {
+	return t.transition(TenderStatusPublished)
+}
+
+func (t *Tender) Close() error {
+	return t.transition(TenderStatusClosed)
+}
+
+// TransitionTo moves the tender to the requested status through the matching transition
+func (t *Tender) TransitionTo(status TenderStatus) error {
+	switch status {
+	case TenderStatusPublished:
+		return t.Publish()
+	case TenderStatusClosed:
+		return t.Close()
+	default:
+		return fmt.Errorf("tender cannot move from %s to %s: %w", t.Status, status, domain.ErrInvalidArgument)
+	}
+}
+
+// TenderDeadlines are the time limits of a tender. A nil time means no limit.
+type TenderDeadlines struct {
+	// SubmissionDeadline is the last moment bids can be created or edited
+	SubmissionDeadline *time.Time
+	// BidOpeningAt is the moment the tender organization can see and decide on bids
+	BidOpeningAt *time.Time
+	// DecisionDeadline is the last moment decisions can be submitted
+	DecisionDeadline *time.Time
+}
+
+// NewTenderDeadlines checks that the deadlines follow each other:
+// submission, then bid opening, then decisions
+func NewTenderDeadlines(submissionDeadline, bidOpeningAt, decisionDeadline *time.Time) (TenderDeadlines, error) {
+	if submissionDeadline != nil && bidOpeningAt != nil && bidOpeningAt.Before(*submissionDeadline) {
+		return TenderDeadlines{}, fmt.Errorf("bid opening time must not be before the submission deadline: %w", domain.ErrInvalidArgument)
+	}
+
+	if decisionDeadline != nil {
+		if submissionDeadline != nil && !decisionDeadline.After(*submissionDeadline) {
+			return TenderDeadlines{}, fmt.Errorf("decision deadline must be after the submission deadline: %w", domain.ErrInvalidArgument)
+		}
+
+		if bidOpeningAt != nil && !decisionDeadline.After(*bidOpeningAt) {
+			return TenderDeadlines{}, fmt.Errorf("decision deadline must be after the bid opening time: %w", domain.ErrInvalidArgument)
+		}
+	}
+
+	return TenderDeadlines{
+		SubmissionDeadline: submissionDeadline,
+		BidOpeningAt:       bidOpeningAt,
+		DecisionDeadline:   decisionDeadline,
+	}, nil
+}
+
+// ClosesAt returns the moment a published tender is closed automatically:
+// the decision deadline, or the submission deadline when no decisions window is set
+func (d TenderDeadlines) ClosesAt() *time.Time {
+	if d.DecisionDeadline != nil {
+		return d.DecisionDeadline
+	}
+
+	return d.SubmissionDeadline
 }
 
-func (t *Tender) Close() {
-	t.Status = TenderStatusClosed
+// CheckAcceptsBids returns an error if bids for the tender can no longer be created or edited
+func (t *Tender) CheckAcceptsBids(now time.Time) error {
+	if t.Deadlines.SubmissionDeadline != nil && !now.Before(*t.Deadlines.SubmissionDeadline) {
+		return fmt.Errorf("submission deadline of tender %s passed at %s: %w", t.ID, t.Deadlines.SubmissionDeadline.Format(time.RFC3339), domain.ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+// BidsSealed reports whether the bids are still hidden from the tender organization
+func (t *Tender) BidsSealed(now time.Time) bool {
+	return t.Deadlines.BidOpeningAt != nil && now.Before(*t.Deadlines.BidOpeningAt)
+}
+
+// CheckAcceptsDecisions returns an error if decisions on the tender's bids cannot be submitted now
+func (t *Tender) CheckAcceptsDecisions(now time.Time) error {
+	if t.BidsSealed(now) {
+		return fmt.Errorf("bids of tender %s are sealed until %s: %w", t.ID, t.Deadlines.BidOpeningAt.Format(time.RFC3339), domain.ErrInvalidArgument)
+	}
+
+	if t.Deadlines.DecisionDeadline != nil && !now.Before(*t.Deadlines.DecisionDeadline) {
+		return fmt.Errorf("decision deadline of tender %s passed at %s: %w", t.ID, t.Deadlines.DecisionDeadline.Format(time.RFC3339), domain.ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+// IsExpired reports whether the tender is published and its closing time has come
+func (t *Tender) IsExpired(now time.Time) bool {
+	closesAt := t.Deadlines.ClosesAt()
+	return t.Status == TenderStatusPublished && closesAt != nil && !now.Before(*closesAt)
+}
+
+// budgetCurrency returns the currency of the lot budget, or of the tender budget when the lot has none
+func (t *Tender) budgetCurrency(lot Lot) *Currency {
+	if lot.Budget != nil {
+		return &lot.Budget.Currency
+	}
+
+	if t.Budget != nil {
+		return &t.Budget.Currency
+	}
+
+	return nil
+}
+
+// CheckPriceCurrency returns an error if the price of a bid for the lot is not in the currency
+// of the lot or tender budget. Lots of tenders without a budget accept any currency.
+func (t *Tender) CheckPriceCurrency(lot Lot, price *Money) error {
+	currency := t.budgetCurrency(lot)
+	if price == nil || currency == nil {
+		return nil
+	}
+
+	if price.Currency != *currency {
+		return fmt.Errorf("bid currency %s does not match tender currency %s: %w", price.Currency, *currency, domain.ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+// AddLot appends a new open lot with the next number
+func (t *Tender) AddLot(name, description string, budget *Money) Lot {
+	lot := NewLot(t.ID, len(t.Lots)+1, name, description, budget)
+	t.Lots = append(t.Lots, lot)
+	return lot
+}
+
+// CheckLotBudgets returns an error if a lot budget is not in the currency of the tender budget
+func (t *Tender) CheckLotBudgets() error {
+	if t.Budget == nil {
+		return nil
+	}
+
+	for _, lot := range t.Lots {
+		if lot.Budget != nil && lot.Budget.Currency != t.Budget.Currency {
+			return fmt.Errorf("currency %s of lot %d does not match tender currency %s: %w", lot.Budget.Currency, lot.Number, t.Budget.Currency, domain.ErrInvalidArgument)
+		}
+	}
+
+	return nil
+}
+
+// Lot returns the lot of the tender with the ID
+func (t *Tender) Lot(id ID) (*Lot, error) {
+	for i := range t.Lots {
+		if t.Lots[i].ID == id {
+			return &t.Lots[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("lot %s not found in tender %s: %w", id, t.ID, domain.ErrNotFound)
+}
+
+// DefaultLot returns the lot bids go to when no lot is given, which only exists for single-lot tenders
+func (t *Tender) DefaultLot() (*Lot, error) {
+	if len(t.Lots) != 1 {
+		return nil, fmt.Errorf("tender %s has %d lots, select one: %w", t.ID, len(t.Lots), domain.ErrInvalidArgument)
+	}
+
+	return &t.Lots[0], nil
+}
+
+// AllLotsFinished reports whether every lot of the tender is awarded or canceled
+func (t *Tender) AllLotsFinished() bool {
+	for _, lot := range t.Lots {
+		if lot.IsOpen() {
+			return false
+		}
+	}
+
+	return true
 }
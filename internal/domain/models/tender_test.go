@@ -0,0 +1,171 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"tenderSystem/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestTenderTransitions(t *testing.T) {
+	statuses := []TenderStatus{TenderStatusCreated, TenderStatusPublished, TenderStatusClosed}
+
+	allowed := map[TenderStatus][]TenderStatus{
+		TenderStatusCreated:   {TenderStatusPublished, TenderStatusClosed},
+		TenderStatusPublished: {TenderStatusClosed},
+		TenderStatusClosed:    nil,
+	}
+
+	for _, from := range statuses {
+		if got := from.NextStatuses(); !slices.Equal(got, allowed[from]) {
+			t.Errorf("%s: next statuses are %v, want %v", from, got, allowed[from])
+		}
+
+		for _, to := range append(statuses, TenderStatusUnknown) {
+			tender := Tender{Status: from}
+			err := tender.TransitionTo(to)
+
+			if slices.Contains(allowed[from], to) {
+				if err != nil || tender.Status != to {
+					t.Errorf("%s -> %s: got %v and status %s, want the move", from, to, err, tender.Status)
+				}
+				continue
+			}
+
+			if !errors.Is(err, domain.ErrInvalidArgument) {
+				t.Errorf("%s -> %s: got %v, want invalid argument", from, to, err)
+			}
+			if tender.Status != from {
+				t.Errorf("%s -> %s: status changed to %s by a refused move", from, to, tender.Status)
+			}
+		}
+	}
+}
+
+func TestNewTenderDeadlines(t *testing.T) {
+	at := func(hours int) *time.Time {
+		moment := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(hours) * time.Hour)
+		return &moment
+	}
+
+	tests := []struct {
+		name       string
+		submission *time.Time
+		opening    *time.Time
+		decision   *time.Time
+		wantErr    error
+	}{
+		{name: "no deadlines"},
+		{name: "all in order", submission: at(1), opening: at(2), decision: at(3)},
+		{name: "opening at submission deadline", submission: at(1), opening: at(1), decision: at(2)},
+		{name: "opening before submission deadline", submission: at(2), opening: at(1), wantErr: domain.ErrInvalidArgument},
+		{name: "decision at submission deadline", submission: at(1), decision: at(1), wantErr: domain.ErrInvalidArgument},
+		{name: "decision at opening", opening: at(1), decision: at(1), wantErr: domain.ErrInvalidArgument},
+		{name: "only decision", decision: at(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTenderDeadlines(tt.submission, tt.opening, tt.decision)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTenderDeadlines(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		name             string
+		status           TenderStatus
+		deadlines        TenderDeadlines
+		acceptsBids      bool
+		sealed           bool
+		acceptsDecisions bool
+		expired          bool
+	}{
+		{name: "no deadlines", status: TenderStatusPublished, acceptsBids: true, acceptsDecisions: true},
+		{
+			name: "submission open", status: TenderStatusPublished, deadlines: TenderDeadlines{SubmissionDeadline: &after},
+			acceptsBids: true, acceptsDecisions: true,
+		},
+		{
+			name: "submission passed", status: TenderStatusPublished, deadlines: TenderDeadlines{SubmissionDeadline: &before},
+			acceptsDecisions: true, expired: true,
+		},
+		{
+			name: "submission deadline is exclusive", status: TenderStatusPublished, deadlines: TenderDeadlines{SubmissionDeadline: &now},
+			acceptsDecisions: true, expired: true,
+		},
+		{
+			name: "bids sealed", status: TenderStatusPublished, deadlines: TenderDeadlines{SubmissionDeadline: &before, BidOpeningAt: &after},
+			sealed: true, expired: true,
+		},
+		{
+			name: "decisions open after submission", status: TenderStatusPublished,
+			deadlines:        TenderDeadlines{SubmissionDeadline: &before, BidOpeningAt: &before, DecisionDeadline: &after},
+			acceptsDecisions: true,
+		},
+		{
+			name: "decision deadline passed", status: TenderStatusPublished, deadlines: TenderDeadlines{DecisionDeadline: &before},
+			acceptsBids: true, expired: true,
+		},
+		{
+			name: "only published tenders expire", status: TenderStatusCreated, deadlines: TenderDeadlines{SubmissionDeadline: &before},
+			acceptsDecisions: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tender := Tender{ID: NewID(), Status: tt.status, Deadlines: tt.deadlines}
+
+			if got := tender.CheckAcceptsBids(now) == nil; got != tt.acceptsBids {
+				t.Errorf("accepts bids: got %t, want %t", got, tt.acceptsBids)
+			}
+			if got := tender.BidsSealed(now); got != tt.sealed {
+				t.Errorf("bids sealed: got %t, want %t", got, tt.sealed)
+			}
+			if got := tender.CheckAcceptsDecisions(now) == nil; got != tt.acceptsDecisions {
+				t.Errorf("accepts decisions: got %t, want %t", got, tt.acceptsDecisions)
+			}
+			if got := tender.IsExpired(now); got != tt.expired {
+				t.Errorf("expired: got %t, want %t", got, tt.expired)
+			}
+		})
+	}
+}
+
+func TestTenderCheckPriceCurrency(t *testing.T) {
+	rub := &Money{Amount: 100, Currency: "RUB"}
+	usd := &Money{Amount: 100, Currency: "USD"}
+
+	tests := []struct {
+		name    string
+		budget  *Money
+		lot     *Money
+		price   *Money
+		wantErr error
+	}{
+		{name: "same currency", budget: rub, price: rub},
+		{name: "other currency", budget: rub, price: usd, wantErr: domain.ErrInvalidArgument},
+		{name: "without price", budget: rub},
+		{name: "without budget", price: usd},
+		{name: "lot budget wins", budget: rub, lot: usd, price: usd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tender := Tender{Budget: tt.budget}
+			err := tender.CheckPriceCurrency(Lot{Budget: tt.lot}, tt.price)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
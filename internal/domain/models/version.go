@@ -0,0 +1,32 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"tenderSystem/internal/domain"
+)
+
+// VersionAuthor is the principal that created a version of a tender or a bid:
+// an employee or an organization acting by one of its API keys
+type VersionAuthor struct {
+	Type PrincipalType
+	ID   ID
+}
+
+func NewVersionAuthor(principal Principal) VersionAuthor {
+	if principal.IsEmployee() {
+		return VersionAuthor{Type: PrincipalTypeEmployee, ID: principal.Employee.ID}
+	}
+
+	return VersionAuthor{Type: PrincipalTypeOrganization, ID: principal.Organization.ID}
+}
+
+// CheckCurrentRevision fails when the current revision is not one of the revisions the caller
+// expects. Nil expected revisions match any, an empty list matches none.
+func CheckCurrentRevision(current int, expected []int) error {
+	if expected == nil || slices.Contains(expected, current) {
+		return nil
+	}
+
+	return fmt.Errorf("revision %d is stale: %w", current, domain.ErrPreconditionFailed)
+}
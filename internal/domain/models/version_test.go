@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+	"tenderSystem/internal/domain"
+	"testing"
+)
+
+func TestNewVersionAuthor(t *testing.T) {
+	employee := Employee{ID: NewID()}
+	organization := Organization{ID: NewID()}
+
+	tests := []struct {
+		name      string
+		principal Principal
+		want      VersionAuthor
+	}{
+		{name: "employee", principal: NewEmployeePrincipal(employee), want: VersionAuthor{Type: PrincipalTypeEmployee, ID: employee.ID}},
+		{
+			name:      "employee acting for an organization",
+			principal: NewEmployeePrincipal(employee).WithMembership(OrganizationMember{Organization: organization}),
+			want:      VersionAuthor{Type: PrincipalTypeEmployee, ID: employee.ID},
+		},
+		{
+			name:      "API key",
+			principal: NewOrganizationPrincipal(organization, []APIKeyScope{APIKeyScopeTendersWrite}),
+			want:      VersionAuthor{Type: PrincipalTypeOrganization, ID: organization.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewVersionAuthor(tt.principal); got != tt.want {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCurrentRevision(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []int
+		wantErr  error
+	}{
+		{name: "any", expected: nil},
+		{name: "current", expected: []int{3}},
+		{name: "one of", expected: []int{2, 3}},
+		{name: "stale", expected: []int{2}, wantErr: domain.ErrPreconditionFailed},
+		{name: "none", expected: []int{}, wantErr: domain.ErrPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckCurrentRevision(3, tt.expected); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
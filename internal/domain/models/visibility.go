@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"tenderSystem/internal/domain"
+)
+
+type TenderVisibility string
+
+const (
+	TenderVisibilityPublic     TenderVisibility = "public"
+	TenderVisibilityInviteOnly TenderVisibility = "invite_only"
+)
+
+func (v TenderVisibility) String() string {
+	return string(v)
+}
+
+func NewTenderVisibility(v string) (TenderVisibility, error) {
+	switch v {
+	case "public":
+		return TenderVisibilityPublic, nil
+	case "invite_only":
+		return TenderVisibilityInviteOnly, nil
+	default:
+		return "", fmt.Errorf("unknown tender visibility: %w", domain.ErrInvalidArgument)
+	}
+}
+
+// TenderInvitee is an organization or an employee invited to an invite-only tender.
+// Inviting an organization invites all of its employees.
+type TenderInvitee struct {
+	Type PrincipalType
+	ID   ID
+}
+
+func NewTenderInvitee(inviteeType string, id ID) (TenderInvitee, error) {
+	switch PrincipalType(inviteeType) {
+	case PrincipalTypeEmployee, PrincipalTypeOrganization:
+		return TenderInvitee{Type: PrincipalType(inviteeType), ID: id}, nil
+	default:
+		return TenderInvitee{}, fmt.Errorf("unknown invitee type %s: %w", inviteeType, domain.ErrInvalidArgument)
+	}
+}
+
+// TenderViewer is who the visibility of a tender is checked for: an employee with the
+// organizations they belong to, or an organization
+type TenderViewer struct {
+	// EmployeeID is nil for an organization
+	EmployeeID      *ID
+	OrganizationIDs []ID
+}
+
+// Includes reports whether the viewer is the invitee or belongs to the invited organization
+func (v TenderViewer) Includes(invitee TenderInvitee) bool {
+	if invitee.Type == PrincipalTypeEmployee {
+		return v.EmployeeID != nil && *v.EmployeeID == invitee.ID
+	}
+
+	return slices.Contains(v.OrganizationIDs, invitee.ID)
+}
+
+// IsVisibleTo reports whether the viewer may see the tender and bid on it: public tenders are
+// visible to everyone, invite-only ones to their organization and the invitees
+func (t *Tender) IsVisibleTo(viewer TenderViewer, invitees []TenderInvitee) bool {
+	if t.Visibility != TenderVisibilityInviteOnly || slices.Contains(viewer.OrganizationIDs, t.OrganizationID) {
+		return true
+	}
+
+	return slices.ContainsFunc(invitees, viewer.Includes)
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"errors"
+	"tenderSystem/internal/domain"
+	"testing"
+)
+
+func TestNewTenderVisibility(t *testing.T) {
+	for _, v := range []string{"public", "invite_only"} {
+		visibility, err := NewTenderVisibility(v)
+		if err != nil || visibility.String() != v {
+			t.Fatalf("got %q, %v for %q", visibility, err, v)
+		}
+	}
+
+	_, err := NewTenderVisibility("private")
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v for an unknown visibility, want ErrInvalidArgument", err)
+	}
+}
+
+func TestNewTenderInvitee(t *testing.T) {
+	id := NewID()
+
+	for _, inviteeType := range []PrincipalType{PrincipalTypeEmployee, PrincipalTypeOrganization} {
+		invitee, err := NewTenderInvitee(string(inviteeType), id)
+		if err != nil || invitee != (TenderInvitee{Type: inviteeType, ID: id}) {
+			t.Fatalf("got %v, %v for %s", invitee, err, inviteeType)
+		}
+	}
+
+	_, err := NewTenderInvitee("user", id)
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v for an unknown type, want ErrInvalidArgument", err)
+	}
+}
+
+func TestTenderIsVisibleTo(t *testing.T) {
+	customer, supplier, other := NewID(), NewID(), NewID()
+	employee := NewID()
+
+	employeeOf := func(organizationIDs ...ID) TenderViewer {
+		return TenderViewer{EmployeeID: &employee, OrganizationIDs: organizationIDs}
+	}
+
+	tests := []struct {
+		name       string
+		visibility TenderVisibility
+		viewer     TenderViewer
+		invitees   []TenderInvitee
+		want       bool
+	}{
+		{name: "public", visibility: TenderVisibilityPublic, viewer: employeeOf(other), want: true},
+		{name: "own organization", visibility: TenderVisibilityInviteOnly, viewer: employeeOf(other, customer), want: true},
+		{name: "own organization by API key", visibility: TenderVisibilityInviteOnly, viewer: TenderViewer{OrganizationIDs: []ID{customer}}, want: true},
+		{name: "not invited", visibility: TenderVisibilityInviteOnly, viewer: employeeOf(other)},
+		{
+			name: "invited employee", visibility: TenderVisibilityInviteOnly, viewer: employeeOf(),
+			invitees: []TenderInvitee{{Type: PrincipalTypeEmployee, ID: employee}}, want: true,
+		},
+		{
+			name: "employee of invited organization", visibility: TenderVisibilityInviteOnly, viewer: employeeOf(other, supplier),
+			invitees: []TenderInvitee{{Type: PrincipalTypeOrganization, ID: supplier}}, want: true,
+		},
+		{
+			name: "invited organization by API key", visibility: TenderVisibilityInviteOnly, viewer: TenderViewer{OrganizationIDs: []ID{supplier}},
+			invitees: []TenderInvitee{{Type: PrincipalTypeOrganization, ID: supplier}}, want: true,
+		},
+		{
+			name: "organization ID is not an employee", visibility: TenderVisibilityInviteOnly, viewer: TenderViewer{OrganizationIDs: []ID{supplier}},
+			invitees: []TenderInvitee{{Type: PrincipalTypeEmployee, ID: supplier}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tender := Tender{OrganizationID: customer, Visibility: tt.visibility}
+			if got := tender.IsVisibleTo(tt.viewer, tt.invitees); got != tt.want {
+				t.Fatalf("got %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
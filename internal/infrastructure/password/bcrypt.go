@@ -0,0 +1,50 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ abstraction.PasswordHasher = &BcryptHasher{}
+
+// BcryptHasher hashes passwords with bcrypt
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher creates a new instance of BcryptHasher
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+
+	return &BcryptHasher{cost: cost}
+}
+
+func (b *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", fmt.Errorf("password is too long: %w", domain.ErrInvalidArgument)
+		}
+		return "", fmt.Errorf("error hashing password: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+func (b *BcryptHasher) Compare(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return fmt.Errorf("password mismatch: %w", domain.ErrUnauthorized)
+		}
+		return fmt.Errorf("error comparing password: %w", err)
+	}
+
+	return nil
+}
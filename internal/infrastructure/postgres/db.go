@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// DB is the connection used by the repositories. Queries run inside the
+// transaction carried by the context if there is one, otherwise on the pool.
+type DB struct {
+	pool *pgxpool.Pool
+}
+
+// NewDB creates a new instance of DB
+func NewDB(pool *pgxpool.Pool) *DB {
+	return &DB{pool: pool}
+}
+
+func withTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+func txFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+func (d *DB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.Exec(ctx, sql, args...)
+	}
+
+	return d.pool.Exec(ctx, sql, args...)
+}
+
+func (d *DB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.Query(ctx, sql, args...)
+	}
+
+	return d.pool.Query(ctx, sql, args...)
+}
+
+func (d *DB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.QueryRow(ctx, sql, args...)
+	}
+
+	return d.pool.QueryRow(ctx, sql, args...)
+}
+
+// Begin starts a transaction, or a savepoint when the context already carries one
+func (d *DB) Begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx.Begin(ctx)
+	}
+
+	return d.pool.Begin(ctx)
+}
+
+// IsUniqueViolation reports whether the error violates the unique constraint
+func IsUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == constraint
+}
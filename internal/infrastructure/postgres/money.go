@@ -0,0 +1,25 @@
+package postgres
+
+import "tenderSystem/internal/domain/models"
+
+// MoneyToColumns splits optional money into the nullable amount and currency columns
+func MoneyToColumns(m *models.Money) (*int64, *string) {
+	if m == nil {
+		return nil, nil
+	}
+
+	currency := m.Currency.String()
+	return &m.Amount, &currency
+}
+
+// MoneyFromColumns builds optional money from the nullable amount and currency columns
+func MoneyFromColumns(amount *int64, currency *string) *models.Money {
+	if amount == nil || currency == nil {
+		return nil
+	}
+
+	return &models.Money{
+		Amount:   *amount,
+		Currency: models.Currency(*currency),
+	}
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+)
+
+// KeysetOrder orders a list by a sort key and then by the unique ID, so a page can continue
+// right after the last row of the previous one even when rows are added in between
+type KeysetOrder struct {
+	// Name identifies the order in the cursors issued for it
+	Name string
+	// Key is the sort key expression and Type its SQL type, cursor keys are cast back to it
+	Key  string
+	Type string
+	Desc bool
+	// NullsLast puts rows with a NULL key after all the others
+	NullsLast bool
+	// ID is the UUID column that makes the order unique
+	ID string
+}
+
+func (o KeysetOrder) direction() string {
+	if o.Desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// OrderBy is the ORDER BY clause of the order
+func (o KeysetOrder) OrderBy() string {
+	nulls := ""
+	if o.NullsLast {
+		nulls = " NULLS LAST"
+	}
+
+	return fmt.Sprintf("%s %s%s, %s %s", o.Key, o.direction(), nulls, o.ID, o.direction())
+}
+
+// PositionColumns are the columns scanned into RowPosition, they follow the selected columns
+func (o KeysetOrder) PositionColumns() string {
+	return fmt.Sprintf("(%s)::TEXT, (%s)::TEXT", o.Key, o.ID)
+}
+
+// after is the condition on the rows following the cursor, its arguments are appended to args
+func (o KeysetOrder) after(cursor abstraction.Cursor, args []any) (string, []any) {
+	comparison := ">"
+	if o.Desc {
+		comparison = "<"
+	}
+
+	args = append(args, cursor.ID)
+	idParam := fmt.Sprintf("$%d::UUID", len(args))
+
+	if cursor.Key == nil {
+		// NULL keys go last, so only the NULL keys after the cursor ID remain
+		return fmt.Sprintf("(%s IS NULL AND %s %s %s)", o.Key, o.ID, comparison, idParam), args
+	}
+
+	args = append(args, *cursor.Key)
+	keyParam := fmt.Sprintf("$%d::TEXT::%s", len(args), o.Type)
+
+	condition := fmt.Sprintf("(%s, %s) %s (%s, %s)", o.Key, o.ID, comparison, keyParam, idParam)
+	if o.NullsLast {
+		condition = fmt.Sprintf("(%s OR %s IS NULL)", condition, o.Key)
+	}
+
+	return condition, args
+}
+
+// RowPosition is the position of a row in a KeysetOrder, scanned from PositionColumns
+type RowPosition struct {
+	Key *string
+	ID  string
+}
+
+// Dest are the scan destinations of PositionColumns
+func (p *RowPosition) Dest() []any {
+	return []any{&p.Key, &p.ID}
+}
+
+// Paginate appends the cursor condition, the order and the page limits to a query ending with
+// its WHERE clause, whose parameters are args. One extra row is fetched to tell whether there
+// is a next page, NextPage drops it.
+func Paginate(query string, args []any, order KeysetOrder, options *abstraction.PaginationOptions) (string, []any, error) {
+	if options.Limit < 0 || options.Offset < 0 {
+		return "", nil, fmt.Errorf("limit and offset must not be negative: %w", domain.ErrInvalidArgument)
+	}
+
+	if options.Cursor != nil {
+		if options.Cursor.Order != order.Name {
+			return "", nil, fmt.Errorf("cursor was issued for another list or sort order: %w", domain.ErrInvalidArgument)
+		}
+
+		var condition string
+		condition, args = order.after(*options.Cursor, args)
+		query += "\n\tAND " + condition
+	}
+
+	query += "\n\tORDER BY " + order.OrderBy()
+
+	if options.Cursor == nil {
+		args = append(args, options.Offset)
+		query += fmt.Sprintf("\n\tOFFSET $%d", len(args))
+	}
+
+	args = append(args, options.Limit+1)
+	query += fmt.Sprintf("\n\tLIMIT $%d", len(args))
+
+	return query, args, nil
+}
+
+// NextPage drops the extra row fetched by Paginate and sets the cursor after the last
+// remaining row in the page info of the options
+func NextPage[T any](rows []T, positions []RowPosition, order KeysetOrder, options *abstraction.PaginationOptions) []T {
+	if len(rows) <= options.Limit {
+		return rows
+	}
+
+	rows = rows[:options.Limit]
+	if options.PageInfo != nil && len(rows) > 0 {
+		last := positions[len(rows)-1]
+		options.PageInfo.NextCursor = abstraction.Cursor{Order: order.Name, Key: last.Key, ID: last.ID}.String()
+	}
+
+	return rows
+}
+
+// CountTotal sets the total in the page info when the options request it, fromWhere holds the
+// FROM and WHERE clauses of the list and args their parameters
+func CountTotal(ctx context.Context, conn *DB, fromWhere string, args []any, options *abstraction.PaginationOptions) error {
+	if options.PageInfo == nil || !options.CountTotal {
+		return nil
+	}
+
+	var total int
+	err := conn.QueryRow(ctx, "SELECT count(*) "+fromWhere, args...).Scan(&total)
+	if err != nil {
+		return fmt.Errorf("error counting rows: %w", err)
+	}
+
+	options.PageInfo.Total = &total
+	return nil
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"testing"
+)
+
+var testOrder = KeysetOrder{Name: "tenders.budget_asc", Key: "tv.budget_amount", Type: "BIGINT", NullsLast: true, ID: "t.id"}
+
+func TestCursorRoundTrip(t *testing.T) {
+	key := "1500"
+	for _, cursor := range []abstraction.Cursor{
+		{Order: testOrder.Name, Key: &key, ID: "550e8400-e29b-41d4-a716-446655440000"},
+		{Order: testOrder.Name, ID: "550e8400-e29b-41d4-a716-446655440000"},
+	} {
+		parsed, err := abstraction.ParseCursor(cursor.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(parsed, cursor) {
+			t.Fatalf("got %#v, want %#v", parsed, cursor)
+		}
+	}
+
+	for _, s := range []string{"not base64!", "bm90IGpzb24", abstraction.Cursor{ID: "550e8400-e29b-41d4-a716-446655440000"}.String(), abstraction.Cursor{Order: "o", ID: "1"}.String()} {
+		if _, err := abstraction.ParseCursor(s); !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("got %v for %q, want invalid argument", err, s)
+		}
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	key := "1500"
+	const where = "SELECT * FROM tender t WHERE t.status = $1"
+
+	tests := []struct {
+		name     string
+		options  abstraction.PaginationOptions
+		wantTail string
+		wantArgs []any
+		wantErr  error
+	}{
+		{
+			name:     "offset",
+			options:  abstraction.PaginationOptions{Limit: 10, Offset: 20},
+			wantTail: "ORDER BY tv.budget_amount ASC NULLS LAST, t.id ASC\n\tOFFSET $2\n\tLIMIT $3",
+			wantArgs: []any{"published", 20, 11},
+		},
+		{
+			name: "cursor ignores offset",
+			options: abstraction.PaginationOptions{Limit: 10, Offset: 20, Cursor: &abstraction.Cursor{
+				Order: testOrder.Name, Key: &key, ID: "550e8400-e29b-41d4-a716-446655440000",
+			}},
+			wantTail: "AND ((tv.budget_amount, t.id) > ($3::TEXT::BIGINT, $2::UUID) OR tv.budget_amount IS NULL)" +
+				"\n\tORDER BY tv.budget_amount ASC NULLS LAST, t.id ASC\n\tLIMIT $4",
+			wantArgs: []any{"published", "550e8400-e29b-41d4-a716-446655440000", key, 11},
+		},
+		{
+			name: "cursor on a null key",
+			options: abstraction.PaginationOptions{Limit: 10, Cursor: &abstraction.Cursor{
+				Order: testOrder.Name, ID: "550e8400-e29b-41d4-a716-446655440000",
+			}},
+			wantTail: "AND (tv.budget_amount IS NULL AND t.id > $2::UUID)" +
+				"\n\tORDER BY tv.budget_amount ASC NULLS LAST, t.id ASC\n\tLIMIT $3",
+			wantArgs: []any{"published", "550e8400-e29b-41d4-a716-446655440000", 11},
+		},
+		{
+			name: "cursor of another order",
+			options: abstraction.PaginationOptions{Limit: 10, Cursor: &abstraction.Cursor{
+				Order: "tenders.name_asc", ID: "550e8400-e29b-41d4-a716-446655440000",
+			}},
+			wantErr: domain.ErrInvalidArgument,
+		},
+		{name: "negative limit", options: abstraction.PaginationOptions{Limit: -1}, wantErr: domain.ErrInvalidArgument},
+		{name: "negative offset", options: abstraction.PaginationOptions{Limit: 10, Offset: -1}, wantErr: domain.ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := Paginate(where, []any{"published"}, testOrder, &tt.options)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !strings.HasPrefix(query, where) || !strings.HasSuffix(query, tt.wantTail) {
+				t.Errorf("got query\n%s\nwant it to end with\n%s", query, tt.wantTail)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("got args %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPaginateDescending(t *testing.T) {
+	order := KeysetOrder{Name: "tenders.created_at_desc", Key: "t.created_at", Type: "TIMESTAMPTZ", Desc: true, ID: "t.id"}
+	key := "2024-01-01 00:00:00+00"
+
+	query, _, err := Paginate("WHERE TRUE", nil, order, &abstraction.PaginationOptions{Limit: 5, Cursor: &abstraction.Cursor{
+		Order: order.Name, Key: &key, ID: "550e8400-e29b-41d4-a716-446655440000",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "WHERE TRUE\n\tAND (t.created_at, t.id) < ($2::TEXT::TIMESTAMPTZ, $1::UUID)\n\tORDER BY t.created_at DESC, t.id DESC\n\tLIMIT $3"
+	if query != want {
+		t.Fatalf("got query\n%s\nwant\n%s", query, want)
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	key := "200"
+	positions := []RowPosition{
+		{Key: &key, ID: "550e8400-e29b-41d4-a716-446655440001"},
+		{ID: "550e8400-e29b-41d4-a716-446655440002"},
+		{ID: "550e8400-e29b-41d4-a716-446655440003"},
+	}
+
+	options := &abstraction.PaginationOptions{Limit: 2, PageInfo: &abstraction.PageInfo{}}
+	rows := NextPage([]string{"a", "b", "c"}, positions, testOrder, options)
+	if !reflect.DeepEqual(rows, []string{"a", "b"}) {
+		t.Fatalf("got %v, want the extra row dropped", rows)
+	}
+
+	cursor, err := abstraction.ParseCursor(options.PageInfo.NextCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (abstraction.Cursor{Order: testOrder.Name, ID: positions[1].ID}); !reflect.DeepEqual(cursor, want) {
+		t.Fatalf("got cursor %#v, want the position of the last row on the page", cursor)
+	}
+
+	last := &abstraction.PaginationOptions{Limit: 3, PageInfo: &abstraction.PageInfo{}}
+	rows = NextPage([]string{"a", "b", "c"}, positions, testOrder, last)
+	if len(rows) != 3 || last.PageInfo.NextCursor != "" {
+		t.Fatalf("got %d rows and cursor %q on the last page", len(rows), last.PageInfo.NextCursor)
+	}
+}
@@ -0,0 +1,164 @@
+// Package postgrestest runs tests against a real Postgres. The database is taken from
+// TEST_DATABASE_URL, tests using it are skipped when the variable is not set.
+package postgrestest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"tenderSystem/internal/infrastructure/postgres"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const databaseURLEnv = "TEST_DATABASE_URL"
+
+// New creates a schema with all the migrations applied and returns a connection using it.
+// The schema is dropped when the test ends.
+func New(t *testing.T) *postgres.DB {
+	t.Helper()
+
+	return NewBefore(t, "")
+}
+
+// NewBefore is New with only the migrations preceding the named one applied, so that a test can
+// fill the old schema and run a data migration with Migrate. An empty name applies all of them.
+func NewBefore(t *testing.T, migration string) *postgres.DB {
+	t.Helper()
+
+	databaseURL := os.Getenv(databaseURLEnv)
+	if databaseURL == "" {
+		t.Skipf("%s is not set", databaseURLEnv)
+	}
+
+	ctx := context.Background()
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+
+	admin, err := pgx.Connect(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("error connecting to the test database: %v", err)
+	}
+	defer admin.Close(ctx)
+
+	_, err = admin.Exec(ctx, "CREATE SCHEMA "+schema)
+	if err != nil {
+		t.Fatalf("error creating schema: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn, err := pgx.Connect(context.Background(), databaseURL)
+		if err != nil {
+			t.Errorf("error connecting to the test database: %v", err)
+			return
+		}
+		defer conn.Close(context.Background())
+
+		_, err = conn.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		if err != nil {
+			t.Errorf("error dropping schema: %v", err)
+		}
+	})
+
+	config, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		t.Fatalf("error parsing %s: %v", databaseURLEnv, err)
+	}
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("error connecting to the test database: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	db := postgres.NewDB(pool)
+
+	paths, err := migrationPaths()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range paths {
+		if migration != "" && filepath.Base(path) >= migration {
+			break
+		}
+
+		err = apply(ctx, db, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return db
+}
+
+// Migrate applies the up section of the named migration, e.g. "00011_tender_lots.sql"
+func Migrate(t *testing.T, db *postgres.DB, migration string) {
+	t.Helper()
+
+	paths, err := migrationPaths()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range paths {
+		if filepath.Base(path) == migration {
+			err = apply(context.Background(), db, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return
+		}
+	}
+
+	t.Fatalf("migration %s not found", migration)
+}
+
+// migrationPaths returns the goose migrations in order
+func migrationPaths() ([]string, error) {
+	_, file, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(file), "..", "..", "..", "..", "migrations")
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func apply(ctx context.Context, db *postgres.DB, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(ctx, upSection(string(content)))
+	if err != nil {
+		return fmt.Errorf("error applying migration %s: %w", filepath.Base(path), err)
+	}
+
+	return nil
+}
+
+func upSection(migration string) string {
+	up, _, _ := strings.Cut(migration, "-- +goose Down")
+
+	var statements []string
+	for _, line := range strings.Split(up, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "-- +goose") {
+			continue
+		}
+		statements = append(statements, line)
+	}
+
+	return strings.Join(statements, "\n")
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// SearchQuery is the tsquery of the user input in the parameter, parsed with both configurations
+// used by the search_vector columns
+func SearchQuery(param string) string {
+	return fmt.Sprintf("(websearch_to_tsquery('russian', %[1]s) || websearch_to_tsquery('english', %[1]s))", param)
+}
+
+// Headline is the ts_headline snippet of the text expression matching the tsquery expression. The matches
+// are delimited by the control characters \x01 and \x02, which are stripped from the text beforehand,
+// so that HighlightHeadline can tell them from the user text after escaping it.
+func Headline(text, query string) string {
+	return fmt.Sprintf(`ts_headline('russian', translate(%s, chr(1) || chr(2), ''), %s,
+		'StartSel=' || chr(1) || ', StopSel=' || chr(2) || ', MaxFragments=2, MaxWords=30, MinWords=10')`, text, query)
+}
+
+var headlineMarks = strings.NewReplacer("\x01", "<mark>", "\x02", "</mark>")
+
+// HighlightHeadline HTML-escapes a Headline snippet and marks its matches with <mark> tags
+func HighlightHeadline(snippet string) string {
+	return headlineMarks.Replace(html.EscapeString(snippet))
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import "testing"
+
+func TestHighlightHeadline(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		want    string
+	}{
+		{name: "plain", snippet: "поставка \x01бетона\x02 М400", want: "поставка <mark>бетона</mark> М400"},
+		{name: "no matches", snippet: "поставка бетона", want: "поставка бетона"},
+		{
+			name:    "markup in text",
+			snippet: "<script>alert(1)</script> \x01бетон\x02 <mark>",
+			want:    "&lt;script&gt;alert(1)&lt;/script&gt; <mark>бетон</mark> &lt;mark&gt;",
+		},
+		{name: "entities and quotes", snippet: "\"A&B\" \x01'C'\x02", want: "&#34;A&amp;B&#34; <mark>&#39;C&#39;</mark>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightHeadline(tt.snippet); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"tenderSystem/internal/abstraction"
+)
+
+var _ abstraction.TransactionManager = &TransactionManager{}
+
+// TransactionManager runs use case steps in one database transaction
+type TransactionManager struct {
+	db *DB
+}
+
+// NewTransactionManager creates a new instance of TransactionManager
+func NewTransactionManager(db *DB) *TransactionManager {
+	return &TransactionManager{db: db}
+}
+
+// Do runs fn in a transaction carried by the context passed to it. Nested calls
+// join the outer transaction. The transaction is rolled back if fn returns an error.
+func (t *TransactionManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+
+	err = fn(withTx(ctx, tx))
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
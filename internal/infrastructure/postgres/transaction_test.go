@@ -0,0 +1,99 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"tenderSystem/internal/infrastructure/postgres"
+	"tenderSystem/internal/infrastructure/postgres/postgrestest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func countEmployees(t *testing.T, db *postgres.DB, usernames ...string) int {
+	t.Helper()
+
+	var count int
+	err := db.QueryRow(context.Background(), `SELECT count(*) FROM employee WHERE username = ANY($1)`, usernames).Scan(&count)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return count
+}
+
+func insertEmployee(ctx context.Context, db *postgres.DB, username string) error {
+	_, err := db.Exec(ctx, `INSERT INTO employee (id, username) VALUES ($1, $2)`, uuid.New(), username)
+	return err
+}
+
+func TestTransactionManagerCommits(t *testing.T) {
+	db := postgrestest.New(t)
+	transactionManager := postgres.NewTransactionManager(db)
+
+	err := transactionManager.Do(context.Background(), func(ctx context.Context) error {
+		if err := insertEmployee(ctx, db, "first"); err != nil {
+			return err
+		}
+		return insertEmployee(ctx, db, "second")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countEmployees(t, db, "first", "second"); count != 2 {
+		t.Fatalf("got %d employees after the commit, want 2", count)
+	}
+}
+
+func TestTransactionManagerRollsBack(t *testing.T) {
+	db := postgrestest.New(t)
+	transactionManager := postgres.NewTransactionManager(db)
+	errStep := errors.New("step failed")
+
+	err := transactionManager.Do(context.Background(), func(ctx context.Context) error {
+		if err := insertEmployee(ctx, db, "first"); err != nil {
+			return err
+		}
+
+		// the nested call joins the outer transaction, its failure undoes the outer writes too
+		return transactionManager.Do(ctx, func(ctx context.Context) error {
+			if err := insertEmployee(ctx, db, "second"); err != nil {
+				return err
+			}
+			return errStep
+		})
+	})
+	if !errors.Is(err, errStep) {
+		t.Fatalf("got %v, want the error of the step", err)
+	}
+
+	if count := countEmployees(t, db, "first", "second"); count != 0 {
+		t.Fatalf("got %d employees after the rollback, want none", count)
+	}
+}
+
+func TestTransactionIsolatesUncommittedWrites(t *testing.T) {
+	db := postgrestest.New(t)
+	transactionManager := postgres.NewTransactionManager(db)
+
+	err := transactionManager.Do(context.Background(), func(ctx context.Context) error {
+		if err := insertEmployee(ctx, db, "pending"); err != nil {
+			return err
+		}
+
+		// queries without the transaction context run on another connection of the pool
+		if count := countEmployees(t, db, "pending"); count != 0 {
+			t.Errorf("uncommitted employee is visible outside the transaction")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := countEmployees(t, db, "pending"); count != 1 {
+		t.Fatalf("got %d employees after the commit, want 1", count)
+	}
+}
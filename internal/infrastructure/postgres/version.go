@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"github.com/google/uuid"
+	"tenderSystem/internal/domain/models"
+)
+
+// VersionAuthorToColumns splits an optional version author into the nullable created_by columns
+func VersionAuthorToColumns(author *models.VersionAuthor) (*string, *uuid.UUID) {
+	if author == nil {
+		return nil, nil
+	}
+
+	authorType := string(author.Type)
+	authorID := uuid.UUID(author.ID)
+	return &authorType, &authorID
+}
+
+// VersionAuthorFromColumns builds an optional version author from the nullable created_by columns
+func VersionAuthorFromColumns(authorType *string, authorID *uuid.UUID) *models.VersionAuthor {
+	if authorType == nil || authorID == nil {
+		return nil
+	}
+
+	return &models.VersionAuthor{
+		Type: models.PrincipalType(*authorType),
+		ID:   models.ID(*authorID),
+	}
+}
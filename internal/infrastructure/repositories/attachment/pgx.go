@@ -0,0 +1,192 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"time"
+)
+
+var _ abstraction.AttachmentRepository = &PGXRepository{}
+
+type attachment struct {
+	ID          uuid.UUID
+	OwnerType   string
+	OwnerID     uuid.UUID
+	FileName    string
+	ContentType string
+	Size        int64
+	SHA256      string
+	StorageKey  string
+	UploadedBy  *uuid.UUID
+	CreatedAt   time.Time
+}
+
+func (a attachment) toModel() models.Attachment {
+	var uploadedBy *models.ID
+	if a.UploadedBy != nil {
+		id := models.ID(*a.UploadedBy)
+		uploadedBy = &id
+	}
+
+	return models.Attachment{
+		ID:          models.ID(a.ID),
+		OwnerType:   models.AttachmentOwnerType(a.OwnerType),
+		OwnerID:     models.ID(a.OwnerID),
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.SHA256,
+		StorageKey:  a.StorageKey,
+		UploadedBy:  uploadedBy,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// linkQueries link an attachment to the current version of its owner
+var linkQueries = map[models.AttachmentOwnerType]string{
+	models.AttachmentOwnerTypeTender: `
+		INSERT INTO tender_version_attachment (tender_version_id, attachment_id)
+		SELECT current_version_id, $1
+		FROM tender
+		WHERE id = $2
+	`,
+	models.AttachmentOwnerTypeBid: `
+		INSERT INTO bid_version_attachment (bid_version_id, attachment_id)
+		SELECT current_version_id, $1
+		FROM bid
+		WHERE id = $2
+	`,
+}
+
+const attachmentSelectQuery = `
+	SELECT a.id, a.owner_type, a.owner_id, a.file_name, a.content_type, a.size, a.sha256, a.storage_key, a.uploaded_by, a.created_at
+	FROM attachment a
+`
+
+// ownerQueries select the attachments of the owner version, of the current one when $2 is NULL
+var ownerQueries = map[models.AttachmentOwnerType]string{
+	models.AttachmentOwnerTypeTender: attachmentSelectQuery + `
+		JOIN tender_version_attachment tva ON tva.attachment_id = a.id
+		JOIN tender_version tv ON tv.id = tva.tender_version_id
+		JOIN tender t ON t.id = tv.tender_id
+		WHERE t.id = $1 AND (($2::INT IS NULL AND tv.id = t.current_version_id) OR tv.version = $2)
+		ORDER BY a.created_at
+	`,
+	models.AttachmentOwnerTypeBid: attachmentSelectQuery + `
+		JOIN bid_version_attachment bva ON bva.attachment_id = a.id
+		JOIN bid_version bv ON bv.id = bva.bid_version_id
+		JOIN bid b ON b.id = bv.bid_id
+		WHERE b.id = $1 AND (($2::INT IS NULL AND bv.id = b.current_version_id) OR bv.version = $2)
+		ORDER BY a.created_at
+	`,
+}
+
+// PGXRepository is a repository for working with attachments using pgx driver
+type PGXRepository struct {
+	conn *postgres.DB
+}
+
+// NewPGXRepository creates a new instance of PGXRepository
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
+	return &PGXRepository{conn: conn}
+}
+
+func (P *PGXRepository) Create(ctx context.Context, data *models.Attachment) (models.Attachment, error) {
+	const query = `
+		INSERT INTO attachment (id, owner_type, owner_id, file_name, content_type, size, sha256, storage_key, uploaded_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	linkQuery, ok := linkQueries[data.OwnerType]
+	if !ok {
+		return models.Attachment{}, fmt.Errorf("unknown attachment owner type %s: %w", data.OwnerType, domain.ErrInvalidArgument)
+	}
+
+	tx, err := P.conn.Begin(ctx)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	_, err = tx.Exec(ctx, query, data.ID, data.OwnerType.String(), data.OwnerID, data.FileName, data.ContentType, data.Size, data.SHA256, data.StorageKey, data.UploadedBy, data.CreatedAt)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return models.Attachment{}, err
+	}
+
+	tag, err := tx.Exec(ctx, linkQuery, data.ID, data.OwnerID)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return models.Attachment{}, err
+	}
+
+	if tag.RowsAffected() == 0 {
+		_ = tx.Rollback(ctx)
+		return models.Attachment{}, fmt.Errorf("%s with ID %s not found: %w", data.OwnerType, data.OwnerID, domain.ErrNotFound)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	return *data, nil
+}
+
+func scanAttachment(row pgx.Row) (models.Attachment, error) {
+	var entity attachment
+
+	err := row.Scan(&entity.ID, &entity.OwnerType, &entity.OwnerID, &entity.FileName, &entity.ContentType, &entity.Size, &entity.SHA256, &entity.StorageKey, &entity.UploadedBy, &entity.CreatedAt)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	return entity.toModel(), nil
+}
+
+func (P *PGXRepository) GetByID(ctx context.Context, id models.ID) (models.Attachment, error) {
+	const query = attachmentSelectQuery + `
+		WHERE a.id = $1
+	`
+
+	attachment, err := scanAttachment(P.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Attachment{}, fmt.Errorf("attachment with ID %s not found: %w", id, domain.ErrNotFound)
+		}
+		return models.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+
+func (P *PGXRepository) GetByOwner(ctx context.Context, ownerType models.AttachmentOwnerType, ownerID models.ID, version *int) ([]models.Attachment, error) {
+	query, ok := ownerQueries[ownerType]
+	if !ok {
+		return nil, fmt.Errorf("unknown attachment owner type %s: %w", ownerType, domain.ErrInvalidArgument)
+	}
+
+	rows, err := P.conn.Query(ctx, query, ownerID, version)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := make([]models.Attachment, 0)
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
@@ -2,10 +2,12 @@ package decision
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
 	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
 	"time"
 )
 
@@ -21,11 +23,11 @@ type bidDecision struct {
 
 // PGXRepository is a repository for working with bid decisions using pgx driver
 type PGXRepository struct {
-	conn *pgx.Conn
+	conn *postgres.DB
 }
 
 // NewPGXRepository creates a new instance of PGXRepository
-func NewPGXRepository(conn *pgx.Conn) *PGXRepository {
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
 	return &PGXRepository{conn: conn}
 }
 
@@ -33,13 +35,18 @@ func (P *PGXRepository) Create(ctx context.Context, data *models.BidDecision) (m
 	const query = `
 		INSERT INTO bid_decision (id, bid_id, decision, employee_id)
 		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (bid_id, employee_id) DO NOTHING
 	`
 
-	_, err := P.conn.Exec(ctx, query, data.ID, data.BidID, data.Decision, data.EmployeeID)
+	tag, err := P.conn.Exec(ctx, query, data.ID, data.BidID, data.Decision, data.EmployeeID)
 	if err != nil {
 		return models.BidDecision{}, err
 	}
 
+	if tag.RowsAffected() == 0 {
+		return models.BidDecision{}, fmt.Errorf("employee %s has already decided on bid %s: %w", data.EmployeeID, data.BidID, domain.ErrAlreadyExists)
+	}
+
 	return *data, nil
 }
 
@@ -3,9 +3,9 @@ package feedback
 import (
 	"context"
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
 	"tenderSystem/internal/abstraction"
 	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
 	"time"
 )
 
@@ -21,11 +21,11 @@ type bidFeedback struct {
 
 // PGXRepository is a repository for working with bid feedback using pgx driver
 type PGXRepository struct {
-	conn *pgx.Conn
+	conn *postgres.DB
 }
 
 // NewPGXRepository creates a new instance of PGXRepository
-func NewPGXRepository(conn *pgx.Conn) *PGXRepository {
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
 	return &PGXRepository{conn: conn}
 }
 
@@ -43,23 +43,39 @@ func (P *PGXRepository) Create(ctx context.Context, data *models.BidFeedback) (m
 	return *data, nil
 }
 
+// feedbacksOrder puts the latest feedback first
+var feedbacksOrder = postgres.KeysetOrder{Name: "bid_feedbacks", Key: "created_at", Type: "TIMESTAMP", Desc: true, ID: "id"}
+
 func (P *PGXRepository) GetByAuthorID(ctx context.Context, authorID models.ID, options ...abstraction.PaginationOptFunc) ([]models.BidFeedback, error) {
-	const query = `
-		SELECT id, bid_id, description, author_id, created_at
+	const fromWhere = `
 		FROM bid_feedback
 		WHERE author_id = $1
 	`
 
-	rows, err := P.conn.Query(ctx, query, authorID)
+	paginationOptions, err := abstraction.NewPaginationOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []any{authorID}
+	query, queryArgs, err := postgres.Paginate("SELECT id, bid_id, description, author_id, created_at, "+feedbacksOrder.PositionColumns()+fromWhere, args, feedbacksOrder, paginationOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := P.conn.Query(ctx, query, queryArgs...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	var feedbacks []models.BidFeedback
+	var positions []postgres.RowPosition
 	for rows.Next() {
 		var feedback bidFeedback
-		err := rows.Scan(&feedback.ID, &feedback.BidID, &feedback.Description, &feedback.AuthorID, &feedback.CreatedAt)
+		var position postgres.RowPosition
+
+		err := rows.Scan(append([]any{&feedback.ID, &feedback.BidID, &feedback.Description, &feedback.AuthorID, &feedback.CreatedAt}, position.Dest()...)...)
 		if err != nil {
 			return nil, err
 		}
@@ -71,6 +87,18 @@ func (P *PGXRepository) GetByAuthorID(ctx context.Context, authorID models.ID, o
 			AuthorID:    models.ID(feedback.AuthorID),
 			CreatedAt:   feedback.CreatedAt,
 		})
+		positions = append(positions, position)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	feedbacks = postgres.NextPage(feedbacks, positions, feedbacksOrder, paginationOptions)
+
+	err = postgres.CountTotal(ctx, P.conn, fromWhere, args, paginationOptions)
+	if err != nil {
+		return nil, err
 	}
 
 	return feedbacks, nil
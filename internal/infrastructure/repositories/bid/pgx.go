@@ -9,6 +9,7 @@ import (
 	"tenderSystem/internal/abstraction"
 	"tenderSystem/internal/domain"
 	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
 	"time"
 )
 
@@ -17,6 +18,7 @@ var _ abstraction.BidRepository = &PGXRepository{}
 type bid struct {
 	ID       uuid.UUID
 	TenderID uuid.UUID
+	LotID    uuid.UUID
 
 	Status string
 
@@ -34,26 +36,34 @@ type bidVersion struct {
 
 	Name        string
 	Description string
+
+	PriceAmount   *int64
+	PriceCurrency *string
+
+	CreatedByType *string
+	CreatedByID   *uuid.UUID
+
+	RolledBackFrom *int
 }
 
 // PGXRepository is a repository for working with bids using pgx driver
 type PGXRepository struct {
-	conn *pgx.Conn
+	conn *postgres.DB
 }
 
 // NewPGXRepository creates a new instance of PGXRepository
-func NewPGXRepository(conn *pgx.Conn) *PGXRepository {
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
 	return &PGXRepository{conn: conn}
 }
 
 func (P *PGXRepository) Create(ctx context.Context, data *models.Bid) (models.Bid, error) {
 	const bidInsertQuery = `
-		INSERT INTO bid (id, tender_id, status, author_type, author_id, current_version_id)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO bid (id, tender_id, lot_id, status, author_type, author_id, current_version_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 	const bidVersionInsertQuery = `
-		INSERT INTO bid_version (id, bid_id, version, name, description)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO bid_version (id, bid_id, version, name, description, price_amount, price_currency, created_by_type, created_by_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	tx, err := P.conn.Begin(ctx)
@@ -69,23 +79,28 @@ func (P *PGXRepository) Create(ctx context.Context, data *models.Bid) (models.Bi
 		Name:        data.Name,
 		Description: data.Description,
 	}
+	bidVersionEntity.PriceAmount, bidVersionEntity.PriceCurrency = postgres.MoneyToColumns(data.Price)
+	bidVersionEntity.CreatedByType, bidVersionEntity.CreatedByID = postgres.VersionAuthorToColumns(data.VersionAuthor)
 
 	bidEntity := bid{
 		ID:               uuid.UUID(data.ID),
 		TenderID:         uuid.UUID(data.TenderID),
+		LotID:            uuid.UUID(data.LotID),
 		Status:           string(data.Status),
 		AuthorType:       string(data.AuthorType),
 		AuthorID:         uuid.UUID(data.AuthorID),
 		CurrentVersionID: bidVersionEntity.ID,
 	}
 
-	_, err = tx.Exec(ctx, bidInsertQuery, bidEntity.ID, bidEntity.TenderID, bidEntity.Status, bidEntity.AuthorType, bidEntity.AuthorID, bidEntity.CurrentVersionID)
+	_, err = tx.Exec(ctx, bidInsertQuery, bidEntity.ID, bidEntity.TenderID, bidEntity.LotID, bidEntity.Status, bidEntity.AuthorType, bidEntity.AuthorID, bidEntity.CurrentVersionID)
 	if err != nil {
 		_ = tx.Rollback(ctx)
 		return models.Bid{}, err
 	}
 
-	_, err = tx.Exec(ctx, bidVersionInsertQuery, bidVersionEntity.ID, bidVersionEntity.BidID, bidVersionEntity.Version, bidVersionEntity.Name, bidVersionEntity.Description)
+	_, err = tx.Exec(ctx, bidVersionInsertQuery, bidVersionEntity.ID, bidVersionEntity.BidID, bidVersionEntity.Version, bidVersionEntity.Name, bidVersionEntity.Description, bidVersionEntity.PriceAmount, bidVersionEntity.PriceCurrency,
+		bidVersionEntity.CreatedByType, bidVersionEntity.CreatedByID,
+	)
 	if err != nil {
 		_ = tx.Rollback(ctx)
 		return models.Bid{}, err
@@ -99,19 +114,67 @@ func (P *PGXRepository) Create(ctx context.Context, data *models.Bid) (models.Bi
 	return *data, nil
 }
 
+const bidSelectColumns = `
+	b.id, b.tender_id, b.lot_id, b.status, b.author_type, b.author_id, bv.name, bv.description, bv.price_amount, bv.price_currency, bv.version, b.created_at,
+	bv.created_by_type, bv.created_by_id, bv.rolled_back_from, b.revision
+`
+
+// bidFromClause joins the bids to their current versions
+const bidFromClause = `
+	FROM bid b
+	JOIN bid_version bv ON b.current_version_id = bv.id
+`
+
+const bidSelectQuery = `
+	SELECT ` + bidSelectColumns + bidFromClause
+
+func scanBid(row pgx.Row) (models.Bid, error) {
+	return scanBidWith(row)
+}
+
+// scanBidWith scans a row of bidSelectQuery followed by the extra columns
+func scanBidWith(row pgx.Row, extra ...any) (models.Bid, error) {
+	var bid models.Bid
+	var bidVersionEntity bidVersion
+
+	dest := []any{&bid.ID, &bid.TenderID, &bid.LotID, &bid.Status, &bid.AuthorType, &bid.AuthorID, &bid.Name, &bid.Description, &bidVersionEntity.PriceAmount, &bidVersionEntity.PriceCurrency, &bid.Version, &bid.CreatedAt,
+		&bidVersionEntity.CreatedByType, &bidVersionEntity.CreatedByID, &bid.RolledBackFrom, &bid.Revision}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	bid.Price = postgres.MoneyFromColumns(bidVersionEntity.PriceAmount, bidVersionEntity.PriceCurrency)
+	bid.VersionAuthor = postgres.VersionAuthorFromColumns(bidVersionEntity.CreatedByType, bidVersionEntity.CreatedByID)
+
+	return bid, nil
+}
+
 func (P *PGXRepository) GetByID(ctx context.Context, id models.ID) (models.Bid, error) {
-	const bidSelectQuery = `
-		SELECT b.id, b.tender_id, b.status, b.author_type, b.author_id, bv.name, bv.description, bv.version, b.created_at
-		FROM bid b
-		JOIN bid_version bv ON b.current_version_id = bv.id
+	const query = bidSelectQuery + `
 		WHERE b.id = $1
 	`
 
-	row := P.conn.QueryRow(ctx, bidSelectQuery, id)
+	bid, err := scanBid(P.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Bid{}, fmt.Errorf("bid with ID %s not found: %w", id, domain.ErrNotFound)
+		}
+		return models.Bid{}, err
+	}
+
+	return bid, nil
+}
 
-	var bid models.Bid
+// LockByID locks the bid row until the end of the transaction carried by ctx and returns the bid
+func (P *PGXRepository) LockByID(ctx context.Context, id models.ID) (models.Bid, error) {
+	const query = bidSelectQuery + `
+		WHERE b.id = $1
+		FOR UPDATE OF b
+	`
 
-	err := row.Scan(&bid.ID, &bid.TenderID, &bid.Status, &bid.AuthorType, &bid.AuthorID, &bid.Name, &bid.Description, &bid.Version, &bid.CreatedAt)
+	bid, err := scanBid(P.conn.QueryRow(ctx, query, uuid.UUID(id)))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.Bid{}, fmt.Errorf("bid with ID %s not found: %w", id, domain.ErrNotFound)
@@ -122,14 +185,12 @@ func (P *PGXRepository) GetByID(ctx context.Context, id models.ID) (models.Bid,
 	return bid, nil
 }
 
+// publishedOrder puts the latest published bids first
+var publishedOrder = postgres.KeysetOrder{Name: "bids.published", Key: "b.created_at", Type: "TIMESTAMP", Desc: true, ID: "b.id"}
+
 func (P *PGXRepository) GetAll(ctx context.Context, options ...abstraction.PaginationOptFunc) ([]models.Bid, error) {
-	const bidSelectQuery = `
-		SELECT b.id, b.tender_id, b.status, b.author_type, b.author_id, bv.name, bv.description, bv.version, b.created_at
-		FROM bid b
-		JOIN bid_version bv ON b.current_version_id = bv.id
+	const fromWhere = bidFromClause + `
 		WHERE b.status = 'published'
-		ORDER BY b.created_at DESC
-		LIMIT $1 OFFSET $2
 	`
 
 	paginationOpts, err := abstraction.NewPaginationOptions(options...)
@@ -137,132 +198,228 @@ func (P *PGXRepository) GetAll(ctx context.Context, options ...abstraction.Pagin
 		return nil, err
 	}
 
-	rows, err := P.conn.Query(ctx, bidSelectQuery, paginationOpts.Limit, paginationOpts.Offset)
+	return P.page(ctx, fromWhere, nil, publishedOrder, paginationOpts)
+}
+
+// page returns a page of the bids matched by fromWhere with the arguments args
+func (P *PGXRepository) page(ctx context.Context, fromWhere string, args []any, order postgres.KeysetOrder, pagination *abstraction.PaginationOptions) ([]models.Bid, error) {
+	query, queryArgs, err := postgres.Paginate("SELECT "+bidSelectColumns+", "+order.PositionColumns()+fromWhere, args, order, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := P.conn.Query(ctx, query, queryArgs...)
 	if err != nil {
 		return nil, err
 	}
 
-	var bids []models.Bid
+	defer rows.Close()
 
+	var bids []models.Bid
+	var positions []postgres.RowPosition
 	for rows.Next() {
-		var bid models.Bid
+		var position postgres.RowPosition
 
-		err := rows.Scan(&bid.ID, &bid.TenderID, &bid.Status, &bid.AuthorType, &bid.AuthorID, &bid.Name, &bid.Description, &bid.Version, &bid.CreatedAt)
+		bid, err := scanBidWith(rows, position.Dest()...)
 		if err != nil {
 			return nil, err
 		}
 
 		bids = append(bids, bid)
+		positions = append(positions, position)
 	}
 
-	return bids, nil
-}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
 
-func (P *PGXRepository) GetByAuthorID(ctx context.Context, authorID models.ID, options ...abstraction.PaginationOptFunc) ([]models.Bid, error) {
-	const bidSelectQuery = `
-		SELECT b.id, b.tender_id, b.status, b.author_type, b.author_id, bv.name, bv.description, bv.version, b.created_at
-		FROM bid b
-		JOIN bid_version bv ON b.current_version_id = bv.id
-		WHERE b.author_id = $1	
-		ORDER BY b.created_at DESC
-		LIMIT $2 OFFSET $3
-	`
+	bids = postgres.NextPage(bids, positions, order, pagination)
 
-	paginationOpts, err := abstraction.NewPaginationOptions(options...)
+	err = postgres.CountTotal(ctx, P.conn, fromWhere, args, pagination)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := P.conn.Query(ctx, bidSelectQuery, authorID, paginationOpts.Limit, paginationOpts.Offset)
+	return bids, nil
+}
+
+// bidFiltersClause applies GetBidsOptions, its arguments are built by bidFiltersArgs.
+// $1 and $2 limit the tender and the author.
+const bidFiltersClause = `
+	($1::UUID IS NULL OR b.tender_id = $1)
+	AND ($2::UUID IS NULL OR b.author_id = $2)
+	AND ($3::UUID IS NULL OR b.lot_id = $3)
+	AND ($4 = '{}' OR b.status = any($4))
+	AND ($5::UUID IS NULL OR (b.author_type = 'organization' AND b.author_id = $5))
+	AND ($6::TIMESTAMPTZ IS NULL OR b.created_at >= $6)
+	AND ($7::TIMESTAMPTZ IS NULL OR b.created_at <= $7)
+	AND ($8::INT IS NULL OR bv.version >= $8)
+	AND ($9::INT IS NULL OR bv.version <= $9)
+`
+
+func optionalUUID(id *models.ID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+
+	u := uuid.UUID(*id)
+	return &u
+}
+
+// bidFiltersArgs returns the arguments of bidFiltersClause, the page arguments follow them
+func bidFiltersArgs(tenderID, authorID *models.ID, getBidsOptions *abstraction.GetBidsOptions) []any {
+	statuses := make([]string, 0, len(getBidsOptions.Statuses))
+	for _, status := range getBidsOptions.Statuses {
+		statuses = append(statuses, status.String())
+	}
+
+	return []any{
+		optionalUUID(tenderID), optionalUUID(authorID),
+		optionalUUID(getBidsOptions.LotID), statuses, optionalUUID(getBidsOptions.OrganizationID),
+		getBidsOptions.CreatedAt.From, getBidsOptions.CreatedAt.To,
+		getBidsOptions.Version.Min, getBidsOptions.Version.Max,
+	}
+}
+
+// bidOrders maps the sort orders to keyset orders, bids without a price go last
+var bidOrders = map[abstraction.BidSortOrder]postgres.KeysetOrder{
+	abstraction.BidSortCreatedAtDesc: {Name: "bids.created_at_desc", Key: "b.created_at", Type: "TIMESTAMP", Desc: true, ID: "b.id"},
+	abstraction.BidSortCreatedAtAsc:  {Name: "bids.created_at_asc", Key: "b.created_at", Type: "TIMESTAMP", ID: "b.id"},
+	abstraction.BidSortNameAsc:       {Name: "bids.name_asc", Key: "bv.name", Type: "TEXT", ID: "b.id"},
+	abstraction.BidSortNameDesc:      {Name: "bids.name_desc", Key: "bv.name", Type: "TEXT", Desc: true, ID: "b.id"},
+	abstraction.BidSortPriceAsc:      {Name: "bids.price_asc", Key: "bv.price_amount", Type: "BIGINT", NullsLast: true, ID: "b.id"},
+	abstraction.BidSortPriceDesc:     {Name: "bids.price_desc", Key: "bv.price_amount", Type: "BIGINT", Desc: true, NullsLast: true, ID: "b.id"},
+}
+
+// list returns the bids of the tender and the author, nil IDs are not limited
+func (P *PGXRepository) list(ctx context.Context, tenderID, authorID *models.ID, options ...abstraction.GetBidsOptFunc) ([]models.Bid, error) {
+	getBidsOptions, err := abstraction.NewGetBidsOptions(options...)
 	if err != nil {
 		return nil, err
 	}
 
-	var bids []models.Bid
+	order, ok := bidOrders[getBidsOptions.SortOrder]
+	if !ok {
+		return nil, fmt.Errorf("unknown bid sort order %s: %w", getBidsOptions.SortOrder, domain.ErrInvalidArgument)
+	}
 
-	for rows.Next() {
-		var bid models.Bid
+	fromWhere := bidFromClause + `
+		WHERE ` + bidFiltersClause
 
-		err := rows.Scan(&bid.ID, &bid.TenderID, &bid.Status, &bid.AuthorType, &bid.AuthorID, &bid.Name, &bid.Description, &bid.Version, &bid.CreatedAt)
-		if err != nil {
-			return nil, err
-		}
+	return P.page(ctx, fromWhere, bidFiltersArgs(tenderID, authorID, getBidsOptions), order, getBidsOptions.PaginationOptions)
+}
 
-		bids = append(bids, bid)
-	}
+func (P *PGXRepository) GetByAuthorID(ctx context.Context, authorID models.ID, options ...abstraction.GetBidsOptFunc) ([]models.Bid, error) {
+	return P.list(ctx, nil, &authorID, options...)
+}
 
-	return bids, nil
+func (P *PGXRepository) GetByTenderID(ctx context.Context, tenderID models.ID, options ...abstraction.GetBidsOptFunc) ([]models.Bid, error) {
+	return P.list(ctx, &tenderID, nil, options...)
 }
 
-func (P *PGXRepository) GetByTenderID(ctx context.Context, tenderID models.ID, options ...abstraction.PaginationOptFunc) ([]models.Bid, error) {
-	const bidSelectQuery = `
-		SELECT b.id, b.tender_id, b.status, b.author_type, b.author_id, bv.name, bv.description, bv.version, b.created_at
-		FROM bid b
-		JOIN bid_version bv ON b.current_version_id = bv.id
-		WHERE b.tender_id = $1
-		ORDER BY b.created_at DESC
-		LIMIT $2 OFFSET $3
+// searchFromWhere matches the full-text query in $10, only current versions are matched, so the
+// results follow Update and Rollback without reindexing
+var searchFromWhere = bidFromClause + `
+	CROSS JOIN (SELECT ` + postgres.SearchQuery("$10") + ` AS query) s
+	WHERE bv.search_vector @@ s.query AND ` + bidFiltersClause
+
+// bidSearchOrder replaces the sort order of the search results by the relevance
+var bidSearchOrder = postgres.KeysetOrder{Name: "bids.relevance", Key: "ts_rank(bv.search_vector, s.query)", Type: "REAL", Desc: true, ID: "b.id"}
+
+func (P *PGXRepository) SearchByTenderID(ctx context.Context, tenderID models.ID, text string, options ...abstraction.GetBidsOptFunc) ([]models.BidSearchResult, error) {
+	getBidsOptions, err := abstraction.NewGetBidsOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	selectClause := `
+		SELECT ` + bidSelectColumns + `, ` + bidSearchOrder.PositionColumns() + `,
+		       ts_rank(bv.search_vector, s.query),
+		       ` + postgres.Headline("bv.name || ' ' || bv.description", "s.query") + `
 	`
+	args := append(bidFiltersArgs(&tenderID, nil, getBidsOptions), text)
+	pagination := getBidsOptions.PaginationOptions
 
-	paginationOpts, err := abstraction.NewPaginationOptions(options...)
+	query, queryArgs, err := postgres.Paginate(selectClause+searchFromWhere, args, bidSearchOrder, pagination)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := P.conn.Query(ctx, bidSelectQuery, tenderID, paginationOpts.Limit, paginationOpts.Offset)
+	rows, err := P.conn.Query(ctx, query, queryArgs...)
 	if err != nil {
 		return nil, err
 	}
 
-	var bids []models.Bid
+	defer rows.Close()
 
+	var results []models.BidSearchResult
+	var positions []postgres.RowPosition
 	for rows.Next() {
-		var bid models.Bid
+		var result models.BidSearchResult
+		var position postgres.RowPosition
 
-		err := rows.Scan(&bid.ID, &bid.TenderID, &bid.Status, &bid.AuthorType, &bid.AuthorID, &bid.Name, &bid.Description, &bid.Version, &bid.CreatedAt)
+		result.Bid, err = scanBidWith(rows, append(position.Dest(), &result.Rank, &result.Snippet)...)
 		if err != nil {
 			return nil, err
 		}
+		result.Snippet = postgres.HighlightHeadline(result.Snippet)
 
-		bids = append(bids, bid)
+		results = append(results, result)
+		positions = append(positions, position)
 	}
 
-	return bids, nil
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	results = postgres.NextPage(results, positions, bidSearchOrder, pagination)
+
+	err = postgres.CountTotal(ctx, P.conn, searchFromWhere, args, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
 }
 
 func (P *PGXRepository) SetStatus(ctx context.Context, id models.ID, status models.BidStatus) (models.Bid, error) {
 	const bidUpdateQuery = `
 		UPDATE bid
-		SET status = $1	
-		WHERE id = $2	
-		RETURNING id, tender_id, status, author_type, author_id, current_version_id
+		SET status = $1, revision = revision + 1
+		WHERE id = $2
 	`
 
-	row := P.conn.QueryRow(ctx, bidUpdateQuery, status, id)
-
-	var bid models.Bid
-
-	err := row.Scan(&bid.ID, &bid.TenderID, &bid.Status, &bid.AuthorType, &bid.AuthorID, &bid.Version)
+	tag, err := P.conn.Exec(ctx, bidUpdateQuery, status, id)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return models.Bid{}, fmt.Errorf("bid with ID %s not found: %w", id, domain.ErrNotFound)
-		}
 		return models.Bid{}, err
 	}
 
-	return bid, nil
+	if tag.RowsAffected() == 0 {
+		return models.Bid{}, fmt.Errorf("bid with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+
+	return P.GetByID(ctx, id)
 }
 
 func (P *PGXRepository) Update(ctx context.Context, id models.ID, data *models.Bid) (models.Bid, error) {
 	const bidUpdateQuery = `
 		UPDATE bid
-		SET current_version_id = $1
+		SET current_version_id = $1, revision = revision + 1
 		WHERE id = $2
+		RETURNING revision
 	`
 
 	const bidVersionInsertQuery = `
-		INSERT INTO bid_version (id, bid_id, version, name, description)
-		VALUES ($1, $2, $3, $4, $5)		
+		INSERT INTO bid_version (id, bid_id, version, name, description, price_amount, price_currency, created_by_type, created_by_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	const copyAttachmentsQuery = `
+		INSERT INTO bid_version_attachment (bid_version_id, attachment_id)
+		SELECT $1, bva.attachment_id
+		FROM bid b
+		JOIN bid_version_attachment bva ON bva.bid_version_id = b.current_version_id
+		WHERE b.id = $2
 	`
 
 	tx, err := P.conn.Begin(ctx)
@@ -278,19 +435,33 @@ func (P *PGXRepository) Update(ctx context.Context, id models.ID, data *models.B
 		Name:        data.Name,
 		Description: data.Description,
 	}
+	bidVersionEntity.PriceAmount, bidVersionEntity.PriceCurrency = postgres.MoneyToColumns(data.Price)
+	bidVersionEntity.CreatedByType, bidVersionEntity.CreatedByID = postgres.VersionAuthorToColumns(data.VersionAuthor)
 
-	_, err = tx.Exec(ctx, bidUpdateQuery, bidVersionEntity.ID, id)
+	_, err = tx.Exec(ctx, bidVersionInsertQuery, bidVersionEntity.ID, bidVersionEntity.BidID, bidVersionEntity.Version, bidVersionEntity.Name, bidVersionEntity.Description, bidVersionEntity.PriceAmount, bidVersionEntity.PriceCurrency,
+		bidVersionEntity.CreatedByType, bidVersionEntity.CreatedByID,
+	)
 	if err != nil {
 		_ = tx.Rollback(ctx)
-		if errors.Is(err, pgx.ErrNoRows) {
-			return models.Bid{}, fmt.Errorf("bid with ID %s not found: %w", id, domain.ErrNotFound)
+		if postgres.IsUniqueViolation(err, "uq_bid_version_number") {
+			return models.Bid{}, fmt.Errorf("version %d of bid %s already exists: %w", bidVersionEntity.Version, id, domain.ErrAlreadyExists)
 		}
 		return models.Bid{}, err
 	}
 
-	_, err = tx.Exec(ctx, bidVersionInsertQuery, bidVersionEntity.ID, bidVersionEntity.BidID, bidVersionEntity.Version, bidVersionEntity.Name, bidVersionEntity.Description)
+	// the new version inherits the attachments of the current one, so it is copied before switching
+	_, err = tx.Exec(ctx, copyAttachmentsQuery, bidVersionEntity.ID, id)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return models.Bid{}, err
+	}
+
+	err = tx.QueryRow(ctx, bidUpdateQuery, bidVersionEntity.ID, id).Scan(&data.Revision)
 	if err != nil {
 		_ = tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Bid{}, fmt.Errorf("bid with ID %s not found: %w", id, domain.ErrNotFound)
+		}
 		return models.Bid{}, err
 	}
 
@@ -302,37 +473,78 @@ func (P *PGXRepository) Update(ctx context.Context, id models.ID, data *models.B
 	return *data, nil
 }
 
-func (P *PGXRepository) Rollback(ctx context.Context, id models.ID, version int) (models.Bid, error) {
-	const bidVersionSelectQuery = `
-		SELECT id, bid_id, version, name, description, created_at
-		FROM bid_version
-		WHERE bid_id = $1 AND version = $2
+// Rollback copies the version into a new version, which becomes current and records the version it was
+// rolled back from. Attachments are linked to the version, so they are copied as well.
+func (P *PGXRepository) Rollback(ctx context.Context, id models.ID, version int, author *models.VersionAuthor) (models.Bid, error) {
+	// the lock serializes the version numbers of the bid
+	const bidLockQuery = `
+		SELECT id
+		FROM bid
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	const bidVersionInsertQuery = `
+		INSERT INTO bid_version (id, bid_id, version, name, description, price_amount, price_currency,
+		                         created_by_type, created_by_id, rolled_back_from)
+		SELECT $1, bv.bid_id, (SELECT MAX(version) + 1 FROM bid_version WHERE bid_id = $2),
+		       bv.name, bv.description, bv.price_amount, bv.price_currency,
+		       $4, $5, bv.version
+		FROM bid_version bv
+		WHERE bv.bid_id = $2 AND bv.version = $3
+	`
+
+	const copyAttachmentsQuery = `
+		INSERT INTO bid_version_attachment (bid_version_id, attachment_id)
+		SELECT $1, bva.attachment_id
+		FROM bid_version bv
+		JOIN bid_version_attachment bva ON bva.bid_version_id = bv.id
+		WHERE bv.bid_id = $2 AND bv.version = $3
 	`
 
 	const bidUpdateQuery = `
 		UPDATE bid
-		SET current_version_id = $1
+		SET current_version_id = $1, revision = revision + 1
 		WHERE id = $2
 	`
 
-	row := P.conn.QueryRow(ctx, bidVersionSelectQuery, id, version)
+	idUUID := uuid.UUID(id)
+	versionID := uuid.New()
+	createdByType, createdByID := postgres.VersionAuthorToColumns(author)
 
-	var bidVersion bidVersion
+	tx, err := P.conn.Begin(ctx)
+	if err != nil {
+		return models.Bid{}, err
+	}
 
-	err := row.Scan(&bidVersion.ID, &bidVersion.BidID, &bidVersion.Version, &bidVersion.Name, &bidVersion.Description, &bidVersion.CreatedAt)
+	var lockedID uuid.UUID
+	err = tx.QueryRow(ctx, bidLockQuery, idUUID).Scan(&lockedID)
 	if err != nil {
+		_ = tx.Rollback(ctx)
 		if errors.Is(err, pgx.ErrNoRows) {
-			return models.Bid{}, fmt.Errorf("bid version with ID %s and version %d not found: %w", id, version, domain.ErrNotFound)
+			return models.Bid{}, fmt.Errorf("bid with ID %s not found: %w", id, domain.ErrNotFound)
 		}
 		return models.Bid{}, err
 	}
 
-	tx, err := P.conn.Begin(ctx)
+	tag, err := tx.Exec(ctx, bidVersionInsertQuery, versionID, idUUID, version, createdByType, createdByID)
 	if err != nil {
+		_ = tx.Rollback(ctx)
 		return models.Bid{}, err
 	}
 
-	_, err = tx.Exec(ctx, bidUpdateQuery, bidVersion.ID, id)
+	if tag.RowsAffected() == 0 {
+		_ = tx.Rollback(ctx)
+		return models.Bid{}, fmt.Errorf("bid version with ID %s and version %d not found: %w", id, version, domain.ErrNotFound)
+	}
+
+	_, err = tx.Exec(ctx, copyAttachmentsQuery, versionID, idUUID, version)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return models.Bid{}, err
+	}
+
+	_, err = tx.Exec(ctx, bidUpdateQuery, versionID, idUUID)
 	if err != nil {
 		_ = tx.Rollback(ctx)
 		return models.Bid{}, err
@@ -343,13 +555,93 @@ func (P *PGXRepository) Rollback(ctx context.Context, id models.ID, version int)
 		return models.Bid{}, err
 	}
 
-	return models.Bid{
-		ID:          models.ID(bidVersion.BidID),
-		Name:        bidVersion.Name,
-		Description: bidVersion.Description,
-		Version:     bidVersion.Version,
-		CreatedAt:   bidVersion.CreatedAt,
-	}, nil
+	return P.GetByID(ctx, id)
+}
+
+// versionsOrder puts the latest versions first
+var versionsOrder = postgres.KeysetOrder{Name: "bid_versions", Key: "bv.version", Type: "INT", Desc: true, ID: "bv.id"}
+
+// bidVersionsFromClause joins any version of a bid to the bid, so the selected bid
+// columns hold the version instead of the current one
+const bidVersionsFromClause = `
+	FROM bid_version bv
+	JOIN bid b ON b.id = bv.bid_id
+`
+
+// GetVersions returns the versions of the bid, the latest first. CreatedAt is the creation time of the version.
+func (P *PGXRepository) GetVersions(ctx context.Context, id models.ID, options ...abstraction.PaginationOptFunc) ([]models.Bid, error) {
+	const fromWhere = bidVersionsFromClause + `
+		WHERE bv.bid_id = $1
+	`
+
+	paginationOptions, err := abstraction.NewPaginationOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []any{uuid.UUID(id)}
+	query, queryArgs, err := postgres.Paginate("SELECT "+bidSelectColumns+", bv.created_at, "+versionsOrder.PositionColumns()+fromWhere, args, versionsOrder, paginationOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := P.conn.Query(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var bids []models.Bid
+	var positions []postgres.RowPosition
+	for rows.Next() {
+		var versionCreatedAt time.Time
+		var position postgres.RowPosition
+
+		bid, err := scanBidWith(rows, append([]any{&versionCreatedAt}, position.Dest()...)...)
+		if err != nil {
+			return nil, err
+		}
+		bid.CreatedAt = versionCreatedAt
+
+		bids = append(bids, bid)
+		positions = append(positions, position)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	bids = postgres.NextPage(bids, positions, versionsOrder, paginationOptions)
+
+	err = postgres.CountTotal(ctx, P.conn, fromWhere, args, paginationOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return bids, nil
+}
+
+// GetSpecificVersion returns the version of the bid, CreatedAt is the creation time of the version
+func (P *PGXRepository) GetSpecificVersion(ctx context.Context, id models.ID, version int) (models.Bid, error) {
+	const query = `
+		SELECT ` + bidSelectColumns + `, bv.created_at
+		` + bidVersionsFromClause + `
+		WHERE bv.bid_id = $1 AND bv.version = $2
+	`
+
+	var versionCreatedAt time.Time
+
+	bid, err := scanBidWith(P.conn.QueryRow(ctx, query, uuid.UUID(id), version), &versionCreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Bid{}, fmt.Errorf("bid with ID %s and version %d not found: %w", id, version, domain.ErrNotFound)
+		}
+		return models.Bid{}, err
+	}
+	bid.CreatedAt = versionCreatedAt
+
+	return bid, nil
 }
 
 func (P *PGXRepository) GetLatestVersionNumber(ctx context.Context, id models.ID) (int, error) {
@@ -357,7 +649,7 @@ func (P *PGXRepository) GetLatestVersionNumber(ctx context.Context, id models.ID
 		SELECT version
 		FROM bid_version
 		WHERE bid_id = $1
-		ORDER BY created_at DESC
+		ORDER BY version DESC
 		LIMIT 1
 	`
 
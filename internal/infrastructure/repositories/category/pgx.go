@@ -0,0 +1,160 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+)
+
+var _ abstraction.CategoryRepository = &PGXRepository{}
+
+// PGXRepository is a repository for working with the procurement classifier using pgx driver
+type PGXRepository struct {
+	conn *postgres.DB
+}
+
+// NewPGXRepository creates a new instance of PGXRepository
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
+	return &PGXRepository{conn: conn}
+}
+
+const categorySelectQuery = `
+	SELECT c.code, c.parent_code, c.name, c.created_at
+	FROM category c
+`
+
+func scanCategory(row pgx.Row) (models.Category, error) {
+	var category models.Category
+
+	err := row.Scan(&category.Code, &category.ParentCode, &category.Name, &category.CreatedAt)
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	return category, nil
+}
+
+func scanCategories(rows pgx.Rows) ([]models.Category, error) {
+	defer rows.Close()
+
+	categories := make([]models.Category, 0)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+func (P *PGXRepository) GetByCode(ctx context.Context, code models.CategoryCode) (models.Category, error) {
+	const query = categorySelectQuery + `
+		WHERE c.code = $1
+	`
+
+	category, err := scanCategory(P.conn.QueryRow(ctx, query, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Category{}, fmt.Errorf("category %s not found: %w", code, domain.ErrNotFound)
+		}
+		return models.Category{}, err
+	}
+
+	return category, nil
+}
+
+func (P *PGXRepository) GetAll(ctx context.Context) ([]models.Category, error) {
+	const query = categorySelectQuery + `
+		ORDER BY c.code
+	`
+
+	rows, err := P.conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCategories(rows)
+}
+
+func (P *PGXRepository) GetChildren(ctx context.Context, parentCode *models.CategoryCode) ([]models.Category, error) {
+	const query = categorySelectQuery + `
+		WHERE c.parent_code IS NOT DISTINCT FROM $1
+		ORDER BY c.code
+	`
+
+	rows, err := P.conn.Query(ctx, query, parentCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanCategories(rows)
+}
+
+func (P *PGXRepository) GetPath(ctx context.Context, code models.CategoryCode) ([]models.Category, error) {
+	const query = `
+		WITH RECURSIVE path AS (
+			SELECT code, parent_code, name, created_at, 0 AS depth
+			FROM category
+			WHERE code = $1
+			UNION ALL
+			SELECT c.code, c.parent_code, c.name, c.created_at, p.depth + 1
+			FROM category c
+			JOIN path p ON c.code = p.parent_code
+		)
+		SELECT code, parent_code, name, created_at
+		FROM path
+		ORDER BY depth DESC
+	`
+
+	rows, err := P.conn.Query(ctx, query, code)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := scanCategories(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(path) == 0 {
+		return nil, fmt.Errorf("category %s not found: %w", code, domain.ErrNotFound)
+	}
+
+	return path, nil
+}
+
+func (P *PGXRepository) Upsert(ctx context.Context, categories []models.Category) error {
+	const query = `
+		INSERT INTO category (code, parent_code, name, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (code) DO UPDATE
+		SET parent_code = excluded.parent_code, name = excluded.name
+	`
+
+	tx, err := P.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the parent foreign key is deferred, so it is checked on commit
+	batch := &pgx.Batch{}
+	for _, category := range categories {
+		batch.Queue(query, category.Code, category.ParentCode, category.Name, category.CreatedAt)
+	}
+
+	err = tx.SendBatch(ctx, batch).Close()
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,104 @@
+package credential
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"time"
+)
+
+var _ abstraction.CredentialRepository = &PGXRepository{}
+
+// PGXRepository is a repository for working with employee credentials using pgx driver
+type PGXRepository struct {
+	conn *postgres.DB
+}
+
+// NewPGXRepository creates a new instance of PGXRepository
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
+	return &PGXRepository{conn: conn}
+}
+
+func (P *PGXRepository) GetByEmployeeID(ctx context.Context, employeeID models.ID) (models.EmployeeCredentials, error) {
+	const query = `
+		SELECT id, password_hash, failed_login_attempts, locked_until
+		FROM employee
+		WHERE id = $1
+	`
+
+	row := P.conn.QueryRow(ctx, query, employeeID)
+
+	var credentials models.EmployeeCredentials
+	var passwordHash *string
+
+	err := row.Scan(&credentials.EmployeeID, &passwordHash, &credentials.FailedLoginAttempts, &credentials.LockedUntil)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.EmployeeCredentials{}, fmt.Errorf("employee not found: %w", domain.ErrNotFound)
+		}
+		return models.EmployeeCredentials{}, fmt.Errorf("error getting credentials of employee %s: %w", employeeID, err)
+	}
+
+	if passwordHash != nil {
+		credentials.PasswordHash = *passwordHash
+	}
+
+	return credentials, nil
+}
+
+func (P *PGXRepository) SetPasswordHash(ctx context.Context, employeeID models.ID, passwordHash string) error {
+	const query = `
+		UPDATE employee
+		SET password_hash = $1, failed_login_attempts = 0, locked_until = NULL, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	tag, err := P.conn.Exec(ctx, query, passwordHash, employeeID)
+	if err != nil {
+		return fmt.Errorf("error setting password of employee %s: %w", employeeID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("employee not found: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// RegisterFailedAttempt increments the failed login counter and locks the
+// employee until lockedUntil once maxAttempts is reached
+func (P *PGXRepository) RegisterFailedAttempt(ctx context.Context, employeeID models.ID, maxAttempts int, lockedUntil time.Time) error {
+	const query = `
+		UPDATE employee
+		SET failed_login_attempts = CASE WHEN failed_login_attempts + 1 >= $2 THEN 0 ELSE failed_login_attempts + 1 END,
+		    locked_until          = CASE WHEN failed_login_attempts + 1 >= $2 THEN $3 ELSE locked_until END
+		WHERE id = $1
+	`
+
+	_, err := P.conn.Exec(ctx, query, employeeID, maxAttempts, lockedUntil)
+	if err != nil {
+		return fmt.Errorf("error registering failed login of employee %s: %w", employeeID, err)
+	}
+
+	return nil
+}
+
+func (P *PGXRepository) ResetFailedAttempts(ctx context.Context, employeeID models.ID) error {
+	const query = `
+		UPDATE employee
+		SET failed_login_attempts = 0, locked_until = NULL
+		WHERE id = $1
+	`
+
+	_, err := P.conn.Exec(ctx, query, employeeID)
+	if err != nil {
+		return fmt.Errorf("error resetting failed logins of employee %s: %w", employeeID, err)
+	}
+
+	return nil
+}
@@ -8,25 +8,96 @@ import (
 	"tenderSystem/internal/abstraction"
 	"tenderSystem/internal/domain"
 	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"time"
 )
 
 var _ abstraction.EmployeeRepository = &PGXRepository{}
 
 // PGXRepository is a repository for working with employees using pgx driver
 type PGXRepository struct {
-	conn *pgx.Conn
+	conn *postgres.DB
 }
 
 // NewPGXRepository creates a new instance of PGXRepository
-func NewPGXRepository(conn *pgx.Conn) *PGXRepository {
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
 	return &PGXRepository{conn: conn}
 }
 
+// Create inserts the employee and makes them responsible for the organization with the given role
+func (P *PGXRepository) Create(ctx context.Context, data *models.Employee, passwordHash string, organizationID models.ID, role models.OrganizationRole) (models.Employee, error) {
+	const employeeQuery = `
+		INSERT INTO employee (id, username, first_name, last_name, password_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (username) DO NOTHING
+	`
+
+	const responsibleQuery = `
+		INSERT INTO organization_responsible (id, organization_id, user_id, role)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	var passwordHashValue *string
+	if passwordHash != "" {
+		passwordHashValue = &passwordHash
+	}
+
+	transaction, err := P.conn.Begin(ctx)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	tag, err := transaction.Exec(ctx, employeeQuery, data.ID, data.Username, data.FirstName, data.LastName, passwordHashValue, data.CreatedAt, data.UpdatedAt)
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		return models.Employee{}, fmt.Errorf("error creating employee: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		_ = transaction.Rollback(ctx)
+		return models.Employee{}, fmt.Errorf("employee with username %s already exists: %w", data.Username, domain.ErrAlreadyExists)
+	}
+
+	_, err = transaction.Exec(ctx, responsibleQuery, models.NewID(), organizationID, data.ID, role.String())
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		return models.Employee{}, fmt.Errorf("error adding employee to organization %s: %w", organizationID, err)
+	}
+
+	err = transaction.Commit(ctx)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	return *data, nil
+}
+
+func (P *PGXRepository) GetByID(ctx context.Context, id models.ID) (models.Employee, error) {
+	const query = `
+		SELECT id, username, first_name, last_name, created_at, updated_at, deactivated_at
+		FROM employee
+		WHERE id = $1
+	`
+
+	row := P.conn.QueryRow(ctx, query, id)
+
+	var employee models.Employee
+	err := row.Scan(&employee.ID, &employee.Username, &employee.FirstName, &employee.LastName, &employee.CreatedAt, &employee.UpdatedAt, &employee.DeactivatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Employee{}, fmt.Errorf("employee not found: %w", domain.ErrNotFound)
+		}
+		return models.Employee{}, fmt.Errorf("error getting employee %s by ID: %w", id, err)
+	}
+
+	return employee, nil
+}
+
 func (P *PGXRepository) GetByUsername(ctx context.Context, username string) (models.Employee, error) {
 	const query = `
 		SELECT id, username, first_name, last_name, created_at, updated_at
 		FROM employee
-		WHERE username = $1
+		WHERE username = $1 AND deactivated_at IS NULL
 	`
 
 	row := P.conn.QueryRow(ctx, query, username)
@@ -43,39 +114,120 @@ func (P *PGXRepository) GetByUsername(ctx context.Context, username string) (mod
 	return employee, nil
 }
 
-func (P *PGXRepository) GetOrganization(ctx context.Context, userID models.ID) (models.Organization, error) {
+func (P *PGXRepository) Update(ctx context.Context, id models.ID, data *models.Employee) (models.Employee, error) {
 	const query = `
-		SELECT o.id, o.name, o.description, o.type, o.created_at, o.updated_at
-		FROM organization_responsible o_r
-		JOIN organization o ON o_r.organization_id = o.id
-		WHERE o_r.user_id = $1
+		UPDATE employee
+		SET first_name = $2, last_name = $3, updated_at = $4
+		WHERE id = $1 AND deactivated_at IS NULL
 	`
 
-	row := P.conn.QueryRow(ctx, query, userID)
+	tag, err := P.conn.Exec(ctx, query, id, data.FirstName, data.LastName, data.UpdatedAt)
+	if err != nil {
+		return models.Employee{}, fmt.Errorf("error updating employee %s: %w", id, err)
+	}
 
-	var organization models.Organization
+	if tag.RowsAffected() == 0 {
+		return models.Employee{}, fmt.Errorf("employee not found: %w", domain.ErrNotFound)
+	}
+
+	return *data, nil
+}
+
+func (P *PGXRepository) Deactivate(ctx context.Context, id models.ID, deactivatedAt time.Time) error {
+	const query = `
+		UPDATE employee
+		SET deactivated_at = $2, updated_at = $2
+		WHERE id = $1 AND deactivated_at IS NULL
+	`
+
+	tag, err := P.conn.Exec(ctx, query, id, deactivatedAt)
+	if err != nil {
+		return fmt.Errorf("error deactivating employee %s: %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("employee not found: %w", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+const membershipQuery = `
+	SELECT o.id, o.name, o.description, o.type, o.created_at, o.updated_at,
+	       e.id, e.username, e.first_name, e.last_name, e.created_at, e.updated_at,
+	       o_r.role
+	FROM organization_responsible o_r
+	JOIN organization o ON o_r.organization_id = o.id
+	JOIN employee e ON o_r.user_id = e.id AND e.deactivated_at IS NULL
+`
+
+func scanMembership(row pgx.Row) (models.OrganizationMember, error) {
+	var member models.OrganizationMember
 	var description *string
 
-	err := row.Scan(&organization.ID, &organization.Name, &description, &organization.Type, &organization.CreatedAt, &organization.UpdatedAt)
+	err := row.Scan(
+		&member.Organization.ID, &member.Organization.Name, &description, &member.Organization.Type, &member.Organization.CreatedAt, &member.Organization.UpdatedAt,
+		&member.Employee.ID, &member.Employee.Username, &member.Employee.FirstName, &member.Employee.LastName, &member.Employee.CreatedAt, &member.Employee.UpdatedAt,
+		&member.Role,
+	)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return models.Organization{}, fmt.Errorf("organization not found: %w", domain.ErrNotFound)
-		}
-		return models.Organization{}, fmt.Errorf("error getting organization by user ID: %w", err)
+		return models.OrganizationMember{}, err
 	}
 
 	if description != nil {
-		organization.Description = *description
+		member.Organization.Description = *description
+	}
+
+	return member, nil
+}
+
+// GetMemberships returns every organization the employee is responsible for
+func (P *PGXRepository) GetMemberships(ctx context.Context, userID models.ID) ([]models.OrganizationMember, error) {
+	const query = membershipQuery + `
+		WHERE o_r.user_id = $1
+		ORDER BY o.name
+	`
+
+	rows, err := P.conn.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting memberships by user ID: %w", err)
+	}
+	defer rows.Close()
+
+	var members []models.OrganizationMember
+	for rows.Next() {
+		member, err := scanMembership(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning membership: %w", err)
+		}
+
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+func (P *PGXRepository) GetMembership(ctx context.Context, userID, organizationID models.ID) (models.OrganizationMember, error) {
+	const query = membershipQuery + `
+		WHERE o_r.user_id = $1 AND o_r.organization_id = $2
+	`
+
+	member, err := scanMembership(P.conn.QueryRow(ctx, query, userID, organizationID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.OrganizationMember{}, fmt.Errorf("employee is not responsible for organization %s: %w", organizationID, domain.ErrNotFound)
+		}
+		return models.OrganizationMember{}, fmt.Errorf("error getting membership: %w", err)
 	}
 
-	return organization, nil
+	return member, nil
 }
 
 func (P *PGXRepository) GetByOrganizationID(ctx context.Context, organizationID models.ID) ([]models.Employee, error) {
 	const query = `
 		SELECT e.id, e.username, e.first_name, e.last_name, e.created_at, e.updated_at
 		FROM organization_responsible o_r
-		JOIN employee e ON o_r.user_id = e.id
+		JOIN employee e ON o_r.user_id = e.id AND e.deactivated_at IS NULL
 		WHERE o_r.organization_id = $1
 	`
 
@@ -0,0 +1,141 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"time"
+)
+
+var _ abstraction.IdempotencyRepository = &PGXRepository{}
+
+type idempotencyKey struct {
+	Scope       string
+	Key         string
+	Fingerprint string
+	StatusCode  *int
+	Header      map[string][]string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// PGXRepository is a repository for working with idempotency keys using pgx driver
+type PGXRepository struct {
+	conn *postgres.DB
+}
+
+// NewPGXRepository creates a new instance of PGXRepository
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
+	return &PGXRepository{conn: conn}
+}
+
+func (P *PGXRepository) Reserve(ctx context.Context, data *models.IdempotentRequest) (models.IdempotentRequest, bool, error) {
+	// an expired key is taken over by the new request
+	const insertQuery = `
+		INSERT INTO idempotency_key (scope, key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (scope, key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at,
+		    status_code = NULL, header = NULL, body = NULL
+		WHERE idempotency_key.expires_at <= EXCLUDED.created_at
+	`
+
+	const selectQuery = `
+		SELECT scope, key, fingerprint, status_code, header, body, created_at, expires_at
+		FROM idempotency_key
+		WHERE scope = $1 AND key = $2
+	`
+
+	tag, err := P.conn.Exec(ctx, insertQuery, data.Scope, data.Key, data.Fingerprint, data.CreatedAt, data.ExpiresAt)
+	if err != nil {
+		return models.IdempotentRequest{}, false, fmt.Errorf("error reserving idempotency key: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		return *data, true, nil
+	}
+
+	var entity idempotencyKey
+
+	err = P.conn.QueryRow(ctx, selectQuery, data.Scope, data.Key).Scan(
+		&entity.Scope, &entity.Key, &entity.Fingerprint, &entity.StatusCode, &entity.Header, &entity.Body, &entity.CreatedAt, &entity.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the first request failed and released the key in between
+			return models.IdempotentRequest{}, false, fmt.Errorf("idempotency key %s was released, retry the request: %w", data.Key, domain.ErrAlreadyExists)
+		}
+		return models.IdempotentRequest{}, false, fmt.Errorf("error getting idempotency key: %w", err)
+	}
+
+	request := models.IdempotentRequest{
+		Scope:       entity.Scope,
+		Key:         entity.Key,
+		Fingerprint: entity.Fingerprint,
+		CreatedAt:   entity.CreatedAt,
+		ExpiresAt:   entity.ExpiresAt,
+	}
+
+	if entity.StatusCode != nil {
+		request.Response = &models.StoredResponse{
+			StatusCode: *entity.StatusCode,
+			Header:     entity.Header,
+			Body:       entity.Body,
+		}
+	}
+
+	return request, false, nil
+}
+
+func (P *PGXRepository) SetResponse(ctx context.Context, scope, key string, response models.StoredResponse) error {
+	const query = `
+		UPDATE idempotency_key
+		SET status_code = $3, header = $4, body = $5
+		WHERE scope = $1 AND key = $2
+	`
+
+	tag, err := P.conn.Exec(ctx, query, scope, key, response.StatusCode, response.Header, response.Body)
+	if err != nil {
+		return fmt.Errorf("error storing idempotent response: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("idempotency key %s not found: %w", key, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (P *PGXRepository) Delete(ctx context.Context, scope, key string) error {
+	const query = `
+		DELETE FROM idempotency_key
+		WHERE scope = $1 AND key = $2
+	`
+
+	_, err := P.conn.Exec(ctx, query, scope, key)
+	if err != nil {
+		return fmt.Errorf("error deleting idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (P *PGXRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	const query = `
+		DELETE FROM idempotency_key
+		WHERE expires_at <= $1
+	`
+
+	tag, err := P.conn.Exec(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting expired idempotency keys: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,170 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"time"
+)
+
+var _ abstraction.APIKeyRepository = &PGXRepository{}
+
+type apiKey struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Prefix         string
+	KeyHash        string
+	Scopes         []string
+	ExpiresAt      *time.Time
+	LastUsedAt     *time.Time
+	CreatedBy      *uuid.UUID
+	CreatedAt      time.Time
+	RevokedAt      *time.Time
+}
+
+func (a apiKey) toModel() models.APIKey {
+	scopes := make([]models.APIKeyScope, 0, len(a.Scopes))
+	for _, scope := range a.Scopes {
+		scopes = append(scopes, models.APIKeyScope(scope))
+	}
+
+	var createdBy models.ID
+	if a.CreatedBy != nil {
+		createdBy = models.ID(*a.CreatedBy)
+	}
+
+	return models.APIKey{
+		ID:             models.ID(a.ID),
+		OrganizationID: models.ID(a.OrganizationID),
+		Name:           a.Name,
+		Prefix:         a.Prefix,
+		KeyHash:        a.KeyHash,
+		Scopes:         scopes,
+		ExpiresAt:      a.ExpiresAt,
+		LastUsedAt:     a.LastUsedAt,
+		CreatedBy:      createdBy,
+		CreatedAt:      a.CreatedAt,
+		RevokedAt:      a.RevokedAt,
+	}
+}
+
+// PGXRepository is a repository for working with organization API keys using pgx driver
+type PGXRepository struct {
+	conn *postgres.DB
+}
+
+// NewPGXRepository creates a new instance of PGXRepository
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
+	return &PGXRepository{conn: conn}
+}
+
+func (P *PGXRepository) Create(ctx context.Context, data *models.APIKey) (models.APIKey, error) {
+	const query = `
+		INSERT INTO organization_api_key (id, organization_id, name, prefix, key_hash, scopes, expires_at, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	scopes := make([]string, 0, len(data.Scopes))
+	for _, scope := range data.Scopes {
+		scopes = append(scopes, scope.String())
+	}
+
+	_, err := P.conn.Exec(ctx, query, data.ID, data.OrganizationID, data.Name, data.Prefix, data.KeyHash, scopes, data.ExpiresAt, data.CreatedBy, data.CreatedAt)
+	if err != nil {
+		return models.APIKey{}, fmt.Errorf("error creating API key: %w", err)
+	}
+
+	return *data, nil
+}
+
+func (P *PGXRepository) GetByHash(ctx context.Context, keyHash string) (models.APIKey, error) {
+	const query = `
+		SELECT id, organization_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_by, created_at, revoked_at
+		FROM organization_api_key
+		WHERE key_hash = $1
+	`
+
+	row := P.conn.QueryRow(ctx, query, keyHash)
+
+	var entity apiKey
+
+	err := row.Scan(&entity.ID, &entity.OrganizationID, &entity.Name, &entity.Prefix, &entity.KeyHash, &entity.Scopes, &entity.ExpiresAt, &entity.LastUsedAt, &entity.CreatedBy, &entity.CreatedAt, &entity.RevokedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.APIKey{}, fmt.Errorf("API key not found: %w", domain.ErrNotFound)
+		}
+		return models.APIKey{}, fmt.Errorf("error getting API key: %w", err)
+	}
+
+	return entity.toModel(), nil
+}
+
+func (P *PGXRepository) GetByOrganizationID(ctx context.Context, organizationID models.ID) ([]models.APIKey, error) {
+	const query = `
+		SELECT id, organization_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_by, created_at, revoked_at
+		FROM organization_api_key
+		WHERE organization_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := P.conn.Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting API keys of organization %s: %w", organizationID, err)
+	}
+	defer rows.Close()
+
+	var keys []models.APIKey
+	for rows.Next() {
+		var entity apiKey
+
+		err := rows.Scan(&entity.ID, &entity.OrganizationID, &entity.Name, &entity.Prefix, &entity.KeyHash, &entity.Scopes, &entity.ExpiresAt, &entity.LastUsedAt, &entity.CreatedBy, &entity.CreatedAt, &entity.RevokedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning API key: %w", err)
+		}
+
+		keys = append(keys, entity.toModel())
+	}
+
+	return keys, nil
+}
+
+func (P *PGXRepository) Revoke(ctx context.Context, organizationID, id models.ID) error {
+	const query = `
+		UPDATE organization_api_key
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1 AND organization_id = $2
+	`
+
+	tag, err := P.conn.Exec(ctx, query, id, organizationID)
+	if err != nil {
+		return fmt.Errorf("error revoking API key %s: %w", id, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("API key with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (P *PGXRepository) TouchLastUsed(ctx context.Context, id models.ID, usedAt time.Time) error {
+	const query = `
+		UPDATE organization_api_key
+		SET last_used_at = $2
+		WHERE id = $1
+	`
+
+	_, err := P.conn.Exec(ctx, query, id, usedAt)
+	if err != nil {
+		return fmt.Errorf("error updating last use of API key %s: %w", id, err)
+	}
+
+	return nil
+}
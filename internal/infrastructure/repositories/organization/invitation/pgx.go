@@ -0,0 +1,154 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"time"
+)
+
+var _ abstraction.InvitationRepository = &PGXRepository{}
+
+type invitation struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Role           string
+	TokenHash      string
+	CreatedBy      *uuid.UUID
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	RedeemedAt     *time.Time
+	RedeemedBy     *uuid.UUID
+}
+
+func (i invitation) toModel() models.Invitation {
+	var createdBy models.ID
+	if i.CreatedBy != nil {
+		createdBy = models.ID(*i.CreatedBy)
+	}
+
+	var redeemedBy *models.ID
+	if i.RedeemedBy != nil {
+		id := models.ID(*i.RedeemedBy)
+		redeemedBy = &id
+	}
+
+	return models.Invitation{
+		ID:             models.ID(i.ID),
+		OrganizationID: models.ID(i.OrganizationID),
+		Role:           models.OrganizationRole(i.Role),
+		TokenHash:      i.TokenHash,
+		CreatedBy:      createdBy,
+		CreatedAt:      i.CreatedAt,
+		ExpiresAt:      i.ExpiresAt,
+		RedeemedAt:     i.RedeemedAt,
+		RedeemedBy:     redeemedBy,
+	}
+}
+
+// PGXRepository is a repository for working with organization invitations using pgx driver
+type PGXRepository struct {
+	conn *postgres.DB
+}
+
+// NewPGXRepository creates a new instance of PGXRepository
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
+	return &PGXRepository{conn: conn}
+}
+
+func (P *PGXRepository) Create(ctx context.Context, data *models.Invitation) (models.Invitation, error) {
+	const query = `
+		INSERT INTO organization_invitation (id, organization_id, role, token_hash, created_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := P.conn.Exec(ctx, query, data.ID, data.OrganizationID, data.Role.String(), data.TokenHash, data.CreatedBy, data.CreatedAt, data.ExpiresAt)
+	if err != nil {
+		return models.Invitation{}, fmt.Errorf("error creating invitation: %w", err)
+	}
+
+	return *data, nil
+}
+
+func (P *PGXRepository) GetByHash(ctx context.Context, tokenHash string) (models.Invitation, error) {
+	const query = `
+		SELECT id, organization_id, role, token_hash, created_by, created_at, expires_at, redeemed_at, redeemed_by
+		FROM organization_invitation
+		WHERE token_hash = $1
+	`
+
+	row := P.conn.QueryRow(ctx, query, tokenHash)
+
+	var entity invitation
+	err := row.Scan(&entity.ID, &entity.OrganizationID, &entity.Role, &entity.TokenHash, &entity.CreatedBy, &entity.CreatedAt, &entity.ExpiresAt, &entity.RedeemedAt, &entity.RedeemedBy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Invitation{}, fmt.Errorf("invitation not found: %w", domain.ErrNotFound)
+		}
+		return models.Invitation{}, fmt.Errorf("error getting invitation: %w", err)
+	}
+
+	return entity.toModel(), nil
+}
+
+// Redeem marks the invitation as used and creates the invited employee in one transaction,
+// so a token can never produce two accounts
+func (P *PGXRepository) Redeem(ctx context.Context, data *models.Invitation, employee *models.Employee, passwordHash string) error {
+	const invitationQuery = `
+		UPDATE organization_invitation
+		SET redeemed_at = $2, redeemed_by = $3
+		WHERE id = $1 AND redeemed_at IS NULL AND expires_at > $2
+	`
+
+	const employeeQuery = `
+		INSERT INTO employee (id, username, first_name, last_name, password_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (username) DO NOTHING
+	`
+
+	const responsibleQuery = `
+		INSERT INTO organization_responsible (id, organization_id, user_id, role)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	transaction, err := P.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag, err := transaction.Exec(ctx, employeeQuery, employee.ID, employee.Username, employee.FirstName, employee.LastName, passwordHash, employee.CreatedAt, employee.UpdatedAt)
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		return fmt.Errorf("error creating employee: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		_ = transaction.Rollback(ctx)
+		return fmt.Errorf("employee with username %s already exists: %w", employee.Username, domain.ErrAlreadyExists)
+	}
+
+	tag, err = transaction.Exec(ctx, invitationQuery, data.ID, employee.CreatedAt, employee.ID)
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		return fmt.Errorf("error redeeming invitation: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		_ = transaction.Rollback(ctx)
+		return fmt.Errorf("invitation is already used or expired: %w", domain.ErrInvalidArgument)
+	}
+
+	_, err = transaction.Exec(ctx, responsibleQuery, models.NewID(), data.OrganizationID, employee.ID, data.Role.String())
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		return fmt.Errorf("error adding employee to organization %s: %w", data.OrganizationID, err)
+	}
+
+	return transaction.Commit(ctx)
+}
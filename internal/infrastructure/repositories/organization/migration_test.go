@@ -0,0 +1,35 @@
+package organization
+
+import (
+	"context"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres/postgrestest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestOrganizationTypeMigration(t *testing.T) {
+	const migration = "00005_organization_type_ip.sql"
+
+	db := postgrestest.NewBefore(t, migration)
+	ctx := context.Background()
+
+	// the organization was seeded by hand with the old value of the enum
+	id := uuid.New()
+	_, err := db.Exec(ctx, `INSERT INTO organization (id, name, type) VALUES ($1, 'ИП Иванов', 'IE')`, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	postgrestest.Migrate(t, db, migration)
+
+	organization, err := NewPGXRepository(db).GetByID(ctx, models.ID(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if organization.Type != models.OrganizationTypeIndividualEntrepreneur {
+		t.Fatalf("organization type is %s, want %s", organization.Type, models.OrganizationTypeIndividualEntrepreneur)
+	}
+}
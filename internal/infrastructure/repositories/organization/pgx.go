@@ -0,0 +1,303 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+)
+
+var _ abstraction.OrganizationRepository = &PGXRepository{}
+
+// PGXRepository is a repository for working with organizations using pgx driver
+type PGXRepository struct {
+	conn *postgres.DB
+}
+
+// NewPGXRepository creates a new instance of PGXRepository
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
+	return &PGXRepository{conn: conn}
+}
+
+func (P *PGXRepository) Create(ctx context.Context, data *models.Organization, ownerID models.ID) (models.Organization, error) {
+	const organizationQuery = `
+		INSERT INTO organization (id, name, description, type, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	const ownerQuery = `
+		INSERT INTO organization_responsible (id, organization_id, user_id, role)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	transaction, err := P.conn.Begin(ctx)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	_, err = transaction.Exec(ctx, organizationQuery, data.ID, data.Name, nullableString(data.Description), data.Type.String(), data.CreatedAt, data.UpdatedAt)
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		return models.Organization{}, fmt.Errorf("error creating organization: %w", err)
+	}
+
+	_, err = transaction.Exec(ctx, ownerQuery, models.NewID(), data.ID, ownerID, models.OrganizationRoleOwner.String())
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		return models.Organization{}, fmt.Errorf("error adding owner of organization: %w", err)
+	}
+
+	err = transaction.Commit(ctx)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	return *data, nil
+}
+
+var organizationsOrder = postgres.KeysetOrder{Name: "organizations", Key: "name", Type: "TEXT", ID: "id"}
+
+func (P *PGXRepository) GetAll(ctx context.Context, options ...abstraction.PaginationOptFunc) ([]models.Organization, error) {
+	const fromWhere = `
+		FROM organization
+		WHERE TRUE
+	`
+
+	paginationOptions, err := abstraction.NewPaginationOptions(options...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating pagination options: %w", err)
+	}
+
+	query, args, err := postgres.Paginate("SELECT id, name, description, type, created_at, updated_at, "+organizationsOrder.PositionColumns()+fromWhere, nil, organizationsOrder, paginationOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := P.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var organizations []models.Organization
+	var positions []postgres.RowPosition
+	for rows.Next() {
+		var position postgres.RowPosition
+
+		organization, err := scanOrganization(rows, position.Dest()...)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning organization: %w", err)
+		}
+
+		organizations = append(organizations, organization)
+		positions = append(positions, position)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error getting organizations: %w", err)
+	}
+
+	organizations = postgres.NextPage(organizations, positions, organizationsOrder, paginationOptions)
+
+	err = postgres.CountTotal(ctx, P.conn, fromWhere, nil, paginationOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+func (P *PGXRepository) GetByID(ctx context.Context, id models.ID) (models.Organization, error) {
+	const query = `
+		SELECT id, name, description, type, created_at, updated_at
+		FROM organization
+		WHERE id = $1
+	`
+
+	organization, err := scanOrganization(P.conn.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Organization{}, fmt.Errorf("organization with ID %s not found: %w", id, domain.ErrNotFound)
+		}
+		return models.Organization{}, fmt.Errorf("error getting organization by ID: %w", err)
+	}
+
+	return organization, nil
+}
+
+func (P *PGXRepository) Update(ctx context.Context, id models.ID, data *models.Organization) (models.Organization, error) {
+	const query = `
+		UPDATE organization
+		SET name = $2, description = $3, type = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	tag, err := P.conn.Exec(ctx, query, id, data.Name, nullableString(data.Description), data.Type.String(), data.UpdatedAt)
+	if err != nil {
+		return models.Organization{}, fmt.Errorf("error updating organization: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.Organization{}, fmt.Errorf("organization with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+
+	return *data, nil
+}
+
+// scanOrganization scans the organization columns followed by the extra columns
+func scanOrganization(row pgx.Row, extra ...any) (models.Organization, error) {
+	var organization models.Organization
+	var description *string
+
+	dest := []any{&organization.ID, &organization.Name, &description, &organization.Type, &organization.CreatedAt, &organization.UpdatedAt}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	if description != nil {
+		organization.Description = *description
+	}
+
+	return organization, nil
+}
+
+// nullableString stores empty descriptions as NULL, as the seed data does
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+const memberQuery = `
+	SELECT o.id, o.name, o.description, o.type, o.created_at, o.updated_at,
+	       e.id, e.username, e.first_name, e.last_name, e.created_at, e.updated_at,
+	       o_r.role
+	FROM organization_responsible o_r
+	JOIN organization o ON o_r.organization_id = o.id
+	JOIN employee e ON o_r.user_id = e.id AND e.deactivated_at IS NULL
+`
+
+func scanMember(row pgx.Row) (models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	var description *string
+
+	err := row.Scan(
+		&member.Organization.ID, &member.Organization.Name, &description, &member.Organization.Type, &member.Organization.CreatedAt, &member.Organization.UpdatedAt,
+		&member.Employee.ID, &member.Employee.Username, &member.Employee.FirstName, &member.Employee.LastName, &member.Employee.CreatedAt, &member.Employee.UpdatedAt,
+		&member.Role,
+	)
+	if err != nil {
+		return models.OrganizationMember{}, err
+	}
+
+	if description != nil {
+		member.Organization.Description = *description
+	}
+
+	return member, nil
+}
+
+func (P *PGXRepository) GetMembers(ctx context.Context, organizationID models.ID) ([]models.OrganizationMember, error) {
+	const query = memberQuery + `
+		WHERE o_r.organization_id = $1
+		ORDER BY e.username
+	`
+
+	rows, err := P.conn.Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting members of organization %s: %w", organizationID, err)
+	}
+	defer rows.Close()
+
+	var members []models.OrganizationMember
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning member: %w", err)
+		}
+
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+func (P *PGXRepository) GetMember(ctx context.Context, organizationID, employeeID models.ID) (models.OrganizationMember, error) {
+	const query = memberQuery + `
+		WHERE o_r.organization_id = $1 AND o_r.user_id = $2
+	`
+
+	member, err := scanMember(P.conn.QueryRow(ctx, query, organizationID, employeeID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.OrganizationMember{}, fmt.Errorf("employee %s is not responsible for organization %s: %w", employeeID, organizationID, domain.ErrNotFound)
+		}
+		return models.OrganizationMember{}, fmt.Errorf("error getting member: %w", err)
+	}
+
+	return member, nil
+}
+
+func (P *PGXRepository) SetMemberRole(ctx context.Context, organizationID, employeeID models.ID, role models.OrganizationRole) error {
+	const query = `
+		UPDATE organization_responsible
+		SET role = $3
+		WHERE organization_id = $1 AND user_id = $2
+	`
+
+	tag, err := P.conn.Exec(ctx, query, organizationID, employeeID, role.String())
+	if err != nil {
+		return fmt.Errorf("error setting role of employee %s: %w", employeeID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("employee %s is not responsible for organization %s: %w", employeeID, organizationID, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (P *PGXRepository) AddMember(ctx context.Context, organizationID, employeeID models.ID, role models.OrganizationRole) error {
+	const query = `
+		INSERT INTO organization_responsible (id, organization_id, user_id, role)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (organization_id, user_id) DO NOTHING
+	`
+
+	tag, err := P.conn.Exec(ctx, query, models.NewID(), organizationID, employeeID, role.String())
+	if err != nil {
+		return fmt.Errorf("error adding employee %s to organization %s: %w", employeeID, organizationID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("employee %s is already responsible for organization %s: %w", employeeID, organizationID, domain.ErrAlreadyExists)
+	}
+
+	return nil
+}
+
+func (P *PGXRepository) RemoveMember(ctx context.Context, organizationID, employeeID models.ID) error {
+	const query = `
+		DELETE FROM organization_responsible
+		WHERE organization_id = $1 AND user_id = $2
+	`
+
+	tag, err := P.conn.Exec(ctx, query, organizationID, employeeID)
+	if err != nil {
+		return fmt.Errorf("error removing employee %s from organization %s: %w", employeeID, organizationID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("employee %s is not responsible for organization %s: %w", employeeID, organizationID, domain.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package refreshtoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"time"
+)
+
+var _ abstraction.RefreshTokenRepository = &PGXRepository{}
+
+type refreshToken struct {
+	ID         uuid.UUID
+	EmployeeID uuid.UUID
+	FamilyID   uuid.UUID
+	TokenHash  string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// PGXRepository is a repository for working with refresh tokens using pgx driver
+type PGXRepository struct {
+	conn *postgres.DB
+}
+
+// NewPGXRepository creates a new instance of PGXRepository
+func NewPGXRepository(conn *postgres.DB) *PGXRepository {
+	return &PGXRepository{conn: conn}
+}
+
+func (P *PGXRepository) Create(ctx context.Context, data *models.RefreshToken) (models.RefreshToken, error) {
+	const query = `
+		INSERT INTO refresh_token (id, employee_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := P.conn.Exec(ctx, query, data.ID, data.EmployeeID, data.FamilyID, data.TokenHash, data.ExpiresAt, data.CreatedAt)
+	if err != nil {
+		return models.RefreshToken{}, fmt.Errorf("error creating refresh token: %w", err)
+	}
+
+	return *data, nil
+}
+
+func (P *PGXRepository) GetByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	const query = `
+		SELECT id, employee_id, family_id, token_hash, expires_at, created_at, revoked_at
+		FROM refresh_token
+		WHERE token_hash = $1
+	`
+
+	row := P.conn.QueryRow(ctx, query, tokenHash)
+
+	var entity refreshToken
+
+	err := row.Scan(&entity.ID, &entity.EmployeeID, &entity.FamilyID, &entity.TokenHash, &entity.ExpiresAt, &entity.CreatedAt, &entity.RevokedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.RefreshToken{}, fmt.Errorf("refresh token not found: %w", domain.ErrNotFound)
+		}
+		return models.RefreshToken{}, fmt.Errorf("error getting refresh token: %w", err)
+	}
+
+	return models.RefreshToken{
+		ID:         models.ID(entity.ID),
+		EmployeeID: models.ID(entity.EmployeeID),
+		FamilyID:   models.ID(entity.FamilyID),
+		TokenHash:  entity.TokenHash,
+		ExpiresAt:  entity.ExpiresAt,
+		CreatedAt:  entity.CreatedAt,
+		RevokedAt:  entity.RevokedAt,
+	}, nil
+}
+
+// Revoke marks the token as revoked and reports whether it was still active
+func (P *PGXRepository) Revoke(ctx context.Context, id models.ID) (bool, error) {
+	const query = `
+		UPDATE refresh_token
+		SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	tag, err := P.conn.Exec(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("error revoking refresh token %s: %w", id, err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (P *PGXRepository) RevokeFamily(ctx context.Context, familyID models.ID) error {
+	const query = `
+		UPDATE refresh_token
+		SET revoked_at = NOW()
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`
+
+	_, err := P.conn.Exec(ctx, query, familyID)
+	if err != nil {
+		return fmt.Errorf("error revoking refresh token family %s: %w", familyID, err)
+	}
+
+	return nil
+}
+
+func (P *PGXRepository) RevokeByEmployeeID(ctx context.Context, employeeID models.ID) error {
+	const query = `
+		UPDATE refresh_token
+		SET revoked_at = NOW()
+		WHERE employee_id = $1 AND revoked_at IS NULL
+	`
+
+	_, err := P.conn.Exec(ctx, query, employeeID)
+	if err != nil {
+		return fmt.Errorf("error revoking refresh tokens of employee %s: %w", employeeID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package tender
+
+import (
+	"context"
+	"tenderSystem/internal/infrastructure/postgres/postgrestest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLotsMigrationCopiesBudget(t *testing.T) {
+	const migration = "00011_tender_lots.sql"
+
+	db := postgrestest.NewBefore(t, migration)
+	ctx := context.Background()
+
+	tenderID := uuid.New()
+	_, err := db.Exec(ctx, `INSERT INTO tender (id, organization_id, status) VALUES ($1, $2, 'published')`, tenderID, uuid.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the budget is set in the first version, changed in the second and removed in the current one
+	budgets := []struct {
+		amount   *int64
+		currency *string
+	}{
+		{amount: ptr(int64(100000)), currency: ptr("RUB")},
+		{amount: ptr(int64(150000)), currency: ptr("USD")},
+		{},
+	}
+
+	versionIDs := make([]uuid.UUID, len(budgets))
+	for i, budget := range budgets {
+		versionIDs[i] = uuid.New()
+		_, err = db.Exec(ctx, `
+			INSERT INTO tender_version (id, tender_id, version, name, description, service_type, budget_amount, budget_currency)
+			VALUES ($1, $2, $3, 'Бетон', 'Поставка бетона', 'Construction', $4, $5)
+		`, versionIDs[i], tenderID, i+1, budget.amount, budget.currency)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = db.Exec(ctx, `UPDATE tender SET current_version_id = $1 WHERE id = $2`, versionIDs[len(versionIDs)-1], tenderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	postgrestest.Migrate(t, db, migration)
+
+	for i, budget := range budgets {
+		var amount *int64
+		var currency *string
+		err = db.QueryRow(ctx, `
+			SELECT budget_amount, budget_currency FROM tender_lot_version WHERE tender_version_id = $1
+		`, versionIDs[i]).Scan(&amount, &currency)
+		if err != nil {
+			t.Fatalf("version %d: %v", i+1, err)
+		}
+
+		if !equalPtr(amount, budget.amount) || !equalPtr(currency, budget.currency) {
+			t.Errorf("version %d: lot budget is %v %v, want %v %v", i+1, deref(amount), deref(currency), deref(budget.amount), deref(budget.currency))
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func deref[T any](v *T) any {
+	if v == nil {
+		return nil
+	}
+
+	return *v
+}
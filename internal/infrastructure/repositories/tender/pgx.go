@@ -8,6 +8,7 @@ import (
 	"tenderSystem/internal/abstraction"
 	"tenderSystem/internal/domain"
 	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -16,10 +17,18 @@ import (
 var _ abstraction.TenderRepository = &PGXTenderRepository{}
 
 type tender struct {
-	ID             uuid.UUID
-	OrganizationID uuid.UUID
-	Status         string
-	CreatedAt      time.Time
+	ID              uuid.UUID
+	OrganizationID  uuid.UUID
+	Status          string
+	Visibility      string
+	Revision        int
+	QuorumPolicy    string
+	QuorumThreshold int
+	CreatedAt       time.Time
+
+	SubmissionDeadline *time.Time
+	BidOpeningAt       *time.Time
+	DecisionDeadline   *time.Time
 
 	CurrentVersionID uuid.UUID
 }
@@ -33,13 +42,40 @@ type tenderVersion struct {
 	Name        string
 	Description string
 	ServiceType string
+
+	BudgetAmount   *int64
+	BudgetCurrency *string
+
+	CreatedByType *string
+	CreatedByID   *uuid.UUID
+
+	RolledBackFrom *int
+}
+
+type lot struct {
+	ID           uuid.UUID
+	TenderID     uuid.UUID
+	Status       string
+	AwardedBidID *uuid.UUID
+	CreatedAt    time.Time
+}
+
+type lotVersion struct {
+	TenderVersionID uuid.UUID
+	LotID           uuid.UUID
+	Number          int
+	Name            string
+	Description     string
+
+	BudgetAmount   *int64
+	BudgetCurrency *string
 }
 
 type PGXTenderRepository struct {
-	conn *pgx.Conn
+	conn *postgres.DB
 }
 
-func NewPGXRepository(conn *pgx.Conn) *PGXTenderRepository {
+func NewPGXRepository(conn *postgres.DB) *PGXTenderRepository {
 	return &PGXTenderRepository{
 		conn: conn,
 	}
@@ -47,13 +83,15 @@ func NewPGXRepository(conn *pgx.Conn) *PGXTenderRepository {
 
 func (P *PGXTenderRepository) Create(ctx context.Context, data *models.Tender) (models.Tender, error) {
 	const tenderQuery = `
-		INSERT INTO tender (id, organization_id, status, created_at, current_version_id)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO tender (id, organization_id, status, quorum_policy, quorum_threshold, created_at, current_version_id,
+		                    submission_deadline, bid_opening_at, decision_deadline, visibility)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	const tenderVersionQuery = `
-		INSERT INTO tender_version (id, tender_id, version, created_at, name, description, service_type)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO tender_version (id, tender_id, version, created_at, name, description, service_type, budget_amount, budget_currency,
+		                            created_by_type, created_by_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	tenderVersionEntity := tenderVersion{
@@ -65,12 +103,21 @@ func (P *PGXTenderRepository) Create(ctx context.Context, data *models.Tender) (
 		Description: data.Description,
 		ServiceType: string(data.ServiceType),
 	}
+	tenderVersionEntity.BudgetAmount, tenderVersionEntity.BudgetCurrency = postgres.MoneyToColumns(data.Budget)
+	tenderVersionEntity.CreatedByType, tenderVersionEntity.CreatedByID = postgres.VersionAuthorToColumns(data.VersionAuthor)
 	tenderEntity := tender{
 		ID:               uuid.UUID(data.ID),
 		OrganizationID:   uuid.UUID(data.OrganizationID),
 		Status:           string(data.Status),
+		Visibility:       data.Visibility.String(),
+		QuorumPolicy:     data.QuorumPolicy.Type.String(),
+		QuorumThreshold:  data.QuorumPolicy.Threshold,
 		CreatedAt:        data.CreatedAt,
 		CurrentVersionID: tenderVersionEntity.ID,
+
+		SubmissionDeadline: data.Deadlines.SubmissionDeadline,
+		BidOpeningAt:       data.Deadlines.BidOpeningAt,
+		DecisionDeadline:   data.Deadlines.DecisionDeadline,
 	}
 
 	transaction, err := P.conn.Begin(ctx)
@@ -78,7 +125,9 @@ func (P *PGXTenderRepository) Create(ctx context.Context, data *models.Tender) (
 		return models.Tender{}, err
 	}
 
-	_, err = transaction.Exec(ctx, tenderQuery, tenderEntity.ID, tenderEntity.OrganizationID, tenderEntity.Status, tenderEntity.CreatedAt, tenderEntity.CurrentVersionID)
+	_, err = transaction.Exec(ctx, tenderQuery, tenderEntity.ID, tenderEntity.OrganizationID, tenderEntity.Status, tenderEntity.QuorumPolicy, tenderEntity.QuorumThreshold, tenderEntity.CreatedAt, tenderEntity.CurrentVersionID,
+		tenderEntity.SubmissionDeadline, tenderEntity.BidOpeningAt, tenderEntity.DecisionDeadline, tenderEntity.Visibility,
+	)
 	if err != nil {
 		err := transaction.Rollback(ctx)
 		if err != nil {
@@ -87,7 +136,9 @@ func (P *PGXTenderRepository) Create(ctx context.Context, data *models.Tender) (
 		return models.Tender{}, err
 	}
 
-	_, err = transaction.Exec(ctx, tenderVersionQuery, tenderVersionEntity.ID, tenderVersionEntity.TenderID, tenderVersionEntity.Version, tenderVersionEntity.CreatedAt, tenderVersionEntity.Name, tenderVersionEntity.Description, tenderVersionEntity.ServiceType)
+	_, err = transaction.Exec(ctx, tenderVersionQuery, tenderVersionEntity.ID, tenderVersionEntity.TenderID, tenderVersionEntity.Version, tenderVersionEntity.CreatedAt, tenderVersionEntity.Name, tenderVersionEntity.Description, tenderVersionEntity.ServiceType, tenderVersionEntity.BudgetAmount, tenderVersionEntity.BudgetCurrency,
+		tenderVersionEntity.CreatedByType, tenderVersionEntity.CreatedByID,
+	)
 	if err != nil {
 		err := transaction.Rollback(ctx)
 		if err != nil {
@@ -96,6 +147,12 @@ func (P *PGXTenderRepository) Create(ctx context.Context, data *models.Tender) (
 		return models.Tender{}, err
 	}
 
+	err = insertLots(ctx, transaction, tenderVersionEntity.ID, data.Lots)
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		return models.Tender{}, err
+	}
+
 	err = transaction.Commit(ctx)
 	if err != nil {
 		return models.Tender{}, err
@@ -104,141 +161,559 @@ func (P *PGXTenderRepository) Create(ctx context.Context, data *models.Tender) (
 	return *data, nil
 }
 
-func (P *PGXTenderRepository) GetByID(ctx context.Context, id models.ID) (models.Tender, error) {
+// insertLots creates the lots that do not exist yet and stores the content of all lots for the tender version
+func insertLots(ctx context.Context, transaction pgx.Tx, tenderVersionID uuid.UUID, lots []models.Lot) error {
+	const lotQuery = `
+		INSERT INTO tender_lot (id, tender_id, status, awarded_bid_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	const lotVersionQuery = `
+		INSERT INTO tender_lot_version (tender_version_id, lot_id, number, name, description, budget_amount, budget_currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	for _, data := range lots {
+		lotEntity := lot{
+			ID:        uuid.UUID(data.ID),
+			TenderID:  uuid.UUID(data.TenderID),
+			Status:    data.Status.String(),
+			CreatedAt: data.CreatedAt,
+		}
+		if data.AwardedBidID != nil {
+			awardedBidID := uuid.UUID(*data.AwardedBidID)
+			lotEntity.AwardedBidID = &awardedBidID
+		}
+
+		lotVersionEntity := lotVersion{
+			TenderVersionID: tenderVersionID,
+			LotID:           lotEntity.ID,
+			Number:          data.Number,
+			Name:            data.Name,
+			Description:     data.Description,
+		}
+		lotVersionEntity.BudgetAmount, lotVersionEntity.BudgetCurrency = postgres.MoneyToColumns(data.Budget)
+
+		_, err := transaction.Exec(ctx, lotQuery, lotEntity.ID, lotEntity.TenderID, lotEntity.Status, lotEntity.AwardedBidID, lotEntity.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = transaction.Exec(ctx, lotVersionQuery, lotVersionEntity.TenderVersionID, lotVersionEntity.LotID, lotVersionEntity.Number, lotVersionEntity.Name, lotVersionEntity.Description, lotVersionEntity.BudgetAmount, lotVersionEntity.BudgetCurrency)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const lotSelectQuery = `
+	SELECT l.id, l.tender_id, l.status, l.awarded_bid_id, l.created_at,
+	       lv.number, lv.name, lv.description, lv.budget_amount, lv.budget_currency
+	FROM tender_lot l
+	JOIN tender_lot_version lv ON lv.lot_id = l.id
+`
+
+func scanLots(rows pgx.Rows) ([]models.Lot, error) {
+	defer rows.Close()
+
+	var lots []models.Lot
+	for rows.Next() {
+		var lotEntity lot
+		var lotVersionEntity lotVersion
+
+		err := rows.Scan(
+			&lotEntity.ID, &lotEntity.TenderID, &lotEntity.Status, &lotEntity.AwardedBidID, &lotEntity.CreatedAt,
+			&lotVersionEntity.Number, &lotVersionEntity.Name, &lotVersionEntity.Description, &lotVersionEntity.BudgetAmount, &lotVersionEntity.BudgetCurrency,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		lotModel := models.Lot{
+			ID:          models.ID(lotEntity.ID),
+			TenderID:    models.ID(lotEntity.TenderID),
+			Number:      lotVersionEntity.Number,
+			Name:        lotVersionEntity.Name,
+			Description: lotVersionEntity.Description,
+			Budget:      postgres.MoneyFromColumns(lotVersionEntity.BudgetAmount, lotVersionEntity.BudgetCurrency),
+			Status:      models.LotStatus(lotEntity.Status),
+			CreatedAt:   lotEntity.CreatedAt,
+		}
+		if lotEntity.AwardedBidID != nil {
+			awardedBidID := models.ID(*lotEntity.AwardedBidID)
+			lotModel.AwardedBidID = &awardedBidID
+		}
+
+		lots = append(lots, lotModel)
+	}
+
+	return lots, rows.Err()
+}
+
+// attachLots loads the lots of the current versions of the tenders
+func (P *PGXTenderRepository) attachLots(ctx context.Context, tenders []models.Tender) error {
+	const query = lotSelectQuery + `
+		JOIN tender t ON t.current_version_id = lv.tender_version_id
+		WHERE t.id = any($1)
+		ORDER BY lv.number
+	`
+
+	if len(tenders) == 0 {
+		return nil
+	}
+
+	tenderIDs := make([]uuid.UUID, 0, len(tenders))
+	for _, tenderModel := range tenders {
+		tenderIDs = append(tenderIDs, uuid.UUID(tenderModel.ID))
+	}
+
+	rows, err := P.conn.Query(ctx, query, tenderIDs)
+	if err != nil {
+		return err
+	}
+
+	lots, err := scanLots(rows)
+	if err != nil {
+		return err
+	}
+
+	lotsByTender := make(map[models.ID][]models.Lot, len(tenders))
+	for _, lotModel := range lots {
+		lotsByTender[lotModel.TenderID] = append(lotsByTender[lotModel.TenderID], lotModel)
+	}
+
+	for i := range tenders {
+		tenders[i].Lots = lotsByTender[tenders[i].ID]
+	}
+
+	return nil
+}
+
+// SetLotStatus stores the status of the lot and the bid that won it, the lots are a part of the tender
+// representation, so the tender revision changes too
+func (P *PGXTenderRepository) SetLotStatus(ctx context.Context, id models.ID, status models.LotStatus, awardedBidID *models.ID) error {
 	const query = `
-		SELECT t.id, t.organization_id, t.status, t.created_at, t.current_version_id, tv.version, tv.name, tv.description, tv.service_type
-		FROM tender t
-		JOIN tender_version tv ON t.current_version_id = tv.id
-		WHERE t.id = $1
+		WITH updated AS (
+			UPDATE tender_lot
+			SET status = $1, awarded_bid_id = $2
+			WHERE id = $3
+			RETURNING tender_id
+		)
+		UPDATE tender
+		SET revision = revision + 1
+		WHERE id IN (SELECT tender_id FROM updated)
 	`
 
-	idUUID := uuid.UUID(id)
+	var awardedBidUUID *uuid.UUID
+	if awardedBidID != nil {
+		id := uuid.UUID(*awardedBidID)
+		awardedBidUUID = &id
+	}
 
-	row := P.conn.QueryRow(ctx, query, idUUID)
+	tag, err := P.conn.Exec(ctx, query, status.String(), awardedBidUUID, uuid.UUID(id))
+	if err != nil {
+		return err
+	}
 
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("lot with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+const tenderSelectColumns = `
+	t.id, t.organization_id, t.status, t.quorum_policy, t.quorum_threshold, t.created_at,
+	t.submission_deadline, t.bid_opening_at, t.decision_deadline, t.visibility, t.revision,
+	tv.version, tv.name, tv.description, tv.service_type, tv.budget_amount, tv.budget_currency,
+	tv.created_by_type, tv.created_by_id, tv.rolled_back_from
+`
+
+// tenderFromClause joins the tenders to their current versions
+const tenderFromClause = `
+	FROM tender t
+	JOIN tender_version tv ON t.current_version_id = tv.id
+`
+
+const tenderSelectQuery = `
+	SELECT ` + tenderSelectColumns + tenderFromClause
+
+func scanTender(row pgx.Row) (models.Tender, error) {
+	return scanTenderWith(row)
+}
+
+// scanTenderWith scans a row of tenderSelectQuery followed by the extra columns
+func scanTenderWith(row pgx.Row, extra ...any) (models.Tender, error) {
 	var tenderEntity tender
 	var tenderVersionEntity tenderVersion
 
-	err := row.Scan(&tenderEntity.ID, &tenderEntity.OrganizationID, &tenderEntity.Status, &tenderEntity.CreatedAt, &tenderEntity.CurrentVersionID, &tenderVersionEntity.Version, &tenderVersionEntity.Name, &tenderVersionEntity.Description, &tenderVersionEntity.ServiceType)
+	dest := []any{
+		&tenderEntity.ID, &tenderEntity.OrganizationID, &tenderEntity.Status, &tenderEntity.QuorumPolicy, &tenderEntity.QuorumThreshold, &tenderEntity.CreatedAt,
+		&tenderEntity.SubmissionDeadline, &tenderEntity.BidOpeningAt, &tenderEntity.DecisionDeadline, &tenderEntity.Visibility, &tenderEntity.Revision,
+		&tenderVersionEntity.Version, &tenderVersionEntity.Name, &tenderVersionEntity.Description, &tenderVersionEntity.ServiceType,
+		&tenderVersionEntity.BudgetAmount, &tenderVersionEntity.BudgetCurrency,
+		&tenderVersionEntity.CreatedByType, &tenderVersionEntity.CreatedByID, &tenderVersionEntity.RolledBackFrom,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return models.Tender{}, fmt.Errorf("tender with ID %s not found: %w", id, domain.ErrNotFound)
-		}
 		return models.Tender{}, err
 	}
 
-	tenderModel := models.Tender{
+	return models.Tender{
 		ID:             models.ID(tenderEntity.ID),
 		Name:           tenderVersionEntity.Name,
 		Description:    tenderVersionEntity.Description,
 		Status:         models.TenderStatus(tenderEntity.Status),
-		ServiceType:    models.TenderType(tenderVersionEntity.ServiceType),
+		ServiceType:    models.CategoryCode(tenderVersionEntity.ServiceType),
 		OrganizationID: models.ID(tenderEntity.OrganizationID),
+		Visibility:     models.TenderVisibility(tenderEntity.Visibility),
+		Revision:       tenderEntity.Revision,
+		QuorumPolicy: models.QuorumPolicy{
+			Type:      models.QuorumPolicyType(tenderEntity.QuorumPolicy),
+			Threshold: tenderEntity.QuorumThreshold,
+		},
+		Deadlines: models.TenderDeadlines{
+			SubmissionDeadline: tenderEntity.SubmissionDeadline,
+			BidOpeningAt:       tenderEntity.BidOpeningAt,
+			DecisionDeadline:   tenderEntity.DecisionDeadline,
+		},
+		Budget:         postgres.MoneyFromColumns(tenderVersionEntity.BudgetAmount, tenderVersionEntity.BudgetCurrency),
 		Version:        tenderVersionEntity.Version,
+		VersionAuthor:  postgres.VersionAuthorFromColumns(tenderVersionEntity.CreatedByType, tenderVersionEntity.CreatedByID),
+		RolledBackFrom: tenderVersionEntity.RolledBackFrom,
 		CreatedAt:      tenderEntity.CreatedAt,
+	}, nil
+}
+
+func (P *PGXTenderRepository) GetByID(ctx context.Context, id models.ID) (models.Tender, error) {
+	const query = tenderSelectQuery + `
+		WHERE t.id = $1
+	`
+
+	tenderModel, err := scanTender(P.conn.QueryRow(ctx, query, uuid.UUID(id)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Tender{}, fmt.Errorf("tender with ID %s not found: %w", id, domain.ErrNotFound)
+		}
+		return models.Tender{}, err
 	}
 
-	return tenderModel, nil
+	tenders := []models.Tender{tenderModel}
+	err = P.attachLots(ctx, tenders)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	return tenders[0], nil
 }
 
-func (P *PGXTenderRepository) GetAll(ctx context.Context, options ...abstraction.GetTendersOptFunc) ([]models.Tender, error) {
+// LockByID locks the tender row until the end of the transaction carried by ctx and returns the tender
+func (P *PGXTenderRepository) LockByID(ctx context.Context, id models.ID) (models.Tender, error) {
 	const query = `
-		SELECT t.id, t.organization_id, t.status, t.created_at, t.current_version_id, tv.version, tv.name, tv.description, tv.service_type
-		FROM tender t
-		JOIN tender_version tv ON t.current_version_id = tv.id
-		WHERE tv.service_type = any($1) OR $1 = '{}'
-		ORDER BY t.created_at DESC
-		OFFSET $2
-		LIMIT $3
+		SELECT id
+		FROM tender
+		WHERE id = $1
+		FOR UPDATE
 	`
 
-	getTenderOptions, err := abstraction.NewGetTendersOptions(options...)
+	var lockedID uuid.UUID
+	err := P.conn.QueryRow(ctx, query, uuid.UUID(id)).Scan(&lockedID)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Tender{}, fmt.Errorf("tender with ID %s not found: %w", id, domain.ErrNotFound)
+		}
+		return models.Tender{}, err
 	}
 
+	return P.GetByID(ctx, id)
+}
+
+// tenderFiltersClause applies GetTendersOptions, its arguments are built by tenderFiltersArgs.
+// Service types match the categories and all their descendants.
+const tenderFiltersClause = `
+	($1 = '{}' OR tv.service_type IN (
+		WITH RECURSIVE subtree AS (
+			SELECT code FROM category WHERE code = any($1)
+			UNION
+			SELECT c.code FROM category c JOIN subtree s ON c.parent_code = s.code
+		)
+		SELECT code FROM subtree
+	))
+	AND ($2::TEXT IS NULL OR tv.budget_currency = $2)
+	AND ($3::BIGINT IS NULL OR tv.budget_amount >= $3)
+	AND ($4::BIGINT IS NULL OR tv.budget_amount <= $4)
+	AND ($5 = '{}' OR t.status = any($5))
+	AND ($6::UUID IS NULL OR t.organization_id = $6)
+	AND ($7::TIMESTAMPTZ IS NULL OR t.created_at >= $7)
+	AND ($8::TIMESTAMPTZ IS NULL OR t.created_at <= $8)
+	AND ($9::TIMESTAMPTZ IS NULL OR t.submission_deadline >= $9)
+	AND ($10::TIMESTAMPTZ IS NULL OR t.submission_deadline <= $10)
+	AND ($11::TIMESTAMPTZ IS NULL OR t.decision_deadline >= $11)
+	AND ($12::TIMESTAMPTZ IS NULL OR t.decision_deadline <= $12)
+	AND ($13::INT IS NULL OR tv.version >= $13)
+	AND ($14::INT IS NULL OR tv.version <= $14)
+	AND (NOT $15::BOOLEAN OR t.visibility = 'public' OR t.organization_id = any($17::UUID[]) OR EXISTS (
+		SELECT 1
+		FROM tender_invitee ti
+		WHERE ti.tender_id = t.id AND (
+			(ti.invitee_type = 'employee' AND ti.invitee_id = $16::UUID) OR
+			(ti.invitee_type = 'organization' AND ti.invitee_id = any($17::UUID[]))
+		)
+	))
+`
+
+// tenderFiltersArgs returns the arguments of tenderFiltersClause, the page arguments follow them
+func tenderFiltersArgs(getTenderOptions *abstraction.GetTendersOptions) []any {
 	serviceTypes := make([]string, 0, len(getTenderOptions.ServiceTypes))
 	for _, serviceType := range getTenderOptions.ServiceTypes {
 		serviceTypes = append(serviceTypes, serviceType.String())
 	}
 
-	rows, err := P.conn.Query(ctx, query, serviceTypes, getTenderOptions.PaginationOptions.Offset, getTenderOptions.PaginationOptions.Limit)
-	if err != nil {
-		return nil, err
+	var budgetCurrency *string
+	var budgetMin, budgetMax *int64
+	if getTenderOptions.Budget != nil {
+		currency := getTenderOptions.Budget.Currency.String()
+		budgetCurrency = &currency
+		budgetMin = getTenderOptions.Budget.Min
+		budgetMax = getTenderOptions.Budget.Max
+	}
+
+	statuses := make([]string, 0, len(getTenderOptions.Statuses))
+	for _, status := range getTenderOptions.Statuses {
+		statuses = append(statuses, status.String())
+	}
+
+	var organizationID *uuid.UUID
+	if getTenderOptions.OrganizationID != nil {
+		id := uuid.UUID(*getTenderOptions.OrganizationID)
+		organizationID = &id
+	}
+
+	// the viewer arguments are ignored without a viewer
+	var viewerEmployeeID *uuid.UUID
+	viewerOrganizationIDs := make([]uuid.UUID, 0)
+	if viewer := getTenderOptions.Viewer; viewer != nil {
+		if viewer.EmployeeID != nil {
+			id := uuid.UUID(*viewer.EmployeeID)
+			viewerEmployeeID = &id
+		}
+		for _, id := range viewer.OrganizationIDs {
+			viewerOrganizationIDs = append(viewerOrganizationIDs, uuid.UUID(id))
+		}
 	}
 
+	return []any{
+		serviceTypes,
+		budgetCurrency, budgetMin, budgetMax,
+		statuses, organizationID,
+		getTenderOptions.CreatedAt.From, getTenderOptions.CreatedAt.To,
+		getTenderOptions.SubmissionDeadline.From, getTenderOptions.SubmissionDeadline.To,
+		getTenderOptions.DecisionDeadline.From, getTenderOptions.DecisionDeadline.To,
+		getTenderOptions.Version.Min, getTenderOptions.Version.Max,
+		getTenderOptions.Viewer != nil, viewerEmployeeID, viewerOrganizationIDs,
+	}
+}
+
+// tenderOrders maps the sort orders to keyset orders, tenders without a budget go last
+var tenderOrders = map[abstraction.TenderSortOrder]postgres.KeysetOrder{
+	abstraction.TenderSortCreatedAtDesc: {Name: "tenders.created_at_desc", Key: "t.created_at", Type: "TIMESTAMP", Desc: true, ID: "t.id"},
+	abstraction.TenderSortCreatedAtAsc:  {Name: "tenders.created_at_asc", Key: "t.created_at", Type: "TIMESTAMP", ID: "t.id"},
+	abstraction.TenderSortNameAsc:       {Name: "tenders.name_asc", Key: "tv.name", Type: "TEXT", ID: "t.id"},
+	abstraction.TenderSortNameDesc:      {Name: "tenders.name_desc", Key: "tv.name", Type: "TEXT", Desc: true, ID: "t.id"},
+	abstraction.TenderSortBudgetAsc:     {Name: "tenders.budget_asc", Key: "tv.budget_amount", Type: "BIGINT", NullsLast: true, ID: "t.id"},
+	abstraction.TenderSortBudgetDesc:    {Name: "tenders.budget_desc", Key: "tv.budget_amount", Type: "BIGINT", Desc: true, NullsLast: true, ID: "t.id"},
+}
+
+// scanTenderPage scans the tenders of a paginated query with their positions
+func scanTenderPage(rows pgx.Rows) ([]models.Tender, []postgres.RowPosition, error) {
+	defer rows.Close()
+
 	var tenders []models.Tender
+	var positions []postgres.RowPosition
 	for rows.Next() {
-		var tenderEntity tender
-		var tenderVersionEntity tenderVersion
+		var position postgres.RowPosition
 
-		err := rows.Scan(&tenderEntity.ID, &tenderEntity.OrganizationID, &tenderEntity.Status, &tenderEntity.CreatedAt, &tenderEntity.CurrentVersionID, &tenderVersionEntity.Version, &tenderVersionEntity.Name, &tenderVersionEntity.Description, &tenderVersionEntity.ServiceType)
+		tenderModel, err := scanTenderWith(rows, position.Dest()...)
 		if err != nil {
-			return nil, err
-		}
-
-		tenderModel := models.Tender{
-			ID:             models.ID(tenderEntity.ID),
-			Name:           tenderVersionEntity.Name,
-			Description:    tenderVersionEntity.Description,
-			Status:         models.TenderStatus(tenderEntity.Status),
-			ServiceType:    models.TenderType(tenderVersionEntity.ServiceType),
-			OrganizationID: models.ID(tenderEntity.OrganizationID),
-			Version:        tenderVersionEntity.Version,
-			CreatedAt:      tenderEntity.CreatedAt,
+			return nil, nil, err
 		}
 
 		tenders = append(tenders, tenderModel)
+		positions = append(positions, position)
+	}
+
+	return tenders, positions, rows.Err()
+}
+
+func (P *PGXTenderRepository) GetAll(ctx context.Context, options ...abstraction.GetTendersOptFunc) ([]models.Tender, error) {
+	getTenderOptions, err := abstraction.NewGetTendersOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	order, ok := tenderOrders[getTenderOptions.SortOrder]
+	if !ok {
+		return nil, fmt.Errorf("unknown tender sort order %s: %w", getTenderOptions.SortOrder, domain.ErrInvalidArgument)
+	}
+
+	fromWhere := tenderFromClause + `
+		WHERE ` + tenderFiltersClause
+	args := tenderFiltersArgs(getTenderOptions)
+	pagination := getTenderOptions.PaginationOptions
+
+	query, queryArgs, err := postgres.Paginate("SELECT "+tenderSelectColumns+", "+order.PositionColumns()+fromWhere, args, order, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := P.conn.Query(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	tenders, positions, err := scanTenderPage(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	tenders = postgres.NextPage(tenders, positions, order, pagination)
+
+	err = postgres.CountTotal(ctx, P.conn, fromWhere, args, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	err = P.attachLots(ctx, tenders)
+	if err != nil {
+		return nil, err
 	}
 
 	return tenders, nil
 }
 
-func (P *PGXTenderRepository) GetByOrganizationID(ctx context.Context, authorID models.ID, options ...abstraction.PaginationOptFunc) ([]models.Tender, error) {
-	const query = `
-		SELECT t.id, t.organization_id, t.status, t.created_at, t.current_version_id, tv.version, tv.name, tv.description, tv.service_type
-		FROM tender t
-		JOIN tender_version tv ON t.current_version_id = tv.id
-		WHERE t.organization_id = $1
-		ORDER BY t.created_at DESC
-		OFFSET $2
-		LIMIT $3
+// searchFromWhere matches the full-text query in $18, only current versions are matched, so the
+// results follow Update and Rollback without reindexing
+var searchFromWhere = tenderFromClause + `
+	CROSS JOIN (SELECT ` + postgres.SearchQuery("$18") + ` AS query) s
+	WHERE tv.search_vector @@ s.query AND ` + tenderFiltersClause
+
+// tenderSearchOrder replaces the sort order of the search results by the relevance
+var tenderSearchOrder = postgres.KeysetOrder{Name: "tenders.relevance", Key: "ts_rank(tv.search_vector, s.query)", Type: "REAL", Desc: true, ID: "t.id"}
+
+func (P *PGXTenderRepository) Search(ctx context.Context, text string, options ...abstraction.GetTendersOptFunc) ([]models.TenderSearchResult, error) {
+	getTenderOptions, err := abstraction.NewGetTendersOptions(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	selectClause := `
+		SELECT ` + tenderSelectColumns + `, ` + tenderSearchOrder.PositionColumns() + `,
+		       ts_rank(tv.search_vector, s.query),
+		       ` + postgres.Headline("tv.name || ' ' || tv.description", "s.query") + `
 	`
+	args := append(tenderFiltersArgs(getTenderOptions), text)
+	pagination := getTenderOptions.PaginationOptions
+
+	query, queryArgs, err := postgres.Paginate(selectClause+searchFromWhere, args, tenderSearchOrder, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := P.conn.Query(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var results []models.TenderSearchResult
+	var positions []postgres.RowPosition
+	for rows.Next() {
+		var result models.TenderSearchResult
+		var position postgres.RowPosition
+
+		result.Tender, err = scanTenderWith(rows, append(position.Dest(), &result.Rank, &result.Snippet)...)
+		if err != nil {
+			return nil, err
+		}
+		result.Snippet = postgres.HighlightHeadline(result.Snippet)
 
-	authorIDUUID := uuid.UUID(authorID)
+		results = append(results, result)
+		positions = append(positions, position)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	results = postgres.NextPage(results, positions, tenderSearchOrder, pagination)
+
+	err = postgres.CountTotal(ctx, P.conn, searchFromWhere, args, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	tenders := make([]models.Tender, 0, len(results))
+	for _, result := range results {
+		tenders = append(tenders, result.Tender)
+	}
+
+	err = P.attachLots(ctx, tenders)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Tender = tenders[i]
+	}
+
+	return results, nil
+}
+
+// expiredOrder puts the tenders that closed earliest first
+var expiredOrder = postgres.KeysetOrder{Name: "tenders.expired", Key: "COALESCE(t.decision_deadline, t.submission_deadline)", Type: "TIMESTAMPTZ", ID: "t.id"}
+
+// GetExpired returns published tenders whose closing time is not after now, the earliest first
+func (P *PGXTenderRepository) GetExpired(ctx context.Context, now time.Time, options ...abstraction.PaginationOptFunc) ([]models.Tender, error) {
+	const fromWhere = tenderFromClause + `
+		WHERE t.status = $1 AND COALESCE(t.decision_deadline, t.submission_deadline) <= $2
+	`
 
 	paginationOptions, err := abstraction.NewPaginationOptions(options...)
 	if err != nil {
 		return nil, fmt.Errorf("error creating pagination options: %w", err)
 	}
 
-	rows, err := P.conn.Query(ctx, query, authorIDUUID, paginationOptions.Offset, paginationOptions.Limit)
+	args := []any{models.TenderStatusPublished.String(), now}
+	query, queryArgs, err := postgres.Paginate("SELECT "+tenderSelectColumns+", "+expiredOrder.PositionColumns()+fromWhere, args, expiredOrder, paginationOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := P.conn.Query(ctx, query, queryArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("error executing query: %w", err)
 	}
 
-	var tenders []models.Tender
-	for rows.Next() {
-		var tenderEntity tender
-		var tenderVersionEntity tenderVersion
+	tenders, positions, err := scanTenderPage(rows)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning row: %w", err)
+	}
 
-		err := rows.Scan(&tenderEntity.ID, &tenderEntity.OrganizationID, &tenderEntity.Status, &tenderEntity.CreatedAt, &tenderEntity.CurrentVersionID, &tenderVersionEntity.Version, &tenderVersionEntity.Name, &tenderVersionEntity.Description, &tenderVersionEntity.ServiceType)
-		if err != nil {
-			return nil, fmt.Errorf("error scanning row: %w", err)
-		}
+	tenders = postgres.NextPage(tenders, positions, expiredOrder, paginationOptions)
 
-		tenderModel := models.Tender{
-			ID:             models.ID(tenderEntity.ID),
-			Name:           tenderVersionEntity.Name,
-			Description:    tenderVersionEntity.Description,
-			Status:         models.TenderStatus(tenderEntity.Status),
-			ServiceType:    models.TenderType(tenderVersionEntity.ServiceType),
-			OrganizationID: models.ID(tenderEntity.OrganizationID),
-			Version:        tenderVersionEntity.Version,
-			CreatedAt:      tenderEntity.CreatedAt,
-		}
+	err = postgres.CountTotal(ctx, P.conn, fromWhere, args, paginationOptions)
+	if err != nil {
+		return nil, err
+	}
 
-		tenders = append(tenders, tenderModel)
+	err = P.attachLots(ctx, tenders)
+	if err != nil {
+		return nil, err
 	}
 
 	return tenders, nil
@@ -273,58 +748,27 @@ func (P *PGXTenderRepository) GetLatestVersionNumber(ctx context.Context, id mod
 func (P *PGXTenderRepository) SetStatus(ctx context.Context, id models.ID, status models.TenderStatus) (models.Tender, error) {
 	const query = `
 		UPDATE tender
-		SET status = $1
+		SET status = $1, revision = revision + 1
 		WHERE id = $2
-		RETURNING organization_id, created_at, current_version_id
 	`
 
-	idUUID := uuid.UUID(id)
-
-	row := P.conn.QueryRow(ctx, query, status.String(), idUUID)
-
-	var tenderEntity tender
-
-	err := row.Scan(&tenderEntity.OrganizationID, &tenderEntity.CreatedAt, &tenderEntity.CurrentVersionID)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return models.Tender{}, fmt.Errorf("tender with ID %s not found: %w", id, domain.ErrNotFound)
-		}
-		return models.Tender{}, err
-	}
-
-	const queryVersion = `
-		SELECT version, name, description, service_type
-		FROM tender_version
-		WHERE id = $1
-	`
-
-	row = P.conn.QueryRow(ctx, queryVersion, tenderEntity.CurrentVersionID)
-
-	var tenderVersionEntity tenderVersion
-
-	err = row.Scan(&tenderVersionEntity.Version, &tenderVersionEntity.Name, &tenderVersionEntity.Description, &tenderVersionEntity.ServiceType)
+	tag, err := P.conn.Exec(ctx, query, status.String(), uuid.UUID(id))
 	if err != nil {
 		return models.Tender{}, err
 	}
 
-	tenderModel := models.Tender{
-		ID:             models.ID(idUUID),
-		Name:           tenderVersionEntity.Name,
-		Description:    tenderVersionEntity.Description,
-		Status:         status,
-		ServiceType:    models.TenderType(tenderVersionEntity.ServiceType),
-		OrganizationID: models.ID(tenderEntity.OrganizationID),
-		Version:        tenderVersionEntity.Version,
-		CreatedAt:      tenderEntity.CreatedAt,
+	if tag.RowsAffected() == 0 {
+		return models.Tender{}, fmt.Errorf("tender with ID %s not found: %w", id, domain.ErrNotFound)
 	}
 
-	return tenderModel, nil
+	return P.GetByID(ctx, id)
 }
 
 func (P *PGXTenderRepository) Update(ctx context.Context, id models.ID, data *models.Tender) (models.Tender, error) {
 	const query = `
-		INSERT INTO tender_version (id, tender_id, version, created_at, name, description, service_type)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO tender_version (id, tender_id, version, created_at, name, description, service_type, budget_amount, budget_currency,
+		                            created_by_type, created_by_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	idUUID := uuid.UUID(id)
@@ -338,13 +782,35 @@ func (P *PGXTenderRepository) Update(ctx context.Context, id models.ID, data *mo
 		Description: data.Description,
 		ServiceType: string(data.ServiceType),
 	}
+	tenderVersionEntity.BudgetAmount, tenderVersionEntity.BudgetCurrency = postgres.MoneyToColumns(data.Budget)
+	tenderVersionEntity.CreatedByType, tenderVersionEntity.CreatedByID = postgres.VersionAuthorToColumns(data.VersionAuthor)
 
 	transaction, err := P.conn.Begin(ctx)
 	if err != nil {
 		return models.Tender{}, err
 	}
 
-	_, err = transaction.Exec(ctx, query, tenderVersionEntity.ID, tenderVersionEntity.TenderID, tenderVersionEntity.Version, tenderVersionEntity.CreatedAt, tenderVersionEntity.Name, tenderVersionEntity.Description, tenderVersionEntity.ServiceType)
+	_, err = transaction.Exec(ctx, query, tenderVersionEntity.ID, tenderVersionEntity.TenderID, tenderVersionEntity.Version, tenderVersionEntity.CreatedAt, tenderVersionEntity.Name, tenderVersionEntity.Description, tenderVersionEntity.ServiceType, tenderVersionEntity.BudgetAmount, tenderVersionEntity.BudgetCurrency,
+		tenderVersionEntity.CreatedByType, tenderVersionEntity.CreatedByID,
+	)
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		if postgres.IsUniqueViolation(err, "uq_tender_version_number") {
+			return models.Tender{}, fmt.Errorf("version %d of tender %s already exists: %w", tenderVersionEntity.Version, id, domain.ErrAlreadyExists)
+		}
+		return models.Tender{}, err
+	}
+
+	// the new version inherits the attachments of the current one
+	const copyAttachmentsQuery = `
+		INSERT INTO tender_version_attachment (tender_version_id, attachment_id)
+		SELECT $1, tva.attachment_id
+		FROM tender t
+		JOIN tender_version_attachment tva ON tva.tender_version_id = t.current_version_id
+		WHERE t.id = $2
+	`
+
+	_, err = transaction.Exec(ctx, copyAttachmentsQuery, tenderVersionEntity.ID, idUUID)
 	if err != nil {
 		_ = transaction.Rollback(ctx)
 		return models.Tender{}, err
@@ -352,11 +818,13 @@ func (P *PGXTenderRepository) Update(ctx context.Context, id models.ID, data *mo
 
 	const updateQuery = `
 		UPDATE tender
-		SET current_version_id = $1
+		SET current_version_id = $1, submission_deadline = $3, bid_opening_at = $4, decision_deadline = $5, revision = revision + 1
 		WHERE id = $2
+		RETURNING revision
 	`
 
-	_, err = transaction.Exec(ctx, updateQuery, tenderVersionEntity.ID, idUUID)
+	var revision int
+	err = transaction.QueryRow(ctx, updateQuery, tenderVersionEntity.ID, idUUID, data.Deadlines.SubmissionDeadline, data.Deadlines.BidOpeningAt, data.Deadlines.DecisionDeadline).Scan(&revision)
 	if err != nil {
 		_ = transaction.Rollback(ctx)
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -365,6 +833,12 @@ func (P *PGXTenderRepository) Update(ctx context.Context, id models.ID, data *mo
 		return models.Tender{}, err
 	}
 
+	err = insertLots(ctx, transaction, tenderVersionEntity.ID, data.Lots)
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		return models.Tender{}, err
+	}
+
 	err = transaction.Commit(ctx)
 	if err != nil {
 		return models.Tender{}, err
@@ -377,138 +851,308 @@ func (P *PGXTenderRepository) Update(ctx context.Context, id models.ID, data *mo
 		Status:         data.Status,
 		ServiceType:    data.ServiceType,
 		OrganizationID: data.OrganizationID,
+		Visibility:     data.Visibility,
+		Revision:       revision,
+		QuorumPolicy:   data.QuorumPolicy,
+		Deadlines:      data.Deadlines,
+		Budget:         data.Budget,
+		Lots:           data.Lots,
 		Version:        tenderVersionEntity.Version,
+		VersionAuthor:  data.VersionAuthor,
 		CreatedAt:      tenderVersionEntity.CreatedAt,
 	}
 
 	return tenderModel, nil
 }
 
+// versionsOrder puts the latest versions first
+var versionsOrder = postgres.KeysetOrder{Name: "tender_versions", Key: "tv.version", Type: "INT", Desc: true, ID: "tv.id"}
+
+// tenderVersionsFromClause joins any version of a tender to the tender, so the selected tender
+// columns hold the version instead of the current one
+const tenderVersionsFromClause = `
+	FROM tender_version tv
+	JOIN tender t ON t.id = tv.tender_id
+`
+
+// GetVersions returns the versions of the tender without their lots, the latest first.
+// CreatedAt is the creation time of the version.
 func (P *PGXTenderRepository) GetVersions(ctx context.Context, id models.ID, options ...abstraction.PaginationOptFunc) ([]models.Tender, error) {
-	const query = `
-		SELECT tv.id, tv.tender_id, tv.version, tv.created_at, tv.name, tv.description, tv.service_type
-		FROM tender_version tv
+	const fromWhere = tenderVersionsFromClause + `
 		WHERE tv.tender_id = $1
-		ORDER BY tv.version DESC
-		OFFSET $2
-		LIMIT $3
 	`
 
-	idUUID := uuid.UUID(id)
-
 	paginationOptions, err := abstraction.NewPaginationOptions(options...)
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := P.conn.Query(ctx, query, idUUID, paginationOptions.Offset, paginationOptions.Limit)
+	args := []any{uuid.UUID(id)}
+	query, queryArgs, err := postgres.Paginate("SELECT "+tenderSelectColumns+", tv.created_at, "+versionsOrder.PositionColumns()+fromWhere, args, versionsOrder, paginationOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := P.conn.Query(ctx, query, queryArgs...)
 	if err != nil {
 		return nil, err
 	}
 
+	defer rows.Close()
+
 	var tenders []models.Tender
+	var positions []postgres.RowPosition
 	for rows.Next() {
-		var tenderVersionEntity tenderVersion
+		var versionCreatedAt time.Time
+		var position postgres.RowPosition
 
-		err := rows.Scan(&tenderVersionEntity.ID, &tenderVersionEntity.TenderID, &tenderVersionEntity.Version, &tenderVersionEntity.CreatedAt, &tenderVersionEntity.Name, &tenderVersionEntity.Description, &tenderVersionEntity.ServiceType)
+		tenderModel, err := scanTenderWith(rows, append([]any{&versionCreatedAt}, position.Dest()...)...)
 		if err != nil {
 			return nil, err
 		}
-
-		tenderModel := models.Tender{
-			ID:             models.ID(tenderVersionEntity.TenderID),
-			Name:           tenderVersionEntity.Name,
-			Description:    tenderVersionEntity.Description,
-			Status:         models.TenderStatusUnknown,
-			ServiceType:    models.TenderType(tenderVersionEntity.ServiceType),
-			OrganizationID: models.ID(tenderVersionEntity.TenderID),
-			Version:        tenderVersionEntity.Version,
-			CreatedAt:      tenderVersionEntity.CreatedAt,
-		}
+		tenderModel.CreatedAt = versionCreatedAt
 
 		tenders = append(tenders, tenderModel)
+		positions = append(positions, position)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tenders = postgres.NextPage(tenders, positions, versionsOrder, paginationOptions)
+
+	err = postgres.CountTotal(ctx, P.conn, fromWhere, args, paginationOptions)
+	if err != nil {
+		return nil, err
 	}
 
 	return tenders, nil
 }
 
+// GetSpecificVersion returns the version of the tender with its lots, CreatedAt is the creation time of the version
 func (P *PGXTenderRepository) GetSpecificVersion(ctx context.Context, id models.ID, version int) (models.Tender, error) {
 	const query = `
-		SELECT tv.id, tv.tender_id, tv.version, tv.created_at, tv.name, tv.description, tv.service_type
-		FROM tender_version tv
+		SELECT ` + tenderSelectColumns + `, tv.created_at, tv.id
+		` + tenderVersionsFromClause + `
 		WHERE tv.tender_id = $1 AND tv.version = $2
 	`
 
-	idUUID := uuid.UUID(id)
-
-	row := P.conn.QueryRow(ctx, query, idUUID, version)
-
-	var tenderVersionEntity tenderVersion
+	var versionCreatedAt time.Time
+	var versionID uuid.UUID
 
-	err := row.Scan(&tenderVersionEntity.ID, &tenderVersionEntity.TenderID, &tenderVersionEntity.Version, &tenderVersionEntity.CreatedAt, &tenderVersionEntity.Name, &tenderVersionEntity.Description, &tenderVersionEntity.ServiceType)
+	tenderModel, err := scanTenderWith(P.conn.QueryRow(ctx, query, uuid.UUID(id), version), &versionCreatedAt, &versionID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.Tender{}, fmt.Errorf("tender with ID %s and version %d not found: %w", id, version, domain.ErrNotFound)
 		}
 		return models.Tender{}, err
 	}
+	tenderModel.CreatedAt = versionCreatedAt
 
-	tenderModel := models.Tender{
-		ID:             models.ID(tenderVersionEntity.TenderID),
-		Name:           tenderVersionEntity.Name,
-		Description:    tenderVersionEntity.Description,
-		Status:         models.TenderStatusUnknown,
-		ServiceType:    models.TenderType(tenderVersionEntity.ServiceType),
-		OrganizationID: models.ID(tenderVersionEntity.TenderID),
-		Version:        tenderVersionEntity.Version,
-		CreatedAt:      tenderVersionEntity.CreatedAt,
+	const lotsQuery = lotSelectQuery + `
+		WHERE lv.tender_version_id = $1
+		ORDER BY lv.number
+	`
+
+	rows, err := P.conn.Query(ctx, lotsQuery, versionID)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	tenderModel.Lots, err = scanLots(rows)
+	if err != nil {
+		return models.Tender{}, err
 	}
 
 	return tenderModel, nil
 }
 
-func (P *PGXTenderRepository) Rollback(ctx context.Context, id models.ID, version int) (models.Tender, error) {
-	const query = `
-		SELECT tv.id, tv.tender_id, tv.version, tv.created_at, tv.name, tv.description, tv.service_type
+// Rollback copies the version into a new version, which becomes current and records the version it was
+// rolled back from. Lots and attachments are linked to the version, so they are copied as well.
+func (P *PGXTenderRepository) Rollback(ctx context.Context, id models.ID, version int, author *models.VersionAuthor) (models.Tender, error) {
+	// the lock serializes the version numbers of the tender
+	const lockQuery = `
+		SELECT id
+		FROM tender
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	const versionInsertQuery = `
+		INSERT INTO tender_version (id, tender_id, version, created_at, name, description, service_type, budget_amount, budget_currency,
+		                            created_by_type, created_by_id, rolled_back_from)
+		SELECT $1, tv.tender_id, (SELECT MAX(version) + 1 FROM tender_version WHERE tender_id = $2), $4,
+		       tv.name, tv.description, tv.service_type, tv.budget_amount, tv.budget_currency,
+		       $5, $6, tv.version
 		FROM tender_version tv
-		WHERE tv.tender_id = $1 AND tv.version = $2
+		WHERE tv.tender_id = $2 AND tv.version = $3
+	`
+
+	const copyLotsQuery = `
+		INSERT INTO tender_lot_version (tender_version_id, lot_id, number, name, description, budget_amount, budget_currency)
+		SELECT $1, lv.lot_id, lv.number, lv.name, lv.description, lv.budget_amount, lv.budget_currency
+		FROM tender_version tv
+		JOIN tender_lot_version lv ON lv.tender_version_id = tv.id
+		WHERE tv.tender_id = $2 AND tv.version = $3
+	`
+
+	const copyAttachmentsQuery = `
+		INSERT INTO tender_version_attachment (tender_version_id, attachment_id)
+		SELECT $1, tva.attachment_id
+		FROM tender_version tv
+		JOIN tender_version_attachment tva ON tva.tender_version_id = tv.id
+		WHERE tv.tender_id = $2 AND tv.version = $3
+	`
+
+	const updateQuery = `
+		UPDATE tender
+		SET current_version_id = $1, revision = revision + 1
+		WHERE id = $2
 	`
 
 	idUUID := uuid.UUID(id)
+	versionID := uuid.New()
+	createdByType, createdByID := postgres.VersionAuthorToColumns(author)
 
-	row := P.conn.QueryRow(ctx, query, idUUID, version)
+	transaction, err := P.conn.Begin(ctx)
+	if err != nil {
+		return models.Tender{}, err
+	}
 
-	var tenderVersionEntity tenderVersion
+	var lockedID uuid.UUID
+	err = transaction.QueryRow(ctx, lockQuery, idUUID).Scan(&lockedID)
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Tender{}, fmt.Errorf("tender with ID %s not found: %w", id, domain.ErrNotFound)
+		}
+		return models.Tender{}, err
+	}
 
-	err := row.Scan(&tenderVersionEntity.ID, &tenderVersionEntity.TenderID, &tenderVersionEntity.Version, &tenderVersionEntity.CreatedAt, &tenderVersionEntity.Name, &tenderVersionEntity.Description, &tenderVersionEntity.ServiceType)
+	tag, err := transaction.Exec(ctx, versionInsertQuery, versionID, idUUID, version, time.Now(), createdByType, createdByID)
 	if err != nil {
+		_ = transaction.Rollback(ctx)
 		return models.Tender{}, err
 	}
 
-	const updateQuery = `
+	if tag.RowsAffected() == 0 {
+		_ = transaction.Rollback(ctx)
+		return models.Tender{}, fmt.Errorf("tender with ID %s and version %d not found: %w", id, version, domain.ErrNotFound)
+	}
+
+	for _, copyQuery := range []string{copyLotsQuery, copyAttachmentsQuery} {
+		_, err = transaction.Exec(ctx, copyQuery, versionID, idUUID, version)
+		if err != nil {
+			_ = transaction.Rollback(ctx)
+			return models.Tender{}, err
+		}
+	}
+
+	_, err = transaction.Exec(ctx, updateQuery, versionID, idUUID)
+	if err != nil {
+		_ = transaction.Rollback(ctx)
+		return models.Tender{}, err
+	}
+
+	err = transaction.Commit(ctx)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	return P.GetByID(ctx, id)
+}
+
+func (P *PGXTenderRepository) SetVisibility(ctx context.Context, id models.ID, visibility models.TenderVisibility) (models.Tender, error) {
+	const query = `
 		UPDATE tender
-		SET current_version_id = $1
+		SET visibility = $1, revision = revision + 1
 		WHERE id = $2
 	`
 
-	_, err = P.conn.Exec(ctx, updateQuery, tenderVersionEntity.ID, idUUID)
+	tag, err := P.conn.Exec(ctx, query, visibility.String(), uuid.UUID(id))
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return models.Tender{}, fmt.Errorf("tender with ID %s not found: %w", id, domain.ErrNotFound)
-		}
 		return models.Tender{}, err
 	}
 
-	tenderModel := models.Tender{
-		ID:             models.ID(tenderVersionEntity.TenderID),
-		Name:           tenderVersionEntity.Name,
-		Description:    tenderVersionEntity.Description,
-		Status:         models.TenderStatusUnknown,
-		ServiceType:    models.TenderType(tenderVersionEntity.ServiceType),
-		OrganizationID: models.ID(tenderVersionEntity.TenderID),
-		Version:        tenderVersionEntity.Version,
-		CreatedAt:      tenderVersionEntity.CreatedAt,
+	if tag.RowsAffected() == 0 {
+		return models.Tender{}, fmt.Errorf("tender with ID %s not found: %w", id, domain.ErrNotFound)
 	}
 
-	return tenderModel, nil
+	return P.GetByID(ctx, id)
+}
+
+func (P *PGXTenderRepository) GetInvitees(ctx context.Context, id models.ID) ([]models.TenderInvitee, error) {
+	const query = `
+		SELECT invitee_type, invitee_id
+		FROM tender_invitee
+		WHERE tender_id = $1
+		ORDER BY created_at, invitee_type, invitee_id
+	`
+
+	rows, err := P.conn.Query(ctx, query, uuid.UUID(id))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitees := make([]models.TenderInvitee, 0)
+	for rows.Next() {
+		var inviteeType string
+		var inviteeID uuid.UUID
+
+		err = rows.Scan(&inviteeType, &inviteeID)
+		if err != nil {
+			return nil, err
+		}
+
+		invitees = append(invitees, models.TenderInvitee{
+			Type: models.PrincipalType(inviteeType),
+			ID:   models.ID(inviteeID),
+		})
+	}
+
+	return invitees, rows.Err()
+}
+
+func (P *PGXTenderRepository) AddInvitees(ctx context.Context, id models.ID, invitees []models.TenderInvitee) error {
+	const query = `
+		INSERT INTO tender_invitee (tender_id, invitee_type, invitee_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`
+
+	transaction, err := P.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, invitee := range invitees {
+		_, err = transaction.Exec(ctx, query, uuid.UUID(id), string(invitee.Type), uuid.UUID(invitee.ID), now)
+		if err != nil {
+			_ = transaction.Rollback(ctx)
+			return err
+		}
+	}
+
+	return transaction.Commit(ctx)
+}
+
+func (P *PGXTenderRepository) RemoveInvitee(ctx context.Context, id models.ID, invitee models.TenderInvitee) error {
+	const query = `
+		DELETE FROM tender_invitee
+		WHERE tender_id = $1 AND invitee_type = $2 AND invitee_id = $3
+	`
+
+	tag, err := P.conn.Exec(ctx, query, uuid.UUID(id), string(invitee.Type), uuid.UUID(invitee.ID))
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s %s is not invited to tender with ID %s: %w", invitee.Type, invitee.ID, id, domain.ErrNotFound)
+	}
+
+	return nil
 }
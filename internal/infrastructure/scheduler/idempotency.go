@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"context"
+	"tenderSystem/internal/abstraction"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// IdempotencyKeyPurger periodically deletes the expired idempotency keys with their stored responses
+type IdempotencyKeyPurger struct {
+	idempotencyUseCase abstraction.IdempotencyUseCaseInterface
+	interval           time.Duration
+}
+
+// NewIdempotencyKeyPurger creates a new instance of IdempotencyKeyPurger
+func NewIdempotencyKeyPurger(idempotencyUseCase abstraction.IdempotencyUseCaseInterface, interval time.Duration) *IdempotencyKeyPurger {
+	return &IdempotencyKeyPurger{
+		idempotencyUseCase: idempotencyUseCase,
+		interval:           interval,
+	}
+}
+
+// Run purges expired keys right away and then every interval until ctx is done
+func (p *IdempotencyKeyPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.idempotencyUseCase.PurgeExpired(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			log.Errorf("error purging expired idempotency keys: %v", err)
+		}
+		if purged > 0 {
+			log.Infof("purged %d expired idempotency keys", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"tenderSystem/internal/abstraction"
+	"testing"
+	"time"
+)
+
+// countingIdempotencyUseCase counts the runs of PurgeExpired and stops the scheduler after the given number
+type countingIdempotencyUseCase struct {
+	abstraction.IdempotencyUseCaseInterface
+
+	calls atomic.Int32
+	stop  int32
+	err   error
+
+	cancel context.CancelFunc
+}
+
+func (c *countingIdempotencyUseCase) PurgeExpired(_ context.Context, _ time.Time) (int, error) {
+	if c.calls.Add(1) == c.stop {
+		c.cancel()
+	}
+
+	return 1, c.err
+}
+
+func TestIdempotencyKeyPurgerRunsUntilCanceled(t *testing.T) {
+	for name, purgeErr := range map[string]error{"purges": nil, "keeps running after errors": errors.New("database is down")} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			idempotencyUseCase := &countingIdempotencyUseCase{stop: 3, err: purgeErr, cancel: cancel}
+
+			done := make(chan struct{})
+			go func() {
+				NewIdempotencyKeyPurger(idempotencyUseCase, time.Millisecond).Run(ctx)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("purger did not stop after the context was canceled")
+			}
+
+			if calls := idempotencyUseCase.calls.Load(); calls != 3 {
+				t.Fatalf("expired keys purged %d times, want 3", calls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyKeyPurgerRunsRightAway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	idempotencyUseCase := &countingIdempotencyUseCase{stop: 1, cancel: cancel}
+
+	// the interval is never reached, the first run happens on start
+	NewIdempotencyKeyPurger(idempotencyUseCase, time.Hour).Run(ctx)
+
+	if calls := idempotencyUseCase.calls.Load(); calls != 1 {
+		t.Fatalf("expired keys purged %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"context"
+	"tenderSystem/internal/abstraction"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// TenderCloser periodically closes published tenders whose deadlines have passed
+type TenderCloser struct {
+	tenderUseCase abstraction.TenderUseCaseInterface
+	interval      time.Duration
+}
+
+// NewTenderCloser creates a new instance of TenderCloser
+func NewTenderCloser(tenderUseCase abstraction.TenderUseCaseInterface, interval time.Duration) *TenderCloser {
+	return &TenderCloser{
+		tenderUseCase: tenderUseCase,
+		interval:      interval,
+	}
+}
+
+// Run closes expired tenders right away and then every interval until ctx is done
+func (t *TenderCloser) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		closed, err := t.tenderUseCase.CloseExpired(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			log.Errorf("error closing expired tenders: %v", err)
+		}
+		if closed > 0 {
+			log.Infof("closed %d expired tenders", closed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"tenderSystem/internal/abstraction"
+	"testing"
+	"time"
+)
+
+// countingTenderUseCase counts the runs of CloseExpired and stops the scheduler after the given number
+type countingTenderUseCase struct {
+	abstraction.TenderUseCaseInterface
+
+	calls atomic.Int32
+	stop  int32
+	err   error
+
+	cancel context.CancelFunc
+}
+
+func (c *countingTenderUseCase) CloseExpired(_ context.Context, _ time.Time) (int, error) {
+	if c.calls.Add(1) == c.stop {
+		c.cancel()
+	}
+
+	return 1, c.err
+}
+
+func TestTenderCloserRunsUntilCanceled(t *testing.T) {
+	for name, closeErr := range map[string]error{"closes": nil, "keeps running after errors": errors.New("database is down")} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			tenderUseCase := &countingTenderUseCase{stop: 3, err: closeErr, cancel: cancel}
+
+			done := make(chan struct{})
+			go func() {
+				NewTenderCloser(tenderUseCase, time.Millisecond).Run(ctx)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("closer did not stop after the context was canceled")
+			}
+
+			if calls := tenderUseCase.calls.Load(); calls != 3 {
+				t.Fatalf("expired tenders closed %d times, want 3", calls)
+			}
+		})
+	}
+}
+
+func TestTenderCloserRunsRightAway(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tenderUseCase := &countingTenderUseCase{stop: 1, cancel: cancel}
+
+	// the interval is never reached, the first run happens on start
+	NewTenderCloser(tenderUseCase, time.Hour).Run(ctx)
+
+	if calls := tenderUseCase.calls.Load(); calls != 1 {
+		t.Fatalf("expired tenders closed %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+type apiKeyResponse struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  *string  `json:"expiresAt"`
+	LastUsedAt *string  `json:"lastUsedAt"`
+	RevokedAt  *string  `json:"revokedAt"`
+	CreatedAt  string   `json:"createdAt"`
+}
+
+type createdAPIKeyResponse struct {
+	apiKeyResponse
+	Key string `json:"key"`
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+func modelToAPIKeyResponse(a *models.APIKey) apiKeyResponse {
+	scopes := make([]string, 0, len(a.Scopes))
+	for _, scope := range a.Scopes {
+		scopes = append(scopes, scope.String())
+	}
+
+	return apiKeyResponse{
+		ID:         a.ID.String(),
+		Name:       a.Name,
+		Prefix:     a.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  formatOptionalTime(a.ExpiresAt),
+		LastUsedAt: formatOptionalTime(a.LastUsedAt),
+		RevokedAt:  formatOptionalTime(a.RevokedAt),
+		CreatedAt:  a.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type APIKeyHandler struct {
+	apiKeyUseCase abstraction.APIKeyUseCaseInterface
+}
+
+func NewAPIKeyHandler(apiKeyUseCase abstraction.APIKeyUseCaseInterface) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+	}
+}
+
+func (a *APIKeyHandler) Register(g *echo.Group) {
+	g = g.Group("/organizations/:organizationID/api-keys")
+	g.POST("", a.CreateAPIKey)
+	g.GET("", a.GetAPIKeys)
+	g.DELETE("/:id", a.RevokeAPIKey)
+}
+
+func (a *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	var body struct {
+		Name      string     `json:"name"`
+		Scopes    []string   `json:"scopes"`
+		ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	}
+
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+
+	var input dto.CreateAPIKeyDTO
+	{
+		var err error
+
+		input.OrganizationID, err = models.ParseID(c.Param("organizationID"))
+		if err != nil {
+			return err
+		}
+
+		input.Name = body.Name
+		input.ExpiresAt = body.ExpiresAt
+
+		for _, strScope := range body.Scopes {
+			scope, err := models.NewAPIKeyScope(strScope)
+			if err != nil {
+				return err
+			}
+			input.Scopes = append(input.Scopes, scope)
+		}
+	}
+
+	apiKey, key, err := a.apiKeyUseCase.Create(c.Request().Context(), &input)
+	if err != nil {
+		return err
+	}
+
+	setNoStore(c)
+	return c.JSON(http.StatusCreated, createdAPIKeyResponse{
+		apiKeyResponse: modelToAPIKeyResponse(&apiKey),
+		Key:            key,
+	})
+}
+
+func (a *APIKeyHandler) GetAPIKeys(c echo.Context) error {
+	organizationID, err := models.ParseID(c.Param("organizationID"))
+	if err != nil {
+		return err
+	}
+
+	apiKeys, err := a.apiKeyUseCase.GetByOrganizationID(c.Request().Context(), organizationID)
+	if err != nil {
+		return err
+	}
+
+	response := make([]apiKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		response = append(response, modelToAPIKeyResponse(&apiKey))
+	}
+
+	return c.JSON(200, response)
+}
+
+func (a *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	type query struct {
+		OrganizationID string `param:"organizationID"`
+		ID             string `param:"id"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	organizationID, err := models.ParseID(q.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	id, err := models.ParseID(q.ID)
+	if err != nil {
+		return err
+	}
+
+	err = a.apiKeyUseCase.Revoke(c.Request().Context(), organizationID, id)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// attachmentFormField is the multipart form field holding the uploaded file
+const attachmentFormField = "file"
+
+type attachmentResponse struct {
+	ID          string  `json:"id"`
+	FileName    string  `json:"fileName"`
+	ContentType string  `json:"contentType"`
+	Size        int64   `json:"size"`
+	SHA256      string  `json:"sha256"`
+	UploadedBy  *string `json:"uploadedBy,omitempty"`
+	CreatedAt   string  `json:"createdAt"`
+}
+
+func modelToAttachmentResponse(a *models.Attachment) attachmentResponse {
+	response := attachmentResponse{
+		ID:          a.ID.String(),
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.SHA256,
+		CreatedAt:   a.CreatedAt.Format(time.RFC3339),
+	}
+
+	if a.UploadedBy != nil {
+		uploadedBy := a.UploadedBy.String()
+		response.UploadedBy = &uploadedBy
+	}
+
+	return response
+}
+
+func modelsToAttachmentResponse(attachments []models.Attachment) []attachmentResponse {
+	response := make([]attachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		response = append(response, modelToAttachmentResponse(&attachment))
+	}
+
+	return response
+}
+
+// uploadFromForm opens the uploaded file of the multipart request, the caller closes it
+func uploadFromForm(c echo.Context) (*dto.UploadAttachmentDTO, io.Closer, error) {
+	fileHeader, err := c.FormFile(attachmentFormField)
+	if err != nil {
+		return nil, nil, fmt.Errorf("multipart form field %q with a file is required: %w", attachmentFormField, domain.ErrInvalidArgument)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &dto.UploadAttachmentDTO{
+		FileName:    fileHeader.Filename,
+		ContentType: fileHeader.Header.Get(echo.HeaderContentType),
+		Size:        fileHeader.Size,
+		Content:     file,
+	}, file, nil
+}
+
+// streamAttachment writes the content as a download with its checksum in the X-Checksum-SHA256 header
+func streamAttachment(c echo.Context, attachment *models.Attachment, content io.ReadCloser) error {
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+	header.Set("X-Checksum-SHA256", attachment.SHA256)
+
+	return c.Stream(200, attachment.ContentType, content)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestUploadFromForm(t *testing.T) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile(attachmentFormField, "spec.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write([]byte("specification")); err != nil {
+		t.Fatal(err)
+	}
+	if err := form.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/api/tenders/1/attachments", &body)
+	request.Header.Set(echo.HeaderContentType, form.FormDataContentType())
+	c := echo.New().NewContext(request, httptest.NewRecorder())
+
+	upload, file, err := uploadFromForm(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(upload.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upload.FileName != "spec.txt" || upload.Size != int64(len("specification")) || string(content) != "specification" {
+		t.Fatalf("got %q of %d bytes named %q", content, upload.Size, upload.FileName)
+	}
+}
+
+func TestUploadFromFormRequiresFile(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "/api/tenders/1/attachments", strings.NewReader("file=spec.txt"))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	c := echo.New().NewContext(request, httptest.NewRecorder())
+
+	_, _, err := uploadFromForm(c)
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v, want invalid argument", err)
+	}
+}
+
+func TestStreamAttachment(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), recorder)
+
+	attachment := models.Attachment{FileName: "смета.txt", ContentType: "text/plain", Size: 4, SHA256: "abc"}
+	err := streamAttachment(c, &attachment, io.NopCloser(strings.NewReader("spec")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := recorder.Header()
+	if got := header.Get(echo.HeaderContentDisposition); got != `attachment; filename*=utf-8''%D1%81%D0%BC%D0%B5%D1%82%D0%B0.txt` {
+		t.Errorf("got Content-Disposition %s", got)
+	}
+	if got := header.Get("X-Checksum-SHA256"); got != "abc" {
+		t.Errorf("got checksum %s, want abc", got)
+	}
+	if got := header.Get(echo.HeaderContentType); got != "text/plain" {
+		t.Errorf("got content type %s, want text/plain", got)
+	}
+	if recorder.Body.String() != "spec" {
+		t.Errorf("got body %q, want the content", recorder.Body.String())
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+type tokenPairResponse struct {
+	AccessToken           string `json:"accessToken"`
+	AccessTokenExpiresAt  string `json:"accessTokenExpiresAt"`
+	RefreshToken          string `json:"refreshToken"`
+	RefreshTokenExpiresAt string `json:"refreshTokenExpiresAt"`
+	TokenType             string `json:"tokenType"`
+}
+
+func modelToTokenPairResponse(t *models.TokenPair) tokenPairResponse {
+	return tokenPairResponse{
+		AccessToken:           t.AccessToken,
+		AccessTokenExpiresAt:  t.AccessTokenExpiresAt.Format(time.RFC3339),
+		RefreshToken:          t.RefreshToken,
+		RefreshTokenExpiresAt: t.RefreshTokenExpiresAt.Format(time.RFC3339),
+		TokenType:             "Bearer",
+	}
+}
+
+// setNoStore marks a response carrying credentials, it is neither cached nor kept for idempotent replays
+func setNoStore(c echo.Context) {
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+}
+
+type AuthHandler struct {
+	authUseCase abstraction.AuthUseCaseInterface
+}
+
+func NewAuthHandler(authUseCase abstraction.AuthUseCaseInterface) *AuthHandler {
+	return &AuthHandler{
+		authUseCase: authUseCase,
+	}
+}
+
+// Register registers login, refresh and logout on public and password change on protected
+func (a *AuthHandler) Register(public *echo.Group, protected *echo.Group) {
+	public = public.Group("/auth")
+	public.POST("/login", a.Login)
+	public.POST("/refresh", a.Refresh)
+	public.POST("/logout", a.Logout)
+
+	protected = protected.Group("/auth")
+	protected.PUT("/password", a.SetPassword)
+}
+
+func (a *AuthHandler) Login(c echo.Context) error {
+	var body struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+
+	tokens, err := a.authUseCase.Login(c.Request().Context(), body.Username, body.Password)
+	if err != nil {
+		var lockedErr *models.AccountLockedError
+		if errors.As(err, &lockedErr) {
+			c.Logger().Warnf("login to %s rejected: account is locked until %s", lockedErr.Username, lockedErr.LockedUntil.Format(time.RFC3339))
+		}
+		return err
+	}
+
+	setNoStore(c)
+	return c.JSON(200, modelToTokenPairResponse(&tokens))
+}
+
+func (a *AuthHandler) Refresh(c echo.Context) error {
+	var body struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+
+	tokens, err := a.authUseCase.Refresh(c.Request().Context(), body.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	setNoStore(c)
+	return c.JSON(200, modelToTokenPairResponse(&tokens))
+}
+
+func (a *AuthHandler) Logout(c echo.Context) error {
+	var body struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+
+	err := a.authUseCase.Logout(c.Request().Context(), body.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (a *AuthHandler) SetPassword(c echo.Context) error {
+	var body struct {
+		CurrentPassword string `json:"currentPassword"`
+		NewPassword     string `json:"newPassword"`
+	}
+
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+
+	err := a.authUseCase.SetPassword(c.Request().Context(), body.CurrentPassword, body.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
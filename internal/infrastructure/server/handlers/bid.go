@@ -2,19 +2,23 @@ package handlers
 
 import (
 	"github.com/labstack/echo/v4"
+	"strings"
 	"tenderSystem/internal/abstraction"
 	"tenderSystem/internal/domain/dto"
 	"tenderSystem/internal/domain/models"
+	"time"
 )
 
 type bidResponse struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Status     string `json:"status"`
-	AuthorType string `json:"authorType"`
-	AuthorID   string `json:"authorID"`
-	Version    int    `json:"version"`
-	CreatedAt  string `json:"createdAt"`
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	AuthorType string     `json:"authorType"`
+	AuthorID   string     `json:"authorID"`
+	LotID      string     `json:"lotId"`
+	Price      *moneyJSON `json:"price"`
+	Version    int        `json:"version"`
+	CreatedAt  string     `json:"createdAt"`
 }
 
 func modelToBidResponse(b *models.Bid) bidResponse {
@@ -24,6 +28,8 @@ func modelToBidResponse(b *models.Bid) bidResponse {
 		Status:     b.Status.String(),
 		AuthorType: b.AuthorType.String(),
 		AuthorID:   b.AuthorID.String(),
+		LotID:      b.LotID.String(),
+		Price:      moneyToJSON(b.Price),
 		Version:    b.Version,
 		CreatedAt:  b.CreatedAt.Format("2006-01-02T15:04:05"),
 	}
@@ -60,20 +66,29 @@ func (b *BidHandler) Register(g *echo.Group) {
 	g.GET("/:tenderID/list", b.GetBidsByTenderID)
 	g.GET("/:id/status", b.GetBidStatus)
 	g.PUT("/:id/status", b.ChangeBidStatus)
+	g.GET("/:id/transitions", b.GetBidTransitions)
 	g.PATCH("/:id/edit", b.EditBid)
 	g.PUT("/:id/submit_decision", b.SubmitDecision)
 	g.PUT("/:id/feedback", b.Feedback)
 	g.PUT("/:id/rollback/:version", b.RollbackBid)
+	g.GET("/:id/versions", b.GetBidVersions)
+	g.GET("/:id/versions/:version", b.GetBidVersion)
+	g.GET("/:id/diff", b.DiffBidVersions)
+	g.POST("/:id/attachments", b.UploadAttachment)
+	g.GET("/:id/attachments", b.GetAttachments)
+	g.GET("/:id/attachments/:attachmentID", b.DownloadAttachment)
 	g.GET("/:tenderID/reviews", b.GetReviews)
 }
 
 func (b *BidHandler) CreateBid(c echo.Context) error {
 	type request struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
-		TenderID    string `json:"tenderID"`
-		AuthorType  string `json:"authorType"`
-		AuthorID    string `json:"authorID"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		TenderID    string     `json:"tenderID"`
+		AuthorType  string     `json:"authorType"`
+		AuthorID    string     `json:"authorID"`
+		LotID       *string    `json:"lotId"`
+		Price       *moneyJSON `json:"price"`
 	}
 
 	var req request
@@ -102,6 +117,19 @@ func (b *BidHandler) CreateBid(c echo.Context) error {
 		if err != nil {
 			return err
 		}
+
+		if req.LotID != nil {
+			lotID, err := models.ParseID(*req.LotID)
+			if err != nil {
+				return err
+			}
+			input.LotID = &lotID
+		}
+
+		input.Price, err = moneyFromJSON(req.Price)
+		if err != nil {
+			return err
+		}
 	}
 
 	bid, err := b.bidUseCase.Create(c.Request().Context(), &input)
@@ -109,33 +137,91 @@ func (b *BidHandler) CreateBid(c echo.Context) error {
 		return err
 	}
 
+	setETag(c, bid.Revision)
 	return c.JSON(201, modelToBidResponse(&bid))
 }
 
-func (b *BidHandler) GetMyBids(c echo.Context) error {
-	type query struct {
-		Username string `query:"username"`
-		Limit    int    `query:"limit"`
-		Offset   int    `query:"offset"`
+// bidListQuery holds the filters and sorting shared by the bid lists
+type bidListQuery struct {
+	pageQuery
+	Sort           string     `query:"sort"`
+	LotID          string     `query:"lot_id"`
+	Status         []string   `query:"status"`
+	OrganizationID string     `query:"organization_id"`
+	CreatedFrom    *time.Time `query:"created_from"`
+	CreatedTo      *time.Time `query:"created_to"`
+	VersionMin     *int       `query:"version_min"`
+	VersionMax     *int       `query:"version_max"`
+}
+
+func (q *bidListQuery) options() ([]abstraction.GetBidsOptFunc, *abstraction.PaginationOptions, error) {
+	var options []abstraction.GetBidsOptFunc
+
+	paginationOptions, err := q.paginationOptions()
+	if err != nil {
+		return nil, nil, err
 	}
+	options = append(options, abstraction.WithBidsPaginationOptions(paginationOptions))
 
-	var q query
-	if err := c.Bind(&q); err != nil {
-		return err
+	if q.Sort != "" {
+		sortOrder, err := abstraction.NewBidSortOrder(strings.ToLower(q.Sort))
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, abstraction.WithBidsSortOrder(sortOrder))
 	}
 
-	var options []abstraction.PaginationOptFunc
-	{
-		if q.Limit != 0 {
-			options = append(options, abstraction.WithLimit(q.Limit))
+	if q.LotID != "" {
+		lotID, err := models.ParseID(q.LotID)
+		if err != nil {
+			return nil, nil, err
 		}
+		options = append(options, abstraction.WithBidsLotID(lotID))
+	}
 
-		if q.Offset != 0 {
-			options = append(options, abstraction.WithOffset(q.Offset))
+	for _, strStatus := range q.Status {
+		status, err := models.NewBidStatus(strings.ToLower(strStatus))
+		if err != nil {
+			return nil, nil, err
 		}
+		options = append(options, abstraction.WithBidStatus(status))
 	}
 
-	bids, err := b.bidUseCase.GetMy(c.Request().Context(), q.Username, options...)
+	if q.OrganizationID != "" {
+		organizationID, err := models.ParseID(q.OrganizationID)
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, abstraction.WithBidsOrganizationID(organizationID))
+	}
+
+	createdAt, err := abstraction.NewTimeRange(q.CreatedFrom, q.CreatedTo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version, err := abstraction.NewVersionRange(q.VersionMin, q.VersionMax)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	options = append(options, abstraction.WithBidsCreatedAt(createdAt), abstraction.WithBidsVersion(version))
+
+	return options, paginationOptions, nil
+}
+
+func (b *BidHandler) GetMyBids(c echo.Context) error {
+	var q bidListQuery
+	if err := bindListQuery(c, &q); err != nil {
+		return err
+	}
+
+	options, paginationOptions, err := q.options()
+	if err != nil {
+		return err
+	}
+
+	bids, err := b.bidUseCase.GetMy(c.Request().Context(), options...)
 	if err != nil {
 		return err
 	}
@@ -145,31 +231,24 @@ func (b *BidHandler) GetMyBids(c echo.Context) error {
 		response = append(response, modelToBidResponse(&bid))
 	}
 
-	return c.JSON(200, response)
+	return respondPage(c, paginationOptions, response)
 }
 
 func (b *BidHandler) GetBidsByTenderID(c echo.Context) error {
 	type query struct {
+		bidListQuery
 		TenderID string `param:"tenderID"`
-		Username string `query:"username"`
-		Limit    int    `query:"limit"`
-		Offset   int    `query:"offset"`
+		Query    string `query:"q"`
 	}
 
 	var q query
-	if err := c.Bind(&q); err != nil {
+	if err := bindListQuery(c, &q); err != nil {
 		return err
 	}
 
-	var options []abstraction.PaginationOptFunc
-	{
-		if q.Limit != 0 {
-			options = append(options, abstraction.WithLimit(q.Limit))
-		}
-
-		if q.Offset != 0 {
-			options = append(options, abstraction.WithOffset(q.Offset))
-		}
+	options, paginationOptions, err := q.options()
+	if err != nil {
+		return err
 	}
 
 	tenderID, err := models.ParseID(q.TenderID)
@@ -177,7 +256,29 @@ func (b *BidHandler) GetBidsByTenderID(c echo.Context) error {
 		return err
 	}
 
-	bids, err := b.bidUseCase.GetByTenderID(c.Request().Context(), tenderID, q.Username, options...)
+	if strings.TrimSpace(q.Query) != "" {
+		type searchResponse struct {
+			bidResponse
+			searchHitResponse
+		}
+
+		results, err := b.bidUseCase.SearchByTenderID(c.Request().Context(), tenderID, q.Query, options...)
+		if err != nil {
+			return err
+		}
+
+		response := make([]searchResponse, 0, len(results))
+		for _, result := range results {
+			response = append(response, searchResponse{
+				bidResponse:       modelToBidResponse(&result.Bid),
+				searchHitResponse: modelToSearchHitResponse(result.SearchHit),
+			})
+		}
+
+		return respondPage(c, paginationOptions, response)
+	}
+
+	bids, err := b.bidUseCase.GetByTenderID(c.Request().Context(), tenderID, options...)
 	if err != nil {
 		return err
 	}
@@ -187,13 +288,99 @@ func (b *BidHandler) GetBidsByTenderID(c echo.Context) error {
 		response = append(response, modelToBidResponse(&bid))
 	}
 
-	return c.JSON(200, response)
+	return respondPage(c, paginationOptions, response)
+}
+
+func (b *BidHandler) GetBidVersions(c echo.Context) error {
+	type query struct {
+		BidID string `param:"id"`
+		pageQuery
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	paginationOptions, err := q.paginationOptions()
+	if err != nil {
+		return err
+	}
+
+	bidID, err := models.ParseID(q.BidID)
+	if err != nil {
+		return err
+	}
+
+	bids, err := b.bidUseCase.GetVersions(c.Request().Context(), bidID, abstraction.WithPagination(*paginationOptions))
+	if err != nil {
+		return err
+	}
+
+	response := make([]bidVersionResponse, 0, len(bids))
+	for _, bid := range bids {
+		response = append(response, modelToBidVersionResponse(&bid))
+	}
+
+	return respondPage(c, paginationOptions, response)
+}
+
+func (b *BidHandler) GetBidVersion(c echo.Context) error {
+	type query struct {
+		BidID   string `param:"id"`
+		Version int    `param:"version"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	bidID, err := models.ParseID(q.BidID)
+	if err != nil {
+		return err
+	}
+
+	bid, err := b.bidUseCase.GetVersion(c.Request().Context(), bidID, q.Version)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToBidVersionResponse(&bid))
+}
+
+func (b *BidHandler) DiffBidVersions(c echo.Context) error {
+	type query struct {
+		BidID string `param:"id"`
+		diffQuery
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	from, to, err := q.versions()
+	if err != nil {
+		return err
+	}
+
+	bidID, err := models.ParseID(q.BidID)
+	if err != nil {
+		return err
+	}
+
+	changes, err := b.bidUseCase.Diff(c.Request().Context(), bidID, from, to)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelsToDiffResponse(from, to, changes))
 }
 
 func (b *BidHandler) GetBidStatus(c echo.Context) error {
 	type query struct {
-		Username string `query:"username"`
-		BidID    string `param:"id"`
+		BidID string `param:"id"`
 	}
 
 	var q query
@@ -206,19 +393,50 @@ func (b *BidHandler) GetBidStatus(c echo.Context) error {
 		return err
 	}
 
-	status, err := b.bidUseCase.GetStatus(c.Request().Context(), bidID, q.Username)
+	status, revision, err := b.bidUseCase.GetStatus(c.Request().Context(), bidID)
 	if err != nil {
 		return err
 	}
 
+	setETag(c, revision)
 	return c.JSON(200, status)
 }
 
+func (b *BidHandler) GetBidTransitions(c echo.Context) error {
+	type query struct {
+		BidID string `param:"id"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	bidID, err := models.ParseID(q.BidID)
+	if err != nil {
+		return err
+	}
+
+	status, allowed, err := b.bidUseCase.GetAllowedStatuses(c.Request().Context(), bidID)
+	if err != nil {
+		return err
+	}
+
+	response := transitionsResponse{
+		Status:  status.String(),
+		Allowed: make([]string, 0, len(allowed)),
+	}
+	for _, next := range allowed {
+		response.Allowed = append(response.Allowed, next.String())
+	}
+
+	return c.JSON(200, response)
+}
+
 func (b *BidHandler) ChangeBidStatus(c echo.Context) error {
 	type query struct {
-		Username string `query:"username"`
-		BidID    string `param:"id"`
-		Status   string `query:"status"`
+		BidID  string `param:"id"`
+		Status string `query:"status"`
 	}
 
 	var q query
@@ -231,32 +449,37 @@ func (b *BidHandler) ChangeBidStatus(c echo.Context) error {
 		return err
 	}
 
-	status, err := models.NewBidStatus(q.Status)
+	status, err := models.NewBidStatus(strings.ToLower(q.Status))
+	if err != nil {
+		return err
+	}
+
+	expectedRevisions, err := ifMatchRevisions(c)
 	if err != nil {
 		return err
 	}
 
-	bid, err := b.bidUseCase.SetStatus(c.Request().Context(), bidID, q.Username, status)
+	bid, err := b.bidUseCase.SetStatus(c.Request().Context(), bidID, status, expectedRevisions)
 	if err != nil {
 		return err
 	}
 
+	setETag(c, bid.Revision)
 	return c.JSON(200, modelToBidResponse(&bid))
 }
 
 func (b *BidHandler) EditBid(c echo.Context) error {
 	var body struct {
-		Name        *string `json:"name,omitempty"`
-		Description *string `json:"description,omitempty"`
+		Name        *string    `json:"name,omitempty"`
+		Description *string    `json:"description,omitempty"`
+		Price       *moneyJSON `json:"price,omitempty"`
 	}
 
 	var q struct {
-		Username string
-		BidID    string
+		BidID string
 	}
 	{
 		q.BidID = c.Param("id")
-		q.Username = c.QueryParam("username")
 	}
 
 	if err := c.Bind(&body); err != nil {
@@ -272,19 +495,29 @@ func (b *BidHandler) EditBid(c echo.Context) error {
 	{
 		input.Name = body.Name
 		input.Description = body.Description
+
+		input.Price, err = moneyFromJSON(body.Price)
+		if err != nil {
+			return err
+		}
 	}
 
-	bid, err := b.bidUseCase.Update(c.Request().Context(), bidID, q.Username, &input)
+	expectedRevisions, err := ifMatchRevisions(c)
 	if err != nil {
 		return err
 	}
 
+	bid, err := b.bidUseCase.Update(c.Request().Context(), bidID, &input, expectedRevisions)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, bid.Revision)
 	return c.JSON(200, modelToBidResponse(&bid))
 }
 
 func (b *BidHandler) SubmitDecision(c echo.Context) error {
 	type query struct {
-		Username string `query:"username"`
 		BidID    string `param:"id"`
 		Decision string `query:"decision"`
 	}
@@ -304,17 +537,17 @@ func (b *BidHandler) SubmitDecision(c echo.Context) error {
 		return err
 	}
 
-	bid, err := b.bidUseCase.SubmitDecision(c.Request().Context(), bidID, q.Username, decision)
+	bid, err := b.bidUseCase.SubmitDecision(c.Request().Context(), bidID, decision)
 	if err != nil {
 		return err
 	}
 
+	setETag(c, bid.Revision)
 	return c.JSON(200, modelToBidResponse(&bid))
 }
 
 func (b *BidHandler) Feedback(c echo.Context) error {
 	type query struct {
-		Username    string `query:"username"`
 		BidID       string `param:"id"`
 		BidFeedback string `query:"bidFeedback"`
 	}
@@ -329,19 +562,19 @@ func (b *BidHandler) Feedback(c echo.Context) error {
 		return err
 	}
 
-	bid, err := b.bidUseCase.LeaveFeedback(c.Request().Context(), bidID, q.Username, q.BidFeedback)
+	bid, err := b.bidUseCase.LeaveFeedback(c.Request().Context(), bidID, q.BidFeedback)
 	if err != nil {
 		return err
 	}
 
+	setETag(c, bid.Revision)
 	return c.JSON(200, modelToBidResponse(&bid))
 }
 
 func (b *BidHandler) RollbackBid(c echo.Context) error {
 	type query struct {
-		Username string `query:"username"`
-		BidID    string `param:"id"`
-		Version  int    `param:"version"`
+		BidID   string `param:"id"`
+		Version int    `param:"version"`
 	}
 
 	var q query
@@ -354,22 +587,25 @@ func (b *BidHandler) RollbackBid(c echo.Context) error {
 		return err
 	}
 
-	bid, err := b.bidUseCase.Rollback(c.Request().Context(), bidID, q.Username, q.Version)
+	expectedRevisions, err := ifMatchRevisions(c)
+	if err != nil {
+		return err
+	}
+
+	bid, err := b.bidUseCase.Rollback(c.Request().Context(), bidID, q.Version, expectedRevisions)
 	if err != nil {
 		return err
 	}
 
+	setETag(c, bid.Revision)
 	return c.JSON(200, modelToBidResponse(&bid))
 }
 
 func (b *BidHandler) GetReviews(c echo.Context) error {
 	type query struct {
-		Username          string `query:"username"`
-		BidID             string `param:"tenderID"`
-		RequesterUsername string `query:"requesterUsername"`
-		AuthorUsername    string `query:"authorUsername"`
-		Limit             int    `query:"limit"`
-		Offset            int    `query:"offset"`
+		BidID          string `param:"tenderID"`
+		AuthorUsername string `query:"authorUsername"`
+		pageQuery
 	}
 
 	var q query
@@ -377,15 +613,9 @@ func (b *BidHandler) GetReviews(c echo.Context) error {
 		return err
 	}
 
-	var options []abstraction.PaginationOptFunc
-	{
-		if q.Limit != 0 {
-			options = append(options, abstraction.WithLimit(q.Limit))
-		}
-
-		if q.Offset != 0 {
-			options = append(options, abstraction.WithOffset(q.Offset))
-		}
+	paginationOptions, err := q.paginationOptions()
+	if err != nil {
+		return err
 	}
 
 	bidID, err := models.ParseID(q.BidID)
@@ -393,7 +623,7 @@ func (b *BidHandler) GetReviews(c echo.Context) error {
 		return err
 	}
 
-	bidFeedbacks, err := b.bidUseCase.GetAuthorsFeedback(c.Request().Context(), bidID, q.RequesterUsername, q.AuthorUsername, options...)
+	bidFeedbacks, err := b.bidUseCase.GetAuthorsFeedback(c.Request().Context(), bidID, q.AuthorUsername, abstraction.WithPagination(*paginationOptions))
 	if err != nil {
 		return err
 	}
@@ -403,5 +633,87 @@ func (b *BidHandler) GetReviews(c echo.Context) error {
 		response = append(response, modelToBidFeedbackResponse(&feedback))
 	}
 
-	return c.JSON(200, response)
+	return respondPage(c, paginationOptions, response)
+}
+
+func (b *BidHandler) UploadAttachment(c echo.Context) error {
+	type query struct {
+		BidID string `param:"id"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	bidID, err := models.ParseID(q.BidID)
+	if err != nil {
+		return err
+	}
+
+	upload, file, err := uploadFromForm(c)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	attachment, err := b.bidUseCase.UploadAttachment(c.Request().Context(), bidID, upload)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToAttachmentResponse(&attachment))
+}
+
+func (b *BidHandler) GetAttachments(c echo.Context) error {
+	type query struct {
+		BidID   string `param:"id"`
+		Version *int   `query:"version"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	bidID, err := models.ParseID(q.BidID)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := b.bidUseCase.GetAttachments(c.Request().Context(), bidID, q.Version)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelsToAttachmentResponse(attachments))
+}
+
+func (b *BidHandler) DownloadAttachment(c echo.Context) error {
+	type query struct {
+		BidID        string `param:"id"`
+		AttachmentID string `param:"attachmentID"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	bidID, err := models.ParseID(q.BidID)
+	if err != nil {
+		return err
+	}
+
+	attachmentID, err := models.ParseID(q.AttachmentID)
+	if err != nil {
+		return err
+	}
+
+	attachment, content, err := b.bidUseCase.DownloadAttachment(c.Request().Context(), bidID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	return streamAttachment(c, &attachment, content)
 }
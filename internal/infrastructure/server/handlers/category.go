@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type categoryResponse struct {
+	Code       string  `json:"code"`
+	ParentCode *string `json:"parentCode,omitempty"`
+	Name       string  `json:"name"`
+}
+
+func modelToCategoryResponse(c *models.Category) categoryResponse {
+	response := categoryResponse{
+		Code: c.Code.String(),
+		Name: c.Name,
+	}
+
+	if c.ParentCode != nil {
+		parentCode := c.ParentCode.String()
+		response.ParentCode = &parentCode
+	}
+
+	return response
+}
+
+func modelsToCategoryResponse(categories []models.Category) []categoryResponse {
+	response := make([]categoryResponse, 0, len(categories))
+	for _, category := range categories {
+		response = append(response, modelToCategoryResponse(&category))
+	}
+
+	return response
+}
+
+type CategoryHandler struct {
+	categoryUseCase abstraction.CategoryUseCaseInterface
+}
+
+func NewCategoryHandler(categoryUseCase abstraction.CategoryUseCaseInterface) *CategoryHandler {
+	return &CategoryHandler{
+		categoryUseCase: categoryUseCase,
+	}
+}
+
+func (h *CategoryHandler) Register(g *echo.Group) {
+	g = g.Group("/categories")
+	g.GET("", h.GetCategories)
+	g.GET("/:code", h.GetCategory)
+}
+
+// GetCategories returns the children of the parent category or the root categories
+func (h *CategoryHandler) GetCategories(c echo.Context) error {
+	type query struct {
+		Parent string `query:"parent"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	var parentCode *models.CategoryCode
+	if q.Parent != "" {
+		code, err := models.NewCategoryCode(q.Parent)
+		if err != nil {
+			return err
+		}
+		parentCode = &code
+	}
+
+	categories, err := h.categoryUseCase.GetChildren(c.Request().Context(), parentCode)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelsToCategoryResponse(categories))
+}
+
+// GetCategory returns the category with its path from the root and its children
+func (h *CategoryHandler) GetCategory(c echo.Context) error {
+	type query struct {
+		Code string `param:"code"`
+	}
+
+	type response struct {
+		categoryResponse
+		Path     []categoryResponse `json:"path"`
+		Children []categoryResponse `json:"children"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	code, err := models.NewCategoryCode(q.Code)
+	if err != nil {
+		return err
+	}
+
+	category, path, err := h.categoryUseCase.Get(c.Request().Context(), code)
+	if err != nil {
+		return err
+	}
+
+	children, err := h.categoryUseCase.GetChildren(c.Request().Context(), &code)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, response{
+		categoryResponse: modelToCategoryResponse(&category),
+		Path:             modelsToCategoryResponse(path),
+		Children:         modelsToCategoryResponse(children),
+	})
+}
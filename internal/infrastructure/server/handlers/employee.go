@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"net/http"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+type employeeResponse struct {
+	ID            string  `json:"id"`
+	Username      string  `json:"username"`
+	FirstName     string  `json:"firstName"`
+	LastName      string  `json:"lastName"`
+	CreatedAt     string  `json:"createdAt"`
+	DeactivatedAt *string `json:"deactivatedAt,omitempty"`
+}
+
+func modelToEmployeeResponse(e *models.Employee) employeeResponse {
+	return employeeResponse{
+		ID:            e.ID.String(),
+		Username:      e.Username,
+		FirstName:     e.FirstName,
+		LastName:      e.LastName,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+		DeactivatedAt: formatOptionalTime(e.DeactivatedAt),
+	}
+}
+
+type membershipResponse struct {
+	OrganizationID   string `json:"organizationId"`
+	OrganizationName string `json:"organizationName"`
+	Role             string `json:"role"`
+}
+
+type invitationResponse struct {
+	ID             string `json:"id"`
+	OrganizationID string `json:"organizationId"`
+	Role           string `json:"role"`
+	Token          string `json:"token"`
+	ExpiresAt      string `json:"expiresAt"`
+}
+
+type EmployeeHandler struct {
+	employeeUseCase abstraction.EmployeeUseCaseInterface
+}
+
+func NewEmployeeHandler(employeeUseCase abstraction.EmployeeUseCaseInterface) *EmployeeHandler {
+	return &EmployeeHandler{
+		employeeUseCase: employeeUseCase,
+	}
+}
+
+// Register registers invitation redemption on public and employee management on protected
+func (e *EmployeeHandler) Register(public *echo.Group, protected *echo.Group) {
+	public.POST("/invitations/redeem", e.RedeemInvitation)
+
+	protected.POST("/organizations/:organizationID/invitations", e.CreateInvitation)
+
+	protected = protected.Group("/employees")
+	protected.POST("", e.CreateEmployee)
+	protected.GET("/me/organizations", e.GetMyOrganizations)
+	protected.GET("/:id", e.GetEmployee)
+	protected.PATCH("/:id", e.EditEmployee)
+	protected.POST("/:id/deactivate", e.DeactivateEmployee)
+}
+
+func (e *EmployeeHandler) CreateEmployee(c echo.Context) error {
+	type body struct {
+		Username  string `json:"username"`
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+		Password  string `json:"password"`
+		Role      string `json:"role"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return err
+	}
+
+	var input dto.CreateEmployeeDTO
+	{
+		input.Username = b.Username
+		input.FirstName = b.FirstName
+		input.LastName = b.LastName
+		input.Password = b.Password
+
+		input.Role = models.OrganizationRoleViewer
+		if b.Role != "" {
+			var err error
+			input.Role, err = models.NewOrganizationRole(b.Role)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	employee, err := e.employeeUseCase.Create(c.Request().Context(), &input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToEmployeeResponse(&employee))
+}
+
+func (e *EmployeeHandler) GetMyOrganizations(c echo.Context) error {
+	memberships, err := e.employeeUseCase.GetMyOrganizations(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	response := make([]membershipResponse, 0, len(memberships))
+	for _, member := range memberships {
+		response = append(response, membershipResponse{
+			OrganizationID:   member.Organization.ID.String(),
+			OrganizationName: member.Organization.Name,
+			Role:             member.Role.String(),
+		})
+	}
+
+	return c.JSON(200, response)
+}
+
+func (e *EmployeeHandler) GetEmployee(c echo.Context) error {
+	id, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	employee, err := e.employeeUseCase.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToEmployeeResponse(&employee))
+}
+
+func (e *EmployeeHandler) EditEmployee(c echo.Context) error {
+	type body struct {
+		ID        string  `param:"id"`
+		FirstName *string `json:"firstName"`
+		LastName  *string `json:"lastName"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return err
+	}
+
+	id, err := models.ParseID(b.ID)
+	if err != nil {
+		return err
+	}
+
+	input := dto.UpdateEmployeeDTO{
+		FirstName: b.FirstName,
+		LastName:  b.LastName,
+	}
+
+	employee, err := e.employeeUseCase.Update(c.Request().Context(), id, &input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToEmployeeResponse(&employee))
+}
+
+func (e *EmployeeHandler) DeactivateEmployee(c echo.Context) error {
+	id, err := models.ParseID(c.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	err = e.employeeUseCase.Deactivate(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (e *EmployeeHandler) CreateInvitation(c echo.Context) error {
+	type body struct {
+		OrganizationID string `param:"organizationID"`
+		Role           string `json:"role"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return err
+	}
+
+	var input dto.CreateInvitationDTO
+	{
+		var err error
+		input.OrganizationID, err = models.ParseID(b.OrganizationID)
+		if err != nil {
+			return err
+		}
+
+		input.Role = models.OrganizationRoleViewer
+		if b.Role != "" {
+			input.Role, err = models.NewOrganizationRole(b.Role)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	invitation, token, err := e.employeeUseCase.CreateInvitation(c.Request().Context(), &input)
+	if err != nil {
+		return err
+	}
+
+	setNoStore(c)
+	return c.JSON(200, invitationResponse{
+		ID:             invitation.ID.String(),
+		OrganizationID: invitation.OrganizationID.String(),
+		Role:           invitation.Role.String(),
+		Token:          token,
+		ExpiresAt:      invitation.ExpiresAt.Format(time.RFC3339),
+	})
+}
+
+func (e *EmployeeHandler) RedeemInvitation(c echo.Context) error {
+	var body struct {
+		Token     string `json:"token"`
+		Username  string `json:"username"`
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+		Password  string `json:"password"`
+	}
+
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+
+	input := dto.RedeemInvitationDTO{
+		Token:     body.Token,
+		Username:  body.Username,
+		FirstName: body.FirstName,
+		LastName:  body.LastName,
+		Password:  body.Password,
+	}
+
+	employee, err := e.employeeUseCase.RedeemInvitation(c.Request().Context(), &input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToEmployeeResponse(&employee))
+}
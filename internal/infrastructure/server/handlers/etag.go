@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tenderSystem/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// setETag tags a tender or bid response with its current revision, so the caller can send
+// it back in If-Match to change the state it has seen
+func setETag(c echo.Context, revision int) {
+	c.Response().Header().Set("ETag", strconv.Quote(strconv.Itoa(revision)))
+}
+
+// ifMatchRevisions returns the revisions listed in the If-Match header, nil when the header is
+// absent or "*" (RFC 9110, 13.1.1). If-Match compares tags strongly, so weak tags and tags that are
+// not revisions never match: a header listing only those yields an empty list that matches nothing.
+func ifMatchRevisions(c echo.Context) ([]int, error) {
+	values := c.Request().Header.Values("If-Match")
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	revisions := make([]int, 0)
+	for _, value := range values {
+		for _, member := range strings.Split(value, ",") {
+			member = strings.TrimSpace(member)
+			if member == "" {
+				continue
+			}
+
+			if member == "*" {
+				return nil, nil
+			}
+
+			weak := strings.HasPrefix(member, "W/")
+			tag, err := parseEntityTag(strings.TrimPrefix(member, "W/"))
+			if err != nil {
+				return nil, err
+			}
+
+			if weak {
+				continue
+			}
+
+			revision, err := strconv.Atoi(tag)
+			if err != nil {
+				continue
+			}
+
+			revisions = append(revisions, revision)
+		}
+	}
+
+	return revisions, nil
+}
+
+// parseEntityTag returns the opaque tag of a quoted entity tag
+func parseEntityTag(tag string) (string, error) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' || strings.Contains(tag[1:len(tag)-1], `"`) {
+		return "", fmt.Errorf("If-Match must hold \"*\" or a list of quoted entity tags: %w", domain.ErrInvalidArgument)
+	}
+
+	return tag[1 : len(tag)-1], nil
+}
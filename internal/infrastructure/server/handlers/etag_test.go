@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestIfMatchRevisions(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		want    []int
+		wantErr error
+	}{
+		{name: "absent", want: nil},
+		{name: "any", headers: []string{"*"}, want: nil},
+		{name: "single", headers: []string{`"4"`}, want: []int{4}},
+		{name: "list", headers: []string{`"4", "5"`}, want: []int{4, 5}},
+		{name: "repeated header", headers: []string{`"4"`, `"5"`}, want: []int{4, 5}},
+		{name: "empty members", headers: []string{`, "4",,`}, want: []int{4}},
+		{name: "weak never matches", headers: []string{`W/"4"`}, want: []int{}},
+		{name: "weak in list", headers: []string{`W/"4", "5"`}, want: []int{5}},
+		{name: "foreign tag never matches", headers: []string{`"abc"`}, want: []int{}},
+		{name: "unquoted", headers: []string{`4`}, wantErr: domain.ErrInvalidArgument},
+		{name: "unterminated", headers: []string{`"4`}, wantErr: domain.ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPut, "/api/tenders/1/status", nil)
+			for _, header := range tt.headers {
+				request.Header.Add("If-Match", header)
+			}
+			c := echo.New().NewContext(request, httptest.NewRecorder())
+
+			got, err := ifMatchRevisions(c)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if (got == nil) != (tt.want == nil) || !slices.Equal(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfMatchRevisionsPrecondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		current int
+		wantErr error
+	}{
+		{name: "current", header: `"7"`, current: 7},
+		{name: "current in list", header: `"6", "7"`, current: 7},
+		{name: "stale", header: `"6"`, current: 7, wantErr: domain.ErrPreconditionFailed},
+		{name: "weak", header: `W/"7"`, current: 7, wantErr: domain.ErrPreconditionFailed},
+		{name: "any", header: `*`, current: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPut, "/api/tenders/1/status", nil)
+			request.Header.Set("If-Match", tt.header)
+			c := echo.New().NewContext(request, httptest.NewRecorder())
+
+			revisions, err := ifMatchRevisions(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = models.CheckCurrentRevision(tt.current, revisions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetETag(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), recorder)
+
+	setETag(c, 12)
+
+	if got := recorder.Header().Get("ETag"); got != `"12"` {
+		t.Fatalf("got %s, want \"12\"", got)
+	}
+}
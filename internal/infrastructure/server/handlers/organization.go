@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type organizationResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+func modelToOrganizationResponse(o *models.Organization) organizationResponse {
+	return organizationResponse{
+		ID:          o.ID.String(),
+		Name:        o.Name,
+		Description: o.Description,
+		Type:        o.Type.String(),
+		CreatedAt:   o.CreatedAt.Format("2006-01-02T15:04:05"),
+		UpdatedAt:   o.UpdatedAt.Format("2006-01-02T15:04:05"),
+	}
+}
+
+type organizationMemberResponse struct {
+	EmployeeID string `json:"employeeId"`
+	Username   string `json:"username"`
+	FirstName  string `json:"firstName"`
+	LastName   string `json:"lastName"`
+	Role       string `json:"role"`
+}
+
+func modelToOrganizationMemberResponse(m *models.OrganizationMember) organizationMemberResponse {
+	return organizationMemberResponse{
+		EmployeeID: m.Employee.ID.String(),
+		Username:   m.Employee.Username,
+		FirstName:  m.Employee.FirstName,
+		LastName:   m.Employee.LastName,
+		Role:       m.Role.String(),
+	}
+}
+
+type OrganizationHandler struct {
+	organizationUseCase abstraction.OrganizationUseCaseInterface
+}
+
+func NewOrganizationHandler(organizationUseCase abstraction.OrganizationUseCaseInterface) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUseCase: organizationUseCase,
+	}
+}
+
+func (o *OrganizationHandler) Register(g *echo.Group) {
+	g = g.Group("/organizations")
+	g.GET("", o.GetOrganizations)
+	g.POST("", o.CreateOrganization)
+	g.GET("/:organizationID", o.GetOrganization)
+	g.PATCH("/:organizationID", o.EditOrganization)
+	g.GET("/:organizationID/members", o.GetMembers)
+	g.POST("/:organizationID/members", o.AddMember)
+	g.DELETE("/:organizationID/members/:employeeID", o.RemoveMember)
+	g.PUT("/:organizationID/members/:employeeID/role", o.GrantRole)
+	g.DELETE("/:organizationID/members/:employeeID/role", o.RevokeRole)
+}
+
+func (o *OrganizationHandler) GetOrganizations(c echo.Context) error {
+	var q pageQuery
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	paginationOptions, err := q.paginationOptions()
+	if err != nil {
+		return err
+	}
+
+	organizations, err := o.organizationUseCase.GetAll(c.Request().Context(), abstraction.WithPagination(*paginationOptions))
+	if err != nil {
+		return err
+	}
+
+	response := make([]organizationResponse, 0, len(organizations))
+	for _, organization := range organizations {
+		response = append(response, modelToOrganizationResponse(&organization))
+	}
+
+	return respondPage(c, paginationOptions, response)
+}
+
+func (o *OrganizationHandler) CreateOrganization(c echo.Context) error {
+	type body struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Type        string `json:"type"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return err
+	}
+
+	var input dto.CreateOrganizationDTO
+	{
+		input.Name = b.Name
+		input.Description = b.Description
+
+		var err error
+		input.Type, err = models.NewOrganizationType(strings.ToUpper(b.Type))
+		if err != nil {
+			return err
+		}
+	}
+
+	organization, err := o.organizationUseCase.Create(c.Request().Context(), &input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToOrganizationResponse(&organization))
+}
+
+func (o *OrganizationHandler) GetOrganization(c echo.Context) error {
+	organizationID, err := models.ParseID(c.Param("organizationID"))
+	if err != nil {
+		return err
+	}
+
+	organization, err := o.organizationUseCase.GetByID(c.Request().Context(), organizationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToOrganizationResponse(&organization))
+}
+
+func (o *OrganizationHandler) EditOrganization(c echo.Context) error {
+	type body struct {
+		OrganizationID string  `param:"organizationID"`
+		Name           *string `json:"name"`
+		Description    *string `json:"description"`
+		Type           *string `json:"type"`
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return err
+	}
+
+	organizationID, err := models.ParseID(b.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	var input dto.UpdateOrganizationDTO
+	{
+		input.Name = b.Name
+		input.Description = b.Description
+
+		if b.Type != nil {
+			organizationType, err := models.NewOrganizationType(strings.ToUpper(*b.Type))
+			if err != nil {
+				return err
+			}
+			input.Type = &organizationType
+		}
+	}
+
+	organization, err := o.organizationUseCase.Update(c.Request().Context(), organizationID, &input)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToOrganizationResponse(&organization))
+}
+
+func (o *OrganizationHandler) AddMember(c echo.Context) error {
+	type request struct {
+		OrganizationID string `param:"organizationID"`
+		EmployeeID     string `json:"employeeId"`
+		Role           string `json:"role"`
+	}
+
+	var req request
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	organizationID, err := models.ParseID(req.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	employeeID, err := models.ParseID(req.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	role := models.OrganizationRoleViewer
+	if req.Role != "" {
+		role, err = models.NewOrganizationRole(req.Role)
+		if err != nil {
+			return err
+		}
+	}
+
+	member, err := o.organizationUseCase.AddMember(c.Request().Context(), organizationID, employeeID, role)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToOrganizationMemberResponse(&member))
+}
+
+func (o *OrganizationHandler) RemoveMember(c echo.Context) error {
+	type query struct {
+		OrganizationID string `param:"organizationID"`
+		EmployeeID     string `param:"employeeID"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	organizationID, err := models.ParseID(q.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	employeeID, err := models.ParseID(q.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	err = o.organizationUseCase.RemoveMember(c.Request().Context(), organizationID, employeeID)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (o *OrganizationHandler) GetMembers(c echo.Context) error {
+	organizationID, err := models.ParseID(c.Param("organizationID"))
+	if err != nil {
+		return err
+	}
+
+	members, err := o.organizationUseCase.GetMembers(c.Request().Context(), organizationID)
+	if err != nil {
+		return err
+	}
+
+	response := make([]organizationMemberResponse, 0, len(members))
+	for _, member := range members {
+		response = append(response, modelToOrganizationMemberResponse(&member))
+	}
+
+	return c.JSON(200, response)
+}
+
+func (o *OrganizationHandler) GrantRole(c echo.Context) error {
+	type request struct {
+		OrganizationID string `param:"organizationID"`
+		EmployeeID     string `param:"employeeID"`
+		Role           string `json:"role"`
+	}
+
+	var req request
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	organizationID, err := models.ParseID(req.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	employeeID, err := models.ParseID(req.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	role, err := models.NewOrganizationRole(req.Role)
+	if err != nil {
+		return err
+	}
+
+	member, err := o.organizationUseCase.GrantRole(c.Request().Context(), organizationID, employeeID, role)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToOrganizationMemberResponse(&member))
+}
+
+func (o *OrganizationHandler) RevokeRole(c echo.Context) error {
+	type query struct {
+		OrganizationID string `param:"organizationID"`
+		EmployeeID     string `param:"employeeID"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	organizationID, err := models.ParseID(q.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	employeeID, err := models.ParseID(q.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	member, err := o.organizationUseCase.RevokeRole(c.Request().Context(), organizationID, employeeID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToOrganizationMemberResponse(&member))
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// queryFields collects the `query` tags of the struct type, including embedded structs
+func queryFields(typ reflect.Type, fields map[string]struct{}) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name := field.Tag.Get("query")
+		if name == "" {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				queryFields(field.Type, fields)
+			}
+			continue
+		}
+
+		fields[strings.ToLower(name)] = struct{}{}
+	}
+}
+
+// bindListQuery binds the request like c.Bind, but first rejects query parameters
+// that q does not declare, so a misspelled filter is not silently ignored
+func bindListQuery(c echo.Context, q any) error {
+	fields := make(map[string]struct{})
+	queryFields(reflect.TypeOf(q).Elem(), fields)
+
+	for name := range c.QueryParams() {
+		if _, ok := fields[strings.ToLower(name)]; !ok {
+			return fmt.Errorf("unknown query parameter %s: %w", name, domain.ErrInvalidArgument)
+		}
+	}
+
+	return c.Bind(q)
+}
+
+// pageQuery selects the page of a list. A cursor continues the list right after the previous
+// page instead of skipping an offset, an empty cursor starts from the first page.
+type pageQuery struct {
+	Limit  int     `query:"limit"`
+	Offset int     `query:"offset"`
+	Cursor *string `query:"cursor"`
+	Total  bool    `query:"total"`
+}
+
+// paginationOptions collects the page info when the cursor or the total is requested,
+// the list is then wrapped in pageResponse by respondPage
+func (q *pageQuery) paginationOptions() (*abstraction.PaginationOptions, error) {
+	paginationOptions, _ := abstraction.NewPaginationOptions()
+	if q.Limit != 0 {
+		paginationOptions.Limit = q.Limit
+	}
+	paginationOptions.Offset = q.Offset
+
+	if q.Cursor != nil || q.Total {
+		paginationOptions.PageInfo = &abstraction.PageInfo{}
+		paginationOptions.CountTotal = q.Total
+	}
+
+	if q.Cursor != nil && *q.Cursor != "" {
+		cursor, err := abstraction.ParseCursor(*q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		paginationOptions.Cursor = &cursor
+	}
+
+	return paginationOptions, nil
+}
+
+type pageResponse struct {
+	Items      any    `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	Total      *int   `json:"total,omitempty"`
+}
+
+// respondPage responds with the items, wrapped in pageResponse when the page info was collected,
+// so the lists requested with an offset keep their plain array responses
+func respondPage(c echo.Context, paginationOptions *abstraction.PaginationOptions, items any) error {
+	if paginationOptions.PageInfo == nil {
+		return c.JSON(200, items)
+	}
+
+	return c.JSON(200, pageResponse{
+		Items:      items,
+		NextCursor: paginationOptions.PageInfo.NextCursor,
+		Total:      paginationOptions.PageInfo.Total,
+	})
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestBindListQuery(t *testing.T) {
+	type listQuery struct {
+		pageQuery
+		Status string `query:"status"`
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr error
+	}{
+		{name: "declared", query: "status=published"},
+		{name: "embedded", query: "limit=5&cursor=&total=true"},
+		{name: "case insensitive", query: "Status=published"},
+		{name: "unknown", query: "statuses=published", wantErr: domain.ErrInvalidArgument},
+		{name: "legacy username", query: "username=user1", wantErr: domain.ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/tenders?"+tt.query, nil), httptest.NewRecorder())
+
+			var q listQuery
+			err := bindListQuery(c, &q)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTenderListQueryBudget(t *testing.T) {
+	amount := func(a int64) *int64 { return &a }
+
+	tests := []struct {
+		name    string
+		query   tenderListQuery
+		want    *abstraction.BudgetRange
+		wantErr error
+	}{
+		{name: "absent"},
+		{
+			name:  "range",
+			query: tenderListQuery{BudgetMin: amount(100), BudgetMax: amount(500), BudgetCurrency: "rub"},
+			want:  &abstraction.BudgetRange{Min: amount(100), Max: amount(500), Currency: "RUB"},
+		},
+		{
+			name:  "open range",
+			query: tenderListQuery{BudgetMin: amount(100), BudgetCurrency: "RUB"},
+			want:  &abstraction.BudgetRange{Min: amount(100), Currency: "RUB"},
+		},
+		{name: "without currency", query: tenderListQuery{BudgetMin: amount(100)}, wantErr: domain.ErrInvalidArgument},
+		{name: "invalid currency", query: tenderListQuery{BudgetCurrency: "rubles"}, wantErr: domain.ErrInvalidArgument},
+		{
+			name:    "inverted",
+			query:   tenderListQuery{BudgetMin: amount(500), BudgetMax: amount(100), BudgetCurrency: "RUB"},
+			wantErr: domain.ErrInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, _, err := tt.query.options()
+			if err == nil {
+				var opts *abstraction.GetTendersOptions
+				opts, err = abstraction.NewGetTendersOptions(options...)
+				if err == nil && !reflect.DeepEqual(opts.Budget, tt.want) {
+					t.Fatalf("got %#v, want %#v", opts.Budget, tt.want)
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTenderListQueryOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		check   func(t *testing.T, o *abstraction.GetTendersOptions)
+		wantErr error
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			check: func(t *testing.T, o *abstraction.GetTendersOptions) {
+				if o.SortOrder != abstraction.TenderSortCreatedAtDesc || len(o.Statuses) != 0 {
+					t.Errorf("got sort %s and statuses %v, want the newest first of any status", o.SortOrder, o.Statuses)
+				}
+			},
+		},
+		{
+			name:  "repeated status",
+			query: "status=Created&status=published",
+			check: func(t *testing.T, o *abstraction.GetTendersOptions) {
+				want := []models.TenderStatus{models.TenderStatusCreated, models.TenderStatusPublished}
+				if !slices.Equal(o.Statuses, want) {
+					t.Errorf("got statuses %v, want %v", o.Statuses, want)
+				}
+			},
+		},
+		{
+			name:  "sort",
+			query: "sort=BUDGET_ASC",
+			check: func(t *testing.T, o *abstraction.GetTendersOptions) {
+				if o.SortOrder != abstraction.TenderSortBudgetAsc {
+					t.Errorf("got sort %s, want budget_asc", o.SortOrder)
+				}
+			},
+		},
+		{
+			name:  "ranges",
+			query: "created_from=2024-01-01T00:00:00Z&decision_deadline_to=2024-02-01T00:00:00%2B03:00&version_min=2",
+			check: func(t *testing.T, o *abstraction.GetTendersOptions) {
+				if o.CreatedAt.From == nil || !o.CreatedAt.From.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) || o.CreatedAt.To != nil {
+					t.Errorf("got created range %v - %v", o.CreatedAt.From, o.CreatedAt.To)
+				}
+				if o.DecisionDeadline.To == nil || !o.DecisionDeadline.To.Equal(time.Date(2024, 1, 31, 21, 0, 0, 0, time.UTC)) {
+					t.Errorf("got decision deadline up to %v", o.DecisionDeadline.To)
+				}
+				if o.Version.Min == nil || *o.Version.Min != 2 || o.Version.Max != nil {
+					t.Errorf("got version range %v - %v", o.Version.Min, o.Version.Max)
+				}
+			},
+		},
+		{name: "unknown sort", query: "sort=price_asc", wantErr: domain.ErrInvalidArgument},
+		{name: "unknown status", query: "status=draft", wantErr: domain.ErrInvalidArgument},
+		{name: "inverted dates", query: "created_from=2024-02-01T00:00:00Z&created_to=2024-01-01T00:00:00Z", wantErr: domain.ErrInvalidArgument},
+		{name: "inverted versions", query: "version_min=3&version_max=2", wantErr: domain.ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/tenders?"+tt.query, nil), httptest.NewRecorder())
+
+			var q tenderListQuery
+			err := bindListQuery(c, &q)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options, _, err := q.options()
+			if err == nil {
+				var opts *abstraction.GetTendersOptions
+				opts, err = abstraction.NewGetTendersOptions(options...)
+				if err == nil && tt.check != nil {
+					tt.check(t, opts)
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBidListQueryOptions(t *testing.T) {
+	lotID := models.NewID()
+	organizationID := models.NewID()
+
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/bids/my?sort=name_desc&status=approved&status=REJECTED&lot_id="+lotID.String()+"&organization_id="+organizationID.String(), nil), httptest.NewRecorder())
+
+	var q bidListQuery
+	if err := bindListQuery(c, &q); err != nil {
+		t.Fatal(err)
+	}
+
+	options, _, err := q.options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := abstraction.NewGetBidsOptions(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if opts.SortOrder != abstraction.BidSortNameDesc {
+		t.Errorf("got sort %s, want name_desc", opts.SortOrder)
+	}
+	if want := []models.BidStatus{models.BidStatusApproved, models.BidStatusRejected}; !slices.Equal(opts.Statuses, want) {
+		t.Errorf("got statuses %v, want %v", opts.Statuses, want)
+	}
+	if opts.LotID == nil || *opts.LotID != lotID || opts.OrganizationID == nil || *opts.OrganizationID != organizationID {
+		t.Errorf("got lot %v and organization %v", opts.LotID, opts.OrganizationID)
+	}
+
+	for _, query := range []string{"sort=budget_asc", "status=closed", "lot_id=1", "version_min=2&version_max=1"} {
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/bids/my?"+query, nil), httptest.NewRecorder())
+
+		var q bidListQuery
+		if err := bindListQuery(c, &q); err != nil {
+			t.Fatal(err)
+		}
+
+		options, _, err := q.options()
+		if err == nil {
+			_, err = abstraction.NewGetBidsOptions(options...)
+		}
+		if !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Errorf("got %v for %s, want invalid argument", err, query)
+		}
+	}
+}
+
+func TestRespondPage(t *testing.T) {
+	total := 3
+	nextCursor := abstraction.Cursor{Order: "tenders.created_at_desc", ID: models.NewID().String()}.String()
+
+	tests := []struct {
+		name  string
+		query pageQuery
+		want  string
+	}{
+		{name: "offset", query: pageQuery{Offset: 1}, want: `[1,2]`},
+		{name: "first cursor page", query: pageQuery{Cursor: new(string)}, want: `{"items":[1,2],"nextCursor":"` + nextCursor + `"}`},
+		{name: "total", query: pageQuery{Total: true}, want: `{"items":[1,2],"nextCursor":"` + nextCursor + `","total":3}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paginationOptions, err := tt.query.paginationOptions()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the repository fills the page info it was given
+			if paginationOptions.PageInfo != nil {
+				paginationOptions.PageInfo.NextCursor = nextCursor
+				if paginationOptions.CountTotal {
+					paginationOptions.PageInfo.Total = &total
+				}
+			}
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), recorder)
+			if err := respondPage(c, paginationOptions, []int{1, 2}); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.TrimSpace(recorder.Body.String()); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageQueryRejectsMalformedCursor(t *testing.T) {
+	cursor := "bm90IGEgY3Vyc29y"
+	q := pageQuery{Cursor: &cursor}
+
+	_, err := q.paginationOptions()
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v, want invalid argument", err)
+	}
+}
@@ -0,0 +1,16 @@
+package handlers
+
+import "tenderSystem/internal/domain/models"
+
+// searchHitResponse is added to the list items when the list is a full-text search
+type searchHitResponse struct {
+	Rank    float32 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+func modelToSearchHitResponse(h models.SearchHit) searchHitResponse {
+	return searchHitResponse{
+		Rank:    h.Rank,
+		Snippet: h.Snippet,
+	}
+}
@@ -6,29 +6,127 @@ import (
 	"tenderSystem/internal/abstraction"
 	"tenderSystem/internal/domain/dto"
 	"tenderSystem/internal/domain/models"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
+type quorumPolicyResponse struct {
+	Type      string `json:"type"`
+	Threshold int    `json:"threshold,omitempty"`
+}
+
+// moneyJSON is an amount in minor units of the ISO 4217 currency
+type moneyJSON struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func moneyFromJSON(m *moneyJSON) (*models.Money, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	currency, err := models.NewCurrency(m.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	money, err := models.NewMoney(m.Amount, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &money, nil
+}
+
+func moneyToJSON(m *models.Money) *moneyJSON {
+	if m == nil {
+		return nil
+	}
+
+	return &moneyJSON{
+		Amount:   m.Amount,
+		Currency: m.Currency.String(),
+	}
+}
+
+// transitionsResponse describes the current status of an entity and the statuses the caller may move it to
+type transitionsResponse struct {
+	Status  string   `json:"status"`
+	Allowed []string `json:"allowed"`
+}
+
+type lotResponse struct {
+	ID           string     `json:"id"`
+	Number       int        `json:"number"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Budget       *moneyJSON `json:"budget"`
+	Status       string     `json:"status"`
+	AwardedBidID *string    `json:"awardedBidId"`
+}
+
+func modelToLotResponse(l *models.Lot) lotResponse {
+	response := lotResponse{
+		ID:          l.ID.String(),
+		Number:      l.Number,
+		Name:        l.Name,
+		Description: l.Description,
+		Budget:      moneyToJSON(l.Budget),
+		Status:      l.Status.String(),
+	}
+	if l.AwardedBidID != nil {
+		awardedBidID := l.AwardedBidID.String()
+		response.AwardedBidID = &awardedBidID
+	}
+
+	return response
+}
+
 type tenderResponse struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	ServiceType string `json:"serviceType"`
-	Version     int    `json:"version"`
-	CreatedAt   string `json:"createdAt"`
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Status       string               `json:"status"`
+	Visibility   string               `json:"visibility"`
+	ServiceType  string               `json:"serviceType"`
+	QuorumPolicy quorumPolicyResponse `json:"quorumPolicy"`
+	Budget       *moneyJSON           `json:"budget"`
+	Lots         []lotResponse        `json:"lots"`
+	Version      int                  `json:"version"`
+	CreatedAt    string               `json:"createdAt"`
+
+	SubmissionDeadline *string `json:"submissionDeadline"`
+	BidOpeningAt       *string `json:"bidOpeningAt"`
+	DecisionDeadline   *string `json:"decisionDeadline"`
 }
 
 func modelToResponse(t *models.Tender) tenderResponse {
+	lots := make([]lotResponse, 0, len(t.Lots))
+	for _, lot := range t.Lots {
+		lots = append(lots, modelToLotResponse(&lot))
+	}
+
 	return tenderResponse{
 		ID:          t.ID.String(),
 		Name:        t.Name,
 		Description: t.Description,
 		Status:      t.Status.String(),
+		Visibility:  t.Visibility.String(),
 		ServiceType: t.ServiceType.String(),
-		Version:     t.Version,
-		CreatedAt:   t.CreatedAt.Format("2006-01-02T15:04:05"),
+		QuorumPolicy: quorumPolicyResponse{
+			Type:      t.QuorumPolicy.Type.String(),
+			Threshold: t.QuorumPolicy.Threshold,
+		},
+		Budget:    moneyToJSON(t.Budget),
+		Lots:      lots,
+		Version:   t.Version,
+		CreatedAt: t.CreatedAt.Format("2006-01-02T15:04:05"),
+
+		SubmissionDeadline: formatOptionalTime(t.Deadlines.SubmissionDeadline),
+		BidOpeningAt:       formatOptionalTime(t.Deadlines.BidOpeningAt),
+		DecisionDeadline:   formatOptionalTime(t.Deadlines.DecisionDeadline),
 	}
 }
 
@@ -49,40 +147,160 @@ func (t *TenderHandler) Register(g *echo.Group) {
 	g.GET("/my", t.GetMyTenders)
 	g.GET("/:id/status", t.GetTenderStatus)
 	g.PUT("/:id/status", t.ChangeTenderStatus)
+	g.GET("/:id/transitions", t.GetTenderTransitions)
 	g.PATCH("/:id/edit", t.EditTender)
 	g.PUT("/:id/rollback/:version", t.RollbackTender)
+	g.GET("/:id/versions", t.GetTenderVersions)
+	g.GET("/:id/versions/:version", t.GetTenderVersion)
+	g.GET("/:id/diff", t.DiffTenderVersions)
+	g.PUT("/:id/lots/:lotID/cancel", t.CancelLot)
+	g.PUT("/:id/visibility", t.ChangeTenderVisibility)
+	g.GET("/:id/invitees", t.GetTenderInvitees)
+	g.POST("/:id/invitees", t.AddTenderInvitee)
+	g.DELETE("/:id/invitees/:type/:inviteeID", t.RemoveTenderInvitee)
+	g.POST("/:id/attachments", t.UploadAttachment)
+	g.GET("/:id/attachments", t.GetAttachments)
+	g.GET("/:id/attachments/:attachmentID", t.DownloadAttachment)
+}
+
+// tenderListQuery holds the filters and sorting shared by the tender lists
+type tenderListQuery struct {
+	pageQuery
+	Sort           string   `query:"sort"`
+	ServiceType    []string `query:"service_type"`
+	BudgetMin      *int64   `query:"budget_min"`
+	BudgetMax      *int64   `query:"budget_max"`
+	BudgetCurrency string   `query:"budget_currency"`
+	Status         []string `query:"status"`
+
+	CreatedFrom            *time.Time `query:"created_from"`
+	CreatedTo              *time.Time `query:"created_to"`
+	SubmissionDeadlineFrom *time.Time `query:"submission_deadline_from"`
+	SubmissionDeadlineTo   *time.Time `query:"submission_deadline_to"`
+	DecisionDeadlineFrom   *time.Time `query:"decision_deadline_from"`
+	DecisionDeadlineTo     *time.Time `query:"decision_deadline_to"`
+
+	VersionMin *int `query:"version_min"`
+	VersionMax *int `query:"version_max"`
+}
+
+func (q *tenderListQuery) options() ([]abstraction.GetTendersOptFunc, *abstraction.PaginationOptions, error) {
+	var options []abstraction.GetTendersOptFunc
+
+	paginationOptions, err := q.paginationOptions()
+	if err != nil {
+		return nil, nil, err
+	}
+	options = append(options, abstraction.WithPaginationOptions(paginationOptions))
+
+	if q.Sort != "" {
+		sortOrder, err := abstraction.NewTenderSortOrder(strings.ToLower(q.Sort))
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, abstraction.WithTendersSortOrder(sortOrder))
+	}
+
+	for _, strType := range q.ServiceType {
+		tenderType, err := models.NewCategoryCode(strType)
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, abstraction.WithServiceType(tenderType))
+	}
+
+	if q.BudgetMin != nil || q.BudgetMax != nil || q.BudgetCurrency != "" {
+		// Amounts in different currencies are not comparable
+		currency, err := models.NewCurrency(q.BudgetCurrency)
+		if err != nil {
+			return nil, nil, fmt.Errorf("budget_currency is required with a budget range: %w", err)
+		}
+		options = append(options, abstraction.WithBudgetRange(q.BudgetMin, q.BudgetMax, currency))
+	}
+
+	for _, strStatus := range q.Status {
+		status, err := models.NewTenderStatus(strings.ToLower(strStatus))
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, abstraction.WithTenderStatus(status))
+	}
+
+	createdAt, err := abstraction.NewTimeRange(q.CreatedFrom, q.CreatedTo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	submissionDeadline, err := abstraction.NewTimeRange(q.SubmissionDeadlineFrom, q.SubmissionDeadlineTo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decisionDeadline, err := abstraction.NewTimeRange(q.DecisionDeadlineFrom, q.DecisionDeadlineTo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version, err := abstraction.NewVersionRange(q.VersionMin, q.VersionMax)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	options = append(options,
+		abstraction.WithTendersCreatedAt(createdAt),
+		abstraction.WithSubmissionDeadline(submissionDeadline),
+		abstraction.WithDecisionDeadline(decisionDeadline),
+		abstraction.WithTendersVersion(version),
+	)
+
+	return options, paginationOptions, nil
 }
 
 func (t *TenderHandler) GetTenders(c echo.Context) error {
 	type query struct {
-		Limit       int      `query:"limit"`
-		Offset      int      `query:"offset"`
-		ServiceType []string `query:"service_type"`
+		tenderListQuery
+		OrganizationID string `query:"organization_id"`
+		Query          string `query:"q"`
 	}
 
 	var q query
-	if err := c.Bind(&q); err != nil {
+	if err := bindListQuery(c, &q); err != nil {
 		return err
 	}
 
-	var options []abstraction.GetTendersOptFunc
-	{
-		if q.Limit != 0 || q.Offset != 0 {
-			options = append(options, abstraction.WithPaginationOptions(&abstraction.PaginationOptions{
-				Limit:  q.Limit,
-				Offset: q.Offset,
-			}))
+	options, paginationOptions, err := q.options()
+	if err != nil {
+		return err
+	}
+
+	if q.OrganizationID != "" {
+		organizationID, err := models.ParseID(q.OrganizationID)
+		if err != nil {
+			return err
 		}
+		options = append(options, abstraction.WithOrganizationID(organizationID))
+	}
 
-		if q.ServiceType != nil {
-			for _, strType := range q.ServiceType {
-				tenderType, err := models.NewTenderType(strings.ToLower(strType))
-				if err != nil {
-					return err
-				}
-				options = append(options, abstraction.WithServiceType(tenderType))
-			}
+	if strings.TrimSpace(q.Query) != "" {
+		type searchResponse struct {
+			tenderResponse
+			searchHitResponse
+		}
+
+		results, err := t.tenderUseCase.Search(c.Request().Context(), q.Query, options...)
+		if err != nil {
+			return err
 		}
+
+		response := make([]searchResponse, 0, len(results))
+		for _, result := range results {
+			response = append(response, searchResponse{
+				tenderResponse:    modelToResponse(&result.Tender),
+				searchHitResponse: modelToSearchHitResponse(result.SearchHit),
+			})
+		}
+
+		return respondPage(c, paginationOptions, response)
 	}
 
 	tenders, err := t.tenderUseCase.GetAll(c.Request().Context(), options...)
@@ -95,17 +313,36 @@ func (t *TenderHandler) GetTenders(c echo.Context) error {
 		response = append(response, modelToResponse(&tender))
 	}
 
-	return c.JSON(200, response)
+	return respondPage(c, paginationOptions, response)
 }
 
 func (t *TenderHandler) CreateTender(c echo.Context) error {
 
+	type quorumPolicy struct {
+		Type      string `json:"type"`
+		Threshold int    `json:"threshold"`
+	}
+
+	type lot struct {
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		Budget      *moneyJSON `json:"budget"`
+	}
+
 	type body struct {
-		Name            string `json:"name"`
-		Description     string `json:"description"`
-		ServiceType     string `json:"serviceType"`
-		OrganizationID  string `json:"organizationId"`
-		CreatorUsername string `json:"creatorUsername"`
+		Name           string        `json:"name"`
+		Description    string        `json:"description"`
+		ServiceType    string        `json:"serviceType"`
+		OrganizationID string        `json:"organizationId"`
+		QuorumPolicy   *quorumPolicy `json:"quorumPolicy"`
+		Budget         *moneyJSON    `json:"budget"`
+		Lots           []lot         `json:"lots"`
+		Visibility     *string       `json:"visibility"`
+		Invitees       []inviteeJSON `json:"invitees"`
+
+		SubmissionDeadline *time.Time `json:"submissionDeadline"`
+		BidOpeningAt       *time.Time `json:"bidOpeningAt"`
+		DecisionDeadline   *time.Time `json:"decisionDeadline"`
 	}
 
 	var b body
@@ -119,7 +356,7 @@ func (t *TenderHandler) CreateTender(c echo.Context) error {
 		input.Description = b.Description
 
 		var err error
-		input.ServiceType, err = models.NewTenderType(strings.ToLower(b.ServiceType))
+		input.ServiceType, err = models.NewCategoryCode(b.ServiceType)
 		if err != nil {
 			return err
 		}
@@ -129,7 +366,56 @@ func (t *TenderHandler) CreateTender(c echo.Context) error {
 			return err
 		}
 
-		input.CreatorUsername = b.CreatorUsername
+		if b.QuorumPolicy != nil {
+			policyType, err := models.NewQuorumPolicyType(strings.ToLower(b.QuorumPolicy.Type))
+			if err != nil {
+				return err
+			}
+
+			policy, err := models.NewQuorumPolicy(policyType, b.QuorumPolicy.Threshold)
+			if err != nil {
+				return err
+			}
+			input.QuorumPolicy = &policy
+		}
+
+		input.Budget, err = moneyFromJSON(b.Budget)
+		if err != nil {
+			return err
+		}
+
+		for _, l := range b.Lots {
+			budget, err := moneyFromJSON(l.Budget)
+			if err != nil {
+				return err
+			}
+
+			input.Lots = append(input.Lots, dto.CreateLotDTO{
+				Name:        l.Name,
+				Description: l.Description,
+				Budget:      budget,
+			})
+		}
+
+		if b.Visibility != nil {
+			visibility, err := models.NewTenderVisibility(strings.ToLower(*b.Visibility))
+			if err != nil {
+				return err
+			}
+			input.Visibility = &visibility
+		}
+
+		for _, i := range b.Invitees {
+			invitee, err := inviteeFromJSON(i)
+			if err != nil {
+				return err
+			}
+			input.Invitees = append(input.Invitees, invitee)
+		}
+
+		input.SubmissionDeadline = b.SubmissionDeadline
+		input.BidOpeningAt = b.BidOpeningAt
+		input.DecisionDeadline = b.DecisionDeadline
 	}
 
 	tender, err := t.tenderUseCase.Create(c.Request().Context(), &input)
@@ -137,44 +423,123 @@ func (t *TenderHandler) CreateTender(c echo.Context) error {
 		return err
 	}
 
+	setETag(c, tender.Revision)
 	return c.JSON(200, modelToResponse(&tender))
 }
 
 func (t *TenderHandler) GetMyTenders(c echo.Context) error {
+	var q tenderListQuery
+	if err := bindListQuery(c, &q); err != nil {
+		return fmt.Errorf("failed to bind query: %w", err)
+	}
+
+	options, paginationOptions, err := q.options()
+	if err != nil {
+		return err
+	}
+
+	tenders, err := t.tenderUseCase.GetMy(c.Request().Context(), options...)
+	if err != nil {
+		return fmt.Errorf("failed to get my tenders: %w", err)
+	}
+
+	var response []tenderResponse
+	for _, tender := range tenders {
+		response = append(response, modelToResponse(&tender))
+	}
+
+	return respondPage(c, paginationOptions, response)
+}
+
+func (t *TenderHandler) GetTenderVersions(c echo.Context) error {
 	type query struct {
-		Limit    int    `query:"limit"`
-		Offset   int    `query:"offset"`
-		Username string `query:"username"`
+		TenderID string `param:"id"`
+		pageQuery
 	}
 
 	var q query
 	if err := c.Bind(&q); err != nil {
-		return fmt.Errorf("failed to bind query: %w", err)
+		return err
 	}
 
-	var options []abstraction.PaginationOptFunc
-	{
-		if q.Limit != 0 || q.Offset != 0 {
-			options = append(options, abstraction.WithOffset(q.Offset), abstraction.WithLimit(q.Limit))
-		}
+	paginationOptions, err := q.paginationOptions()
+	if err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
 	}
 
-	tenders, err := t.tenderUseCase.GetMy(c.Request().Context(), q.Username, options...)
+	tenders, err := t.tenderUseCase.GetVersions(c.Request().Context(), tenderID, abstraction.WithPagination(*paginationOptions))
 	if err != nil {
-		return fmt.Errorf("failed to get my tenders: %w", err)
+		return err
 	}
 
-	var response []tenderResponse
+	response := make([]tenderVersionResponse, 0, len(tenders))
 	for _, tender := range tenders {
-		response = append(response, modelToResponse(&tender))
+		response = append(response, modelToTenderVersionResponse(&tender))
 	}
 
-	return c.JSON(200, response)
+	return respondPage(c, paginationOptions, response)
+}
+
+func (t *TenderHandler) GetTenderVersion(c echo.Context) error {
+	type query struct {
+		TenderID string `param:"id"`
+		Version  int    `param:"version"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
+	}
+
+	tender, err := t.tenderUseCase.GetVersion(c.Request().Context(), tenderID, q.Version)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToTenderVersionResponse(&tender))
+}
+
+func (t *TenderHandler) DiffTenderVersions(c echo.Context) error {
+	type query struct {
+		TenderID string `param:"id"`
+		diffQuery
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	from, to, err := q.versions()
+	if err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
+	}
+
+	changes, err := t.tenderUseCase.Diff(c.Request().Context(), tenderID, from, to)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelsToDiffResponse(from, to, changes))
 }
 
 func (t *TenderHandler) GetTenderStatus(c echo.Context) error {
 	type query struct {
-		Username string `query:"username"`
 		TenderID string `param:"id"`
 	}
 
@@ -188,17 +553,17 @@ func (t *TenderHandler) GetTenderStatus(c echo.Context) error {
 		return err
 	}
 
-	status, err := t.tenderUseCase.GetStatus(c.Request().Context(), tenderID, q.Username)
+	status, revision, err := t.tenderUseCase.GetStatus(c.Request().Context(), tenderID)
 	if err != nil {
 		return err
 	}
 
+	setETag(c, revision)
 	return c.JSON(200, status.String())
 }
 
 func (t *TenderHandler) ChangeTenderStatus(c echo.Context) error {
 	type query struct {
-		Username string `query:"username"`
 		TenderID string `param:"id"`
 		Status   string `query:"status"`
 	}
@@ -218,27 +583,75 @@ func (t *TenderHandler) ChangeTenderStatus(c echo.Context) error {
 		return err
 	}
 
-	tender, err := t.tenderUseCase.SetStatus(c.Request().Context(), tenderID, q.Username, status)
+	expectedRevisions, err := ifMatchRevisions(c)
+	if err != nil {
+		return err
+	}
+
+	tender, err := t.tenderUseCase.SetStatus(c.Request().Context(), tenderID, status, expectedRevisions)
 	if err != nil {
 		return err
 	}
 
+	setETag(c, tender.Revision)
 	return c.JSON(200, modelToResponse(&tender))
 }
 
+func (t *TenderHandler) GetTenderTransitions(c echo.Context) error {
+	type query struct {
+		TenderID string `param:"id"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
+	}
+
+	status, allowed, err := t.tenderUseCase.GetAllowedStatuses(c.Request().Context(), tenderID)
+	if err != nil {
+		return err
+	}
+
+	response := transitionsResponse{
+		Status:  status.String(),
+		Allowed: make([]string, 0, len(allowed)),
+	}
+	for _, next := range allowed {
+		response.Allowed = append(response.Allowed, next.String())
+	}
+
+	return c.JSON(200, response)
+}
+
 func (t *TenderHandler) EditTender(c echo.Context) error {
+	type lot struct {
+		ID          *string    `json:"id,omitempty"`
+		Name        *string    `json:"name,omitempty"`
+		Description *string    `json:"description,omitempty"`
+		Budget      *moneyJSON `json:"budget,omitempty"`
+	}
+
 	var b struct {
-		Name        *string `json:"name,omitempty"`
-		Description *string `json:"description,omitempty"`
-		ServiceType *string `json:"serviceType,omitempty"`
+		Name        *string    `json:"name,omitempty"`
+		Description *string    `json:"description,omitempty"`
+		ServiceType *string    `json:"serviceType,omitempty"`
+		Budget      *moneyJSON `json:"budget,omitempty"`
+		Lots        []lot      `json:"lots,omitempty"`
+
+		SubmissionDeadline *time.Time `json:"submissionDeadline,omitempty"`
+		BidOpeningAt       *time.Time `json:"bidOpeningAt,omitempty"`
+		DecisionDeadline   *time.Time `json:"decisionDeadline,omitempty"`
 	}
 
 	var query struct {
-		Username string
 		TenderID string
 	}
 	{
-		query.Username = c.QueryParam("username")
 		query.TenderID = c.Param("id")
 	}
 
@@ -258,25 +671,61 @@ func (t *TenderHandler) EditTender(c echo.Context) error {
 		input.Description = b.Description
 
 		if b.ServiceType != nil {
-			serviceType, err := models.NewTenderType(strings.ToLower(*b.ServiceType))
+			serviceType, err := models.NewCategoryCode(*b.ServiceType)
 			if err != nil {
 				return err
 			}
 			input.ServiceType = &serviceType
 		}
+
+		input.Budget, err = moneyFromJSON(b.Budget)
+		if err != nil {
+			return err
+		}
+
+		for _, l := range b.Lots {
+			lotInput := dto.UpdateLotDTO{
+				Name:        l.Name,
+				Description: l.Description,
+			}
+
+			if l.ID != nil {
+				lotID, err := models.ParseID(*l.ID)
+				if err != nil {
+					return err
+				}
+				lotInput.ID = &lotID
+			}
+
+			lotInput.Budget, err = moneyFromJSON(l.Budget)
+			if err != nil {
+				return err
+			}
+
+			input.Lots = append(input.Lots, lotInput)
+		}
+
+		input.SubmissionDeadline = b.SubmissionDeadline
+		input.BidOpeningAt = b.BidOpeningAt
+		input.DecisionDeadline = b.DecisionDeadline
+	}
+
+	expectedRevisions, err := ifMatchRevisions(c)
+	if err != nil {
+		return err
 	}
 
-	tender, err := t.tenderUseCase.Update(c.Request().Context(), tenderID, query.Username, &input)
+	tender, err := t.tenderUseCase.Update(c.Request().Context(), tenderID, &input, expectedRevisions)
 	if err != nil {
 		return err
 	}
 
+	setETag(c, tender.Revision)
 	return c.JSON(200, modelToResponse(&tender))
 }
 
 func (t *TenderHandler) RollbackTender(c echo.Context) error {
 	type query struct {
-		Username string `query:"username"`
 		TenderID string `param:"id"`
 		Version  int    `param:"version"`
 	}
@@ -291,10 +740,263 @@ func (t *TenderHandler) RollbackTender(c echo.Context) error {
 		return err
 	}
 
-	tender, err := t.tenderUseCase.Rollback(c.Request().Context(), tenderID, q.Username, q.Version)
+	expectedRevisions, err := ifMatchRevisions(c)
+	if err != nil {
+		return err
+	}
+
+	tender, err := t.tenderUseCase.Rollback(c.Request().Context(), tenderID, q.Version, expectedRevisions)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, tender.Revision)
+	return c.JSON(200, modelToResponse(&tender))
+}
+
+func (t *TenderHandler) CancelLot(c echo.Context) error {
+	type query struct {
+		TenderID string `param:"id"`
+		LotID    string `param:"lotID"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
+	}
+
+	lotID, err := models.ParseID(q.LotID)
+	if err != nil {
+		return err
+	}
+
+	tender, err := t.tenderUseCase.CancelLot(c.Request().Context(), tenderID, lotID)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, tender.Revision)
+	return c.JSON(200, modelToResponse(&tender))
+}
+
+type inviteeJSON struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+func inviteeFromJSON(i inviteeJSON) (models.TenderInvitee, error) {
+	id, err := models.ParseID(i.ID)
+	if err != nil {
+		return models.TenderInvitee{}, err
+	}
+
+	return models.NewTenderInvitee(strings.ToLower(i.Type), id)
+}
+
+func inviteesToJSON(invitees []models.TenderInvitee) []inviteeJSON {
+	response := make([]inviteeJSON, 0, len(invitees))
+	for _, invitee := range invitees {
+		response = append(response, inviteeJSON{Type: string(invitee.Type), ID: invitee.ID.String()})
+	}
+
+	return response
+}
+
+func (t *TenderHandler) ChangeTenderVisibility(c echo.Context) error {
+	type query struct {
+		TenderID string `param:"id"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
+	}
+
+	// Bind reads the query of GET, DELETE and HEAD requests only
+	visibility, err := models.NewTenderVisibility(strings.ToLower(c.QueryParam("visibility")))
+	if err != nil {
+		return err
+	}
+
+	tender, err := t.tenderUseCase.SetVisibility(c.Request().Context(), tenderID, visibility)
 	if err != nil {
 		return err
 	}
 
+	setETag(c, tender.Revision)
 	return c.JSON(200, modelToResponse(&tender))
 }
+
+func (t *TenderHandler) GetTenderInvitees(c echo.Context) error {
+	type query struct {
+		TenderID string `param:"id"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
+	}
+
+	invitees, err := t.tenderUseCase.GetInvitees(c.Request().Context(), tenderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, inviteesToJSON(invitees))
+}
+
+func (t *TenderHandler) AddTenderInvitee(c echo.Context) error {
+	type body struct {
+		TenderID string `param:"id"`
+		inviteeJSON
+	}
+
+	var b body
+	if err := c.Bind(&b); err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(b.TenderID)
+	if err != nil {
+		return err
+	}
+
+	invitee, err := inviteeFromJSON(b.inviteeJSON)
+	if err != nil {
+		return err
+	}
+
+	invitees, err := t.tenderUseCase.AddInvitees(c.Request().Context(), tenderID, []models.TenderInvitee{invitee})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, inviteesToJSON(invitees))
+}
+
+func (t *TenderHandler) RemoveTenderInvitee(c echo.Context) error {
+	type query struct {
+		TenderID  string `param:"id"`
+		Type      string `param:"type"`
+		InviteeID string `param:"inviteeID"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
+	}
+
+	invitee, err := inviteeFromJSON(inviteeJSON{Type: q.Type, ID: q.InviteeID})
+	if err != nil {
+		return err
+	}
+
+	invitees, err := t.tenderUseCase.RemoveInvitee(c.Request().Context(), tenderID, invitee)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, inviteesToJSON(invitees))
+}
+
+func (t *TenderHandler) UploadAttachment(c echo.Context) error {
+	type query struct {
+		TenderID string `param:"id"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
+	}
+
+	upload, file, err := uploadFromForm(c)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	attachment, err := t.tenderUseCase.UploadAttachment(c.Request().Context(), tenderID, upload)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelToAttachmentResponse(&attachment))
+}
+
+func (t *TenderHandler) GetAttachments(c echo.Context) error {
+	type query struct {
+		TenderID string `param:"id"`
+		Version  *int   `query:"version"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := t.tenderUseCase.GetAttachments(c.Request().Context(), tenderID, q.Version)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(200, modelsToAttachmentResponse(attachments))
+}
+
+func (t *TenderHandler) DownloadAttachment(c echo.Context) error {
+	type query struct {
+		TenderID     string `param:"id"`
+		AttachmentID string `param:"attachmentID"`
+	}
+
+	var q query
+	if err := c.Bind(&q); err != nil {
+		return err
+	}
+
+	tenderID, err := models.ParseID(q.TenderID)
+	if err != nil {
+		return err
+	}
+
+	attachmentID, err := models.ParseID(q.AttachmentID)
+	if err != nil {
+		return err
+	}
+
+	attachment, content, err := t.tenderUseCase.DownloadAttachment(c.Request().Context(), tenderID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	return streamAttachment(c, &attachment, content)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/server/middleware"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestInviteeFromJSON(t *testing.T) {
+	id := models.NewID()
+
+	invitee, err := inviteeFromJSON(inviteeJSON{Type: "Organization", ID: id.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invitee != (models.TenderInvitee{Type: models.PrincipalTypeOrganization, ID: id}) {
+		t.Fatalf("got %v, want the organization", invitee)
+	}
+
+	for name, i := range map[string]inviteeJSON{
+		"unknown type": {Type: "user", ID: id.String()},
+		"malformed id": {Type: "employee", ID: "user1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := inviteeFromJSON(i)
+			if !errors.Is(err, domain.ErrInvalidArgument) {
+				t.Fatalf("got %v, want ErrInvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestInviteesToJSON(t *testing.T) {
+	body, err := json.Marshal(inviteesToJSON(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "[]" {
+		t.Fatalf("got %s, want an empty list", body)
+	}
+
+	id := models.NewID()
+	body, err = json.Marshal(inviteesToJSON([]models.TenderInvitee{{Type: models.PrincipalTypeEmployee, ID: id}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"type":"employee","id":"` + id.String() + `"}]`; string(body) != want {
+		t.Fatalf("got %s, want %s", body, want)
+	}
+}
+
+// visibilityTenderUseCase records the visibility set on a tender
+type visibilityTenderUseCase struct {
+	abstraction.TenderUseCaseInterface
+
+	tender models.Tender
+}
+
+func (v *visibilityTenderUseCase) SetVisibility(_ context.Context, id models.ID, visibility models.TenderVisibility) (models.Tender, error) {
+	if id != v.tender.ID {
+		return models.Tender{}, domain.ErrNotFound
+	}
+
+	v.tender.Visibility = visibility
+	v.tender.Revision++
+	return v.tender, nil
+}
+
+func TestChangeTenderVisibility(t *testing.T) {
+	tender := models.NewTender("tender", "description", "delivery", models.NewID(), models.DefaultQuorumPolicy(), models.TenderDeadlines{}, nil)
+	tender.Revision = 1
+
+	tests := []struct {
+		name           string
+		query          string
+		wantCode       int
+		wantVisibility models.TenderVisibility
+	}{
+		{name: "invite only", query: "?visibility=invite_only", wantCode: http.StatusOK, wantVisibility: models.TenderVisibilityInviteOnly},
+		{name: "upper case", query: "?visibility=PUBLIC", wantCode: http.StatusOK, wantVisibility: models.TenderVisibilityPublic},
+		{name: "missing", wantCode: http.StatusBadRequest, wantVisibility: models.TenderVisibilityPublic},
+		{name: "unknown", query: "?visibility=private", wantCode: http.StatusBadRequest, wantVisibility: models.TenderVisibilityPublic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenderUseCase := &visibilityTenderUseCase{tender: tender}
+
+			e := echo.New()
+			e.Use(middleware.NewErrorMiddleware())
+			NewTenderHandler(tenderUseCase).Register(e.Group("/api"))
+
+			request := httptest.NewRequest(http.MethodPut, "/api/tenders/"+tender.ID.String()+"/visibility"+tt.query, nil)
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("got status %d: %s, want %d", recorder.Code, recorder.Body.String(), tt.wantCode)
+			}
+			if tenderUseCase.tender.Visibility != tt.wantVisibility {
+				t.Fatalf("tender is %s, want %s", tenderUseCase.tender.Visibility, tt.wantVisibility)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Visibility string `json:"visibility"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatal(err)
+			}
+			if response.Visibility != tt.wantVisibility.String() || recorder.Header().Get("ETag") != `"2"` {
+				t.Fatalf("got %s tender tagged %s", response.Visibility, recorder.Header().Get("ETag"))
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"fmt"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+)
+
+// versionAuthorResponse is who created a version, null when it was not recorded
+type versionAuthorResponse struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+func modelToVersionAuthorResponse(a *models.VersionAuthor) *versionAuthorResponse {
+	if a == nil {
+		return nil
+	}
+
+	return &versionAuthorResponse{
+		Type: string(a.Type),
+		ID:   a.ID.String(),
+	}
+}
+
+// tenderVersionResponse is an entry of the tender history, it holds the versioned fields only
+type tenderVersionResponse struct {
+	Version        int                    `json:"version"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	ServiceType    string                 `json:"serviceType"`
+	Budget         *moneyJSON             `json:"budget"`
+	Lots           []lotResponse          `json:"lots,omitempty"`
+	CreatedAt      string                 `json:"createdAt"`
+	CreatedBy      *versionAuthorResponse `json:"createdBy"`
+	RolledBackFrom *int                   `json:"rolledBackFrom"`
+}
+
+func modelToTenderVersionResponse(t *models.Tender) tenderVersionResponse {
+	var lots []lotResponse
+	for _, lot := range t.Lots {
+		lots = append(lots, modelToLotResponse(&lot))
+	}
+
+	return tenderVersionResponse{
+		Version:        t.Version,
+		Name:           t.Name,
+		Description:    t.Description,
+		ServiceType:    t.ServiceType.String(),
+		Budget:         moneyToJSON(t.Budget),
+		Lots:           lots,
+		CreatedAt:      t.CreatedAt.Format("2006-01-02T15:04:05"),
+		CreatedBy:      modelToVersionAuthorResponse(t.VersionAuthor),
+		RolledBackFrom: t.RolledBackFrom,
+	}
+}
+
+// bidVersionResponse is an entry of the bid history, it holds the versioned fields only
+type bidVersionResponse struct {
+	Version        int                    `json:"version"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	Price          *moneyJSON             `json:"price"`
+	CreatedAt      string                 `json:"createdAt"`
+	CreatedBy      *versionAuthorResponse `json:"createdBy"`
+	RolledBackFrom *int                   `json:"rolledBackFrom"`
+}
+
+func modelToBidVersionResponse(b *models.Bid) bidVersionResponse {
+	return bidVersionResponse{
+		Version:        b.Version,
+		Name:           b.Name,
+		Description:    b.Description,
+		Price:          moneyToJSON(b.Price),
+		CreatedAt:      b.CreatedAt.Format("2006-01-02T15:04:05"),
+		CreatedBy:      modelToVersionAuthorResponse(b.VersionAuthor),
+		RolledBackFrom: b.RolledBackFrom,
+	}
+}
+
+// diffQuery selects the two versions to compare
+type diffQuery struct {
+	From *int `query:"from"`
+	To   *int `query:"to"`
+}
+
+func (q *diffQuery) versions() (int, int, error) {
+	if q.From == nil || q.To == nil {
+		return 0, 0, fmt.Errorf("from and to versions are required: %w", domain.ErrInvalidArgument)
+	}
+
+	return *q.From, *q.To, nil
+}
+
+type wordChangeResponse struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type fieldChangeResponse struct {
+	Field string               `json:"field"`
+	Old   any                  `json:"old"`
+	New   any                  `json:"new"`
+	Words []wordChangeResponse `json:"words,omitempty"`
+}
+
+// fieldValueResponse renders a field value of models.FieldChange
+func fieldValueResponse(value any) any {
+	switch v := value.(type) {
+	case *models.Money:
+		return moneyToJSON(v)
+	case models.CategoryCode:
+		return v.String()
+	case *models.Lot:
+		return modelToLotResponse(v)
+	default:
+		return v
+	}
+}
+
+type diffResponse struct {
+	From    int                   `json:"from"`
+	To      int                   `json:"to"`
+	Changes []fieldChangeResponse `json:"changes"`
+}
+
+func modelsToDiffResponse(from, to int, changes []models.FieldChange) diffResponse {
+	response := diffResponse{
+		From:    from,
+		To:      to,
+		Changes: make([]fieldChangeResponse, 0, len(changes)),
+	}
+
+	for _, change := range changes {
+		var words []wordChangeResponse
+		for _, word := range change.Words {
+			words = append(words, wordChangeResponse{Type: string(word.Type), Text: word.Text})
+		}
+
+		response.Changes = append(response.Changes, fieldChangeResponse{
+			Field: change.Field,
+			Old:   fieldValueResponse(change.Old),
+			New:   fieldValueResponse(change.New),
+			Words: words,
+		})
+	}
+
+	return response
+}
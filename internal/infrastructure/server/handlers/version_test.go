@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestDiffQueryVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantFrom int
+		wantTo   int
+		wantErr  error
+	}{
+		{name: "both", query: "from=1&to=3", wantFrom: 1, wantTo: 3},
+		{name: "backwards", query: "from=3&to=1", wantFrom: 3, wantTo: 1},
+		{name: "without from", query: "to=3", wantErr: domain.ErrInvalidArgument},
+		{name: "without to", query: "from=1", wantErr: domain.ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/tenders/1/diff?"+tt.query, nil), httptest.NewRecorder())
+
+			var q diffQuery
+			if err := c.Bind(&q); err != nil {
+				t.Fatal(err)
+			}
+
+			from, to, err := q.versions()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if from != tt.wantFrom || to != tt.wantTo {
+				t.Fatalf("got versions %d and %d, want %d and %d", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestModelsToDiffResponse(t *testing.T) {
+	lot := models.Lot{ID: models.NewID(), Number: 2, Name: "toner", Status: models.LotStatusOpen}
+
+	changes := []models.FieldChange{
+		{
+			Field: "description", Old: "office paper", New: "office paper A4",
+			Words: []models.WordChange{
+				{Type: models.WordChangeEqual, Text: "office paper"},
+				{Type: models.WordChangeInsert, Text: " A4"},
+			},
+		},
+		{Field: "budget", Old: &models.Money{Amount: 100, Currency: "RUB"}, New: (*models.Money)(nil)},
+		{Field: "lots[2]", New: &lot},
+	}
+
+	body, err := json.Marshal(modelsToDiffResponse(1, 3, changes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		From    int `json:"from"`
+		To      int `json:"to"`
+		Changes []struct {
+			Field string          `json:"field"`
+			Old   json.RawMessage `json:"old"`
+			New   json.RawMessage `json:"new"`
+			Words []struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"words"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.From != 1 || got.To != 3 || len(got.Changes) != 3 {
+		t.Fatalf("got %s", body)
+	}
+
+	description := got.Changes[0]
+	if len(description.Words) != 2 || description.Words[1].Type != "insert" || description.Words[1].Text != " A4" {
+		t.Errorf("got description words %+v", description.Words)
+	}
+
+	budget := got.Changes[1]
+	if string(budget.Old) != `{"amount":100,"currency":"RUB"}` || string(budget.New) != "null" || budget.Words != nil {
+		t.Errorf("got budget change %s -> %s", budget.Old, budget.New)
+	}
+
+	added := got.Changes[2]
+	var addedLot struct {
+		ID     string `json:"id"`
+		Number int    `json:"number"`
+	}
+	if err := json.Unmarshal(added.New, &addedLot); err != nil {
+		t.Fatal(err)
+	}
+	if string(added.Old) != "null" || addedLot.ID != lot.ID.String() || addedLot.Number != 2 {
+		t.Errorf("got added lot %s -> %s", added.Old, added.New)
+	}
+}
+
+func TestModelsToDiffResponseWithoutChanges(t *testing.T) {
+	body, err := json.Marshal(modelsToDiffResponse(2, 2, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != `{"from":2,"to":2,"changes":[]}` {
+		t.Fatalf("got %s, want an empty list of changes", body)
+	}
+}
@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/models"
+)
+
+const (
+	HeaderAPIKey         = "X-API-Key"
+	HeaderOrganizationID = "X-Organization-ID"
+)
+
+// AuthConfig configures the authentication middleware
+type AuthConfig struct {
+	// AllowLegacyUsername accepts the `username` query parameter when no
+	// Authorization header is sent. Must only be enabled for local development.
+	AllowLegacyUsername bool
+}
+
+// NewAuthMiddleware authenticates the caller by a bearer JWT or an organization
+// API key and stores the resolved principal in the request context.
+// Employees select the organization they act for with the X-Organization-ID
+// header or the `org` token claim.
+func NewAuthMiddleware(
+	tokenManager abstraction.TokenManager, employeeRepo abstraction.EmployeeRepository,
+	apiKeyUseCase abstraction.APIKeyUseCaseInterface, config AuthConfig,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			var principal models.Principal
+
+			if key := resolveAPIKey(c); key != "" {
+				var err error
+				principal, err = apiKeyUseCase.Authenticate(ctx, key)
+				if err != nil {
+					return err
+				}
+
+				selected := c.Request().Header.Get(HeaderOrganizationID)
+				if selected != "" && !strings.EqualFold(selected, principal.Organization.ID.String()) {
+					return fmt.Errorf("API key does not belong to organization %s: %w", selected, domain.ErrForbidden)
+				}
+			} else {
+				claims, err := resolveClaims(c, tokenManager, config)
+				if err != nil {
+					return err
+				}
+
+				employee, err := employeeRepo.GetByUsername(ctx, claims.Subject)
+				if err != nil {
+					if errors.Is(err, domain.ErrNotFound) {
+						return fmt.Errorf("employee %s does not exist: %w", claims.Subject, domain.ErrUnauthorized)
+					}
+					return err
+				}
+
+				principal = models.NewEmployeePrincipal(employee)
+
+				selected, err := resolveOrganizationID(c, claims)
+				if err != nil {
+					return err
+				}
+
+				if selected != "" {
+					organizationID, err := models.ParseID(selected)
+					if err != nil {
+						return err
+					}
+
+					member, err := employeeRepo.GetMembership(ctx, employee.ID, organizationID)
+					if err != nil {
+						if errors.Is(err, domain.ErrNotFound) {
+							return fmt.Errorf("employee %s is not responsible for organization %s: %w", employee.Username, selected, domain.ErrForbidden)
+						}
+						return err
+					}
+
+					principal = principal.WithMembership(member)
+				}
+			}
+
+			ctx = auth.WithPrincipal(ctx, principal)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+func resolveAPIKey(c echo.Context) string {
+	if key := c.Request().Header.Get(HeaderAPIKey); key != "" {
+		return key
+	}
+
+	scheme, key, found := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+	if found && strings.EqualFold(scheme, "ApiKey") {
+		return key
+	}
+
+	return ""
+}
+
+// consumeLegacyUsername removes the legacy `username` query parameter from the request and
+// returns it, so the handlers that reject unknown query parameters do not see it
+func consumeLegacyUsername(c echo.Context) string {
+	query := c.QueryParams()
+	username := query.Get("username")
+	if !query.Has("username") {
+		return ""
+	}
+
+	query.Del("username")
+	c.Request().URL.RawQuery = query.Encode()
+
+	return username
+}
+
+func resolveClaims(c echo.Context, tokenManager abstraction.TokenManager, config AuthConfig) (abstraction.TokenClaims, error) {
+	var username string
+	if config.AllowLegacyUsername {
+		username = consumeLegacyUsername(c)
+	}
+
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	if header == "" {
+		if username != "" {
+			return abstraction.TokenClaims{Subject: username}, nil
+		}
+		return abstraction.TokenClaims{}, fmt.Errorf("missing authorization header: %w", domain.ErrUnauthorized)
+	}
+
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return abstraction.TokenClaims{}, fmt.Errorf("authorization header must use the Bearer scheme: %w", domain.ErrUnauthorized)
+	}
+
+	return tokenManager.Parse(token)
+}
+
+// resolveOrganizationID returns the acting organization selected by the header or
+// the token claim. A token bound to an organization cannot be used for another one.
+func resolveOrganizationID(c echo.Context, claims abstraction.TokenClaims) (string, error) {
+	selected := c.Request().Header.Get(HeaderOrganizationID)
+
+	if claims.OrganizationID == "" {
+		return selected, nil
+	}
+
+	if selected != "" && !strings.EqualFold(selected, claims.OrganizationID) {
+		return "", fmt.Errorf("token is bound to organization %s: %w", claims.OrganizationID, domain.ErrForbidden)
+	}
+
+	return claims.OrganizationID, nil
+}
@@ -0,0 +1,301 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/token"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// usernameEmployeeRepository knows a single employee
+type usernameEmployeeRepository struct {
+	abstraction.EmployeeRepository
+
+	employee models.Employee
+}
+
+func (u usernameEmployeeRepository) GetByUsername(_ context.Context, username string) (models.Employee, error) {
+	if username != u.employee.Username {
+		return models.Employee{}, domain.ErrNotFound
+	}
+
+	return u.employee, nil
+}
+
+func TestLegacyUsernameIsConsumed(t *testing.T) {
+	employee := models.Employee{ID: models.NewID(), Username: "user1"}
+	authMiddleware := NewAuthMiddleware(nil, usernameEmployeeRepository{employee: employee}, nil, AuthConfig{AllowLegacyUsername: true})
+
+	e := echo.New()
+	request := httptest.NewRequest(http.MethodGet, "/api/tenders?username=user1&limit=5", nil)
+	c := e.NewContext(request, httptest.NewRecorder())
+
+	err := authMiddleware(func(c echo.Context) error {
+		principal, err := auth.PrincipalFromContext(c.Request().Context())
+		if err != nil {
+			return err
+		}
+		if principal.Employee.ID != employee.ID {
+			t.Errorf("principal is %s, want %s", principal.Employee.Username, employee.Username)
+		}
+
+		if c.QueryParams().Has("username") || c.Request().URL.Query().Has("username") {
+			t.Errorf("username query parameter reached the handler: %s", c.Request().URL.RawQuery)
+		}
+		if c.QueryParam("limit") != "5" {
+			t.Errorf("limit is %q, want 5", c.QueryParam("limit"))
+		}
+
+		return nil
+	})(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLegacyUsernameIsRejectedWhenDisabled(t *testing.T) {
+	employee := models.Employee{ID: models.NewID(), Username: "user1"}
+	authMiddleware := NewAuthMiddleware(nil, usernameEmployeeRepository{employee: employee}, nil, AuthConfig{})
+
+	e := echo.New()
+	request := httptest.NewRequest(http.MethodGet, "/api/tenders?username=user1", nil)
+	c := e.NewContext(request, httptest.NewRecorder())
+
+	err := authMiddleware(func(c echo.Context) error {
+		t.Error("handler ran without authentication")
+		return nil
+	})(c)
+	if err == nil {
+		t.Fatal("request without a token was authenticated")
+	}
+}
+
+func TestBearerTokenAuthentication(t *testing.T) {
+	employee := models.Employee{ID: models.NewID(), Username: "user1"}
+	tokenManager, err := token.NewJWTManager(token.JWTConfig{Algorithm: token.AlgorithmHS256, Secret: []byte("secret")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed, _, err := tokenManager.Issue(employee.Username)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unknown, _, err := tokenManager.Issue("nobody")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authMiddleware := NewAuthMiddleware(tokenManager, usernameEmployeeRepository{employee: employee}, nil, AuthConfig{})
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantErr       error
+	}{
+		{name: "bearer", authorization: "Bearer " + signed},
+		{name: "scheme is case insensitive", authorization: "bearer " + signed},
+		{name: "missing", authorization: "", wantErr: domain.ErrUnauthorized},
+		{name: "other scheme", authorization: "Basic " + signed, wantErr: domain.ErrUnauthorized},
+		{name: "empty token", authorization: "Bearer ", wantErr: domain.ErrUnauthorized},
+		{name: "invalid token", authorization: "Bearer " + signed + "x", wantErr: domain.ErrUnauthorized},
+		{name: "unknown employee", authorization: "Bearer " + unknown, wantErr: domain.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/tenders/my", nil)
+			if tt.authorization != "" {
+				request.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			c := echo.New().NewContext(request, httptest.NewRecorder())
+
+			err := authMiddleware(func(c echo.Context) error {
+				principal, err := auth.PrincipalFromContext(c.Request().Context())
+				if err != nil {
+					return err
+				}
+				if principal.Employee.ID != employee.ID {
+					t.Errorf("principal is %s, want %s", principal.Employee.Username, employee.Username)
+				}
+				return nil
+			})(c)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// singleAPIKeyUseCase authenticates a single key of the organization
+type singleAPIKeyUseCase struct {
+	abstraction.APIKeyUseCaseInterface
+
+	key          string
+	organization models.Organization
+}
+
+func (s singleAPIKeyUseCase) Authenticate(_ context.Context, key string) (models.Principal, error) {
+	if key != s.key {
+		return models.Principal{}, domain.ErrUnauthorized
+	}
+
+	return models.NewOrganizationPrincipal(s.organization, []models.APIKeyScope{models.APIKeyScopeTendersRead}), nil
+}
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	organization := models.Organization{ID: models.NewID(), Name: "org"}
+	authMiddleware := NewAuthMiddleware(nil, nil, singleAPIKeyUseCase{key: "tsk_key", organization: organization}, AuthConfig{})
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantErr error
+	}{
+		{name: "X-API-Key", headers: map[string]string{HeaderAPIKey: "tsk_key"}},
+		{name: "Authorization ApiKey", headers: map[string]string{echo.HeaderAuthorization: "ApiKey tsk_key"}},
+		{name: "own organization", headers: map[string]string{HeaderAPIKey: "tsk_key", HeaderOrganizationID: organization.ID.String()}},
+		{name: "unknown key", headers: map[string]string{HeaderAPIKey: "tsk_other"}, wantErr: domain.ErrUnauthorized},
+		{name: "other organization", headers: map[string]string{HeaderAPIKey: "tsk_key", HeaderOrganizationID: models.NewID().String()}, wantErr: domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/tenders", nil)
+			for name, value := range tt.headers {
+				request.Header.Set(name, value)
+			}
+			c := echo.New().NewContext(request, httptest.NewRecorder())
+
+			err := authMiddleware(func(c echo.Context) error {
+				principal, err := auth.PrincipalFromContext(c.Request().Context())
+				if err != nil {
+					return err
+				}
+				if principal.IsEmployee() || principal.Organization.ID != organization.ID {
+					t.Errorf("got principal %+v, want organization %s", principal, organization.ID)
+				}
+				return nil
+			})(c)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// memberEmployeeRepository knows a single employee and their memberships
+type memberEmployeeRepository struct {
+	usernameEmployeeRepository
+
+	memberships []models.OrganizationMember
+}
+
+func (m memberEmployeeRepository) GetMembership(_ context.Context, userID, organizationID models.ID) (models.OrganizationMember, error) {
+	for _, member := range m.memberships {
+		if member.Employee.ID == userID && member.Organization.ID == organizationID {
+			return member, nil
+		}
+	}
+
+	return models.OrganizationMember{}, domain.ErrNotFound
+}
+
+// claimsTokenManager parses tokens into the claims it was given
+type claimsTokenManager struct {
+	abstraction.TokenManager
+
+	claims map[string]abstraction.TokenClaims
+}
+
+func (c claimsTokenManager) Parse(token string) (abstraction.TokenClaims, error) {
+	claims, ok := c.claims[token]
+	if !ok {
+		return abstraction.TokenClaims{}, domain.ErrUnauthorized
+	}
+
+	return claims, nil
+}
+
+func TestActingOrganizationSelection(t *testing.T) {
+	employee := models.Employee{ID: models.NewID(), Username: "consultant"}
+	first := models.Organization{ID: models.NewID(), Name: "first"}
+	second := models.Organization{ID: models.NewID(), Name: "second"}
+	foreign := models.Organization{ID: models.NewID(), Name: "foreign"}
+
+	employeeRepo := memberEmployeeRepository{
+		usernameEmployeeRepository: usernameEmployeeRepository{employee: employee},
+		memberships: []models.OrganizationMember{
+			{Organization: first, Employee: employee, Role: models.OrganizationRoleOwner},
+			{Organization: second, Employee: employee, Role: models.OrganizationRoleEvaluator},
+		},
+	}
+	tokenManager := claimsTokenManager{claims: map[string]abstraction.TokenClaims{
+		"unbound": {Subject: employee.Username},
+		"second":  {Subject: employee.Username, OrganizationID: second.ID.String()},
+		"foreign": {Subject: employee.Username, OrganizationID: foreign.ID.String()},
+	}}
+	authMiddleware := NewAuthMiddleware(tokenManager, employeeRepo, nil, AuthConfig{})
+
+	tests := []struct {
+		name     string
+		token    string
+		selected string
+		want     *models.Organization
+		wantRole models.OrganizationRole
+		wantErr  error
+	}{
+		{name: "no selection", token: "unbound"},
+		{name: "header", token: "unbound", selected: first.ID.String(), want: &first, wantRole: models.OrganizationRoleOwner},
+		{name: "header in upper case", token: "unbound", selected: strings.ToUpper(second.ID.String()), want: &second, wantRole: models.OrganizationRoleEvaluator},
+		{name: "header of foreign organization", token: "unbound", selected: foreign.ID.String(), wantErr: domain.ErrForbidden},
+		{name: "malformed header", token: "unbound", selected: "first", wantErr: domain.ErrInvalidArgument},
+		{name: "claim", token: "second", want: &second, wantRole: models.OrganizationRoleEvaluator},
+		{name: "header matching claim", token: "second", selected: second.ID.String(), want: &second, wantRole: models.OrganizationRoleEvaluator},
+		{name: "header overriding claim", token: "second", selected: first.ID.String(), wantErr: domain.ErrForbidden},
+		{name: "claim of foreign organization", token: "foreign", wantErr: domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/tenders/my", nil)
+			request.Header.Set(echo.HeaderAuthorization, "Bearer "+tt.token)
+			if tt.selected != "" {
+				request.Header.Set(HeaderOrganizationID, tt.selected)
+			}
+			c := echo.New().NewContext(request, httptest.NewRecorder())
+
+			err := authMiddleware(func(c echo.Context) error {
+				principal, err := auth.PrincipalFromContext(c.Request().Context())
+				if err != nil {
+					return err
+				}
+
+				if tt.want == nil {
+					if principal.HasOrganization() {
+						t.Errorf("acting for %s without a selection", principal.Organization.Name)
+					}
+					return nil
+				}
+
+				if principal.Organization.ID != tt.want.ID || principal.Role != tt.wantRole {
+					t.Errorf("acting for %s as %s, want %s as %s", principal.Organization.Name, principal.Role, tt.want.Name, tt.wantRole)
+				}
+				return nil
+			})(c)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
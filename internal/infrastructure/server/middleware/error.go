@@ -38,6 +38,14 @@ func NewErrorMiddleware() echo.MiddlewareFunc {
 				return echo.NewHTTPError(409, err.Error())
 			}
 
+			if errors.Is(err, domain.ErrPreconditionFailed) {
+				return echo.NewHTTPError(412, err.Error())
+			}
+
+			if errors.Is(err, domain.ErrUnprocessable) {
+				return echo.NewHTTPError(422, err.Error())
+			}
+
 			return echo.NewHTTPError(500, err.Error())
 		}
 	}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"tenderSystem/internal/domain"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestErrorMiddlewareStatusCodes(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: domain.ErrInvalidArgument, want: 400},
+		{err: domain.ErrUnauthorized, want: 401},
+		{err: domain.ErrForbidden, want: 403},
+		{err: domain.ErrNotFound, want: 404},
+		{err: domain.ErrAlreadyExists, want: 409},
+		{err: domain.ErrPreconditionFailed, want: 412},
+		{err: domain.ErrUnprocessable, want: 422},
+		{err: errors.New("boom"), want: 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodPut, "/", nil), httptest.NewRecorder())
+			handler := NewErrorMiddleware()(func(echo.Context) error {
+				return fmt.Errorf("handler failed: %w", tt.err)
+			})
+
+			var httpErr *echo.HTTPError
+			if err := handler(c); !errors.As(err, &httpErr) || httpErr.Code != tt.want {
+				t.Fatalf("got %v, want status %d", err, tt.want)
+			}
+		})
+	}
+}
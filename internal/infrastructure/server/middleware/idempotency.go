@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed marks a response replayed from the first request with the key
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// IdempotencyConfig configures the idempotency middleware
+type IdempotencyConfig struct {
+	// MaxBodySize bounds the body of a request with an idempotency key, the body is
+	// buffered to fingerprint the request
+	MaxBodySize int64
+}
+
+// responseRecorder keeps a copy of the body written to the client
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// NewIdempotencyMiddleware makes POST, PUT and PATCH requests with an Idempotency-Key header
+// run once: a repeated request gets the stored response of the first one. Failed requests are
+// not stored, so they may be retried with the same key. Responses marked with Cache-Control:
+// no-store carry credentials, only their status is stored and a repeated request is rejected
+// instead of replayed. Must run after the authentication, as the keys belong to the caller,
+// requests without a principal share the anonymous scope.
+func NewIdempotencyMiddleware(idempotencyUseCase abstraction.IdempotencyUseCaseInterface, config IdempotencyConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+
+			key := request.Header.Get(HeaderIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+
+			switch request.Method {
+			case http.MethodPost, http.MethodPut, http.MethodPatch:
+			default:
+				return next(c)
+			}
+
+			body, err := io.ReadAll(io.LimitReader(request.Body, config.MaxBodySize+1))
+			if err != nil {
+				return err
+			}
+
+			if int64(len(body)) > config.MaxBodySize {
+				return fmt.Errorf("body of a request with an idempotency key must not exceed %d bytes: %w", config.MaxBodySize, domain.ErrInvalidArgument)
+			}
+
+			request.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := request.Context()
+			ifMatch := strings.Join(request.Header.Values("If-Match"), ", ")
+			fingerprint := models.RequestFingerprint(request.Method, request.URL.RequestURI(), ifMatch, body)
+
+			stored, err := idempotencyUseCase.Begin(ctx, key, fingerprint)
+			if err != nil {
+				return err
+			}
+
+			if stored != nil {
+				return replay(c, stored)
+			}
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			err = next(c)
+
+			// the outcome is recorded even when the client is gone
+			ctx = context.WithoutCancel(ctx)
+
+			if err != nil || c.Response().Status >= 500 {
+				abortErr := idempotencyUseCase.Abort(ctx, key)
+				if abortErr != nil {
+					c.Logger().Error(fmt.Errorf("error releasing idempotency key: %w", abortErr))
+				}
+				return err
+			}
+
+			response := models.StoredResponse{
+				StatusCode: c.Response().Status,
+				Header:     c.Response().Header().Clone(),
+				Body:       recorder.body.Bytes(),
+			}
+			if !response.Replayable() {
+				response.Body = nil
+			}
+
+			// the response is already sent, so a failure only costs the replay
+			err = idempotencyUseCase.Complete(ctx, key, response)
+			if err != nil {
+				c.Logger().Error(fmt.Errorf("error storing idempotent response: %w", err))
+			}
+
+			return nil
+		}
+	}
+}
+
+func replay(c echo.Context, stored *models.StoredResponse) error {
+	if !stored.Replayable() {
+		return fmt.Errorf("request with idempotency key %s is completed, its response is not stored: %w", c.Request().Header.Get(HeaderIdempotencyKey), domain.ErrAlreadyExists)
+	}
+
+	header := c.Response().Header()
+	for name, values := range stored.Header {
+		header[name] = values
+	}
+	header.Set(HeaderIdempotentReplayed, "true")
+
+	c.Response().WriteHeader(stored.StatusCode)
+	_, err := c.Response().Write(stored.Body)
+	return err
+}
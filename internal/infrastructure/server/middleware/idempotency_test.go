@@ -0,0 +1,275 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// memoryIdempotency keeps the keys of a single caller in memory
+type memoryIdempotency struct {
+	fingerprints map[string]string
+	responses    map[string]*models.StoredResponse
+}
+
+func newMemoryIdempotency() *memoryIdempotency {
+	return &memoryIdempotency{
+		fingerprints: map[string]string{},
+		responses:    map[string]*models.StoredResponse{},
+	}
+}
+
+func (m *memoryIdempotency) Begin(_ context.Context, key, fingerprint string) (*models.StoredResponse, error) {
+	stored, ok := m.fingerprints[key]
+	if !ok {
+		m.fingerprints[key] = fingerprint
+		return nil, nil
+	}
+
+	if stored != fingerprint {
+		return nil, domain.ErrUnprocessable
+	}
+
+	response := m.responses[key]
+	if response == nil {
+		return nil, domain.ErrAlreadyExists
+	}
+
+	return response, nil
+}
+
+func (m *memoryIdempotency) Complete(_ context.Context, key string, response models.StoredResponse) error {
+	m.responses[key] = &response
+	return nil
+}
+
+func (m *memoryIdempotency) Abort(_ context.Context, key string) error {
+	delete(m.fingerprints, key)
+	delete(m.responses, key)
+	return nil
+}
+
+func (m *memoryIdempotency) PurgeExpired(context.Context, time.Time) (int, error) {
+	return 0, nil
+}
+
+func serveIdempotent(t *testing.T, handler echo.HandlerFunc, store *memoryIdempotency, key string) (*httptest.ResponseRecorder, error) {
+	t.Helper()
+
+	e := echo.New()
+	request := httptest.NewRequest(http.MethodPost, "/api/tenders/new", strings.NewReader(`{"name":"tender"}`))
+	request.Header.Set(HeaderIdempotencyKey, key)
+	recorder := httptest.NewRecorder()
+
+	c := e.NewContext(request, recorder)
+	err := NewIdempotencyMiddleware(store, IdempotencyConfig{MaxBodySize: 1024})(handler)(c)
+
+	return recorder, err
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	store := newMemoryIdempotency()
+	calls := 0
+	handler := func(c echo.Context) error {
+		calls++
+		return c.String(http.StatusCreated, "created")
+	}
+
+	for i := 0; i < 2; i++ {
+		recorder, err := serveIdempotent(t, handler, store, "key")
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		if recorder.Code != http.StatusCreated || recorder.Body.String() != "created" {
+			t.Fatalf("request %d: got %d %q", i, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyDoesNotStoreCredentials(t *testing.T) {
+	store := newMemoryIdempotency()
+	calls := 0
+	handler := func(c echo.Context) error {
+		calls++
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		return c.String(http.StatusCreated, "secret-key")
+	}
+
+	recorder, err := serveIdempotent(t, handler, store, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Body.String() != "secret-key" {
+		t.Fatalf("first response body is %q", recorder.Body.String())
+	}
+
+	if body := store.responses["key"].Body; len(body) != 0 {
+		t.Fatalf("stored body %q, want none", body)
+	}
+
+	_, err = serveIdempotent(t, handler, store, "key")
+	if !errors.Is(err, domain.ErrAlreadyExists) {
+		t.Fatalf("repeated request: got %v, want ErrAlreadyExists", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyReleasesKeyOnFailure(t *testing.T) {
+	store := newMemoryIdempotency()
+	calls := 0
+	handler := func(c echo.Context) error {
+		calls++
+		if calls == 1 {
+			return domain.ErrInvalidArgument
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	if _, err := serveIdempotent(t, handler, store, "key"); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("first request: got %v", err)
+	}
+
+	recorder, err := serveIdempotent(t, handler, store, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Code != http.StatusNoContent || calls != 2 {
+		t.Fatalf("retry: got %d after %d calls", recorder.Code, calls)
+	}
+}
+
+func TestIdempotencyMarksReplayedResponse(t *testing.T) {
+	store := newMemoryIdempotency()
+	handler := func(c echo.Context) error {
+		c.Response().Header().Set("ETag", `"1"`)
+		return c.String(http.StatusCreated, "created")
+	}
+
+	first, err := serveIdempotent(t, handler, store, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Header().Get(HeaderIdempotentReplayed) != "" {
+		t.Fatal("first response is marked as replayed")
+	}
+
+	replayed, err := serveIdempotent(t, handler, store, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.Header().Get(HeaderIdempotentReplayed) != "true" || replayed.Header().Get("ETag") != `"1"` {
+		t.Fatalf("got headers %v, want the stored ones marked as replayed", replayed.Header())
+	}
+}
+
+func TestIdempotencyRejectsKeyOfAnotherRequest(t *testing.T) {
+	store := newMemoryIdempotency()
+	handler := func(c echo.Context) error {
+		return c.String(http.StatusCreated, "created")
+	}
+
+	if _, err := serveIdempotent(t, handler, store, "key"); err != nil {
+		t.Fatal(err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/api/tenders/new", strings.NewReader(`{"name":"other"}`))
+	request.Header.Set(HeaderIdempotencyKey, "key")
+	c := echo.New().NewContext(request, httptest.NewRecorder())
+
+	err := NewIdempotencyMiddleware(store, IdempotencyConfig{MaxBodySize: 1024})(handler)(c)
+	if !errors.Is(err, domain.ErrUnprocessable) {
+		t.Fatalf("got %v, want ErrUnprocessable", err)
+	}
+}
+
+func TestIdempotencyLimitsBodySize(t *testing.T) {
+	store := newMemoryIdempotency()
+	handler := func(c echo.Context) error {
+		t.Fatal("handler ran for an oversized body")
+		return nil
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/api/tenders/new", strings.NewReader(strings.Repeat("a", 1025)))
+	request.Header.Set(HeaderIdempotencyKey, "key")
+	c := echo.New().NewContext(request, httptest.NewRecorder())
+
+	err := NewIdempotencyMiddleware(store, IdempotencyConfig{MaxBodySize: 1024})(handler)(c)
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v, want ErrInvalidArgument", err)
+	}
+	if len(store.fingerprints) != 0 {
+		t.Fatal("key is reserved for a rejected request")
+	}
+}
+
+func TestIdempotencyIgnoresSafeMethods(t *testing.T) {
+	store := newMemoryIdempotency()
+	calls := 0
+	handler := func(c echo.Context) error {
+		calls++
+		return c.String(http.StatusOK, "tenders")
+	}
+
+	for i := 0; i < 2; i++ {
+		request := httptest.NewRequest(http.MethodGet, "/api/tenders", nil)
+		request.Header.Set(HeaderIdempotencyKey, "key")
+		c := echo.New().NewContext(request, httptest.NewRecorder())
+
+		if err := NewIdempotencyMiddleware(store, IdempotencyConfig{MaxBodySize: 1024})(handler)(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls != 2 || len(store.fingerprints) != 0 {
+		t.Fatalf("handler ran %d times with %d keys stored, want every GET to run without a key", calls, len(store.fingerprints))
+	}
+}
+
+func TestIdempotencyChecksPreconditionOfRetry(t *testing.T) {
+	store := newMemoryIdempotency()
+	calls := 0
+	handler := func(c echo.Context) error {
+		calls++
+		return c.String(http.StatusOK, "edited")
+	}
+
+	serve := func(ifMatch string) error {
+		request := httptest.NewRequest(http.MethodPatch, "/api/tenders/1/edit", strings.NewReader(`{"name":"tender"}`))
+		request.Header.Set(HeaderIdempotencyKey, "key")
+		request.Header.Set("If-Match", ifMatch)
+		c := echo.New().NewContext(request, httptest.NewRecorder())
+
+		return NewIdempotencyMiddleware(store, IdempotencyConfig{MaxBodySize: 1024})(handler)(c)
+	}
+
+	if err := serve(`"4"`); err != nil {
+		t.Fatal(err)
+	}
+	if err := serve(`"4"`); err != nil {
+		t.Fatal(err)
+	}
+
+	// the earlier response is not replayed for another precondition
+	if err := serve(`"5"`); !errors.Is(err, domain.ErrUnprocessable) {
+		t.Fatalf("got %v for another If-Match, want ErrUnprocessable", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls)
+	}
+}
@@ -11,6 +11,17 @@ import (
 type Server struct {
 	tenderUseCase abstraction.TenderUseCaseInterface
 	bidsUseCase   abstraction.BidUseCaseInterface
+	authUseCase   abstraction.AuthUseCaseInterface
+	apiKeyUseCase abstraction.APIKeyUseCaseInterface
+	orgUseCase    abstraction.OrganizationUseCaseInterface
+	employeeUC    abstraction.EmployeeUseCaseInterface
+	categoryUC    abstraction.CategoryUseCaseInterface
+	idempotencyUC abstraction.IdempotencyUseCaseInterface
+
+	tokenManager      abstraction.TokenManager
+	employeeRepo      abstraction.EmployeeRepository
+	authConfig        middleware.AuthConfig
+	idempotencyConfig middleware.IdempotencyConfig
 
 	e    *echo.Echo
 	host string
@@ -19,14 +30,29 @@ type Server struct {
 
 func NewServer(
 	tenderUseCase abstraction.TenderUseCaseInterface, bidsUseCase abstraction.BidUseCaseInterface,
+	authUseCase abstraction.AuthUseCaseInterface, apiKeyUseCase abstraction.APIKeyUseCaseInterface,
+	orgUseCase abstraction.OrganizationUseCaseInterface, employeeUC abstraction.EmployeeUseCaseInterface,
+	categoryUC abstraction.CategoryUseCaseInterface, idempotencyUC abstraction.IdempotencyUseCaseInterface,
+	tokenManager abstraction.TokenManager, employeeRepo abstraction.EmployeeRepository, authConfig middleware.AuthConfig,
+	idempotencyConfig middleware.IdempotencyConfig,
 	host string, port string,
 ) *Server {
 	return &Server{
-		tenderUseCase: tenderUseCase,
-		bidsUseCase:   bidsUseCase,
-		e:             echo.New(),
-		host:          host,
-		port:          port,
+		tenderUseCase:     tenderUseCase,
+		bidsUseCase:       bidsUseCase,
+		authUseCase:       authUseCase,
+		apiKeyUseCase:     apiKeyUseCase,
+		orgUseCase:        orgUseCase,
+		employeeUC:        employeeUC,
+		categoryUC:        categoryUC,
+		idempotencyUC:     idempotencyUC,
+		tokenManager:      tokenManager,
+		employeeRepo:      employeeRepo,
+		authConfig:        authConfig,
+		idempotencyConfig: idempotencyConfig,
+		e:                 echo.New(),
+		host:              host,
+		port:              port,
 	}
 }
 
@@ -38,11 +64,35 @@ func (s *Server) Start() error {
 	pingHandler := handlers.NewPingHandler()
 	pingHandler.Register(g)
 
+	// Mutating requests may carry an idempotency key, it is scoped to the authenticated caller.
+	// The token endpoints and invitation redemption share the anonymous scope: their fingerprints
+	// hold the credentials sent, and the tokens they hand out are marked no-store and never replayed
+	idempotency := middleware.NewIdempotencyMiddleware(s.idempotencyUC, s.idempotencyConfig)
+	public := g.Group("", idempotency)
+
+	// Everything except ping, the token endpoints and invitation redemption requires an authenticated principal
+	protected := g.Group("", middleware.NewAuthMiddleware(s.tokenManager, s.employeeRepo, s.apiKeyUseCase, s.authConfig), idempotency)
+
+	authHandler := handlers.NewAuthHandler(s.authUseCase)
+	authHandler.Register(public, protected)
+
 	tenderHandler := handlers.NewTenderHandler(s.tenderUseCase)
-	tenderHandler.Register(g)
+	tenderHandler.Register(protected)
 
 	bidHandler := handlers.NewBidHandler(s.bidsUseCase)
-	bidHandler.Register(g)
+	bidHandler.Register(protected)
+
+	apiKeyHandler := handlers.NewAPIKeyHandler(s.apiKeyUseCase)
+	apiKeyHandler.Register(protected)
+
+	organizationHandler := handlers.NewOrganizationHandler(s.orgUseCase)
+	organizationHandler.Register(protected)
+
+	employeeHandler := handlers.NewEmployeeHandler(s.employeeUC)
+	employeeHandler.Register(public, protected)
+
+	categoryHandler := handlers.NewCategoryHandler(s.categoryUC)
+	categoryHandler.Register(protected)
 
 	s.e.Use(echoMiddleware.Logger())
 	s.e.Use(middleware.NewErrorMiddleware())
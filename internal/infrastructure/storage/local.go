@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+)
+
+var _ abstraction.BlobStorage = &LocalStorage{}
+
+// LocalStorage keeps blobs as files under the root directory
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage creates a new instance of LocalStorage, the root directory is created if missing
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	err := os.MkdirAll(root, 0o750)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &LocalStorage{root: root}, nil
+}
+
+// path maps the key to a file under the root, so keys can't escape it
+func (l *LocalStorage) path(key string) string {
+	return filepath.Join(l.root, filepath.Clean("/"+key))
+}
+
+func (l *LocalStorage) Put(_ context.Context, key string, content io.Reader, _ int64, _ string) error {
+	path := l.path(key)
+
+	err := os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return err
+	}
+
+	// the content is written to a temporary file first, so readers never see a partial blob
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (l *LocalStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(l.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("blob %s not found: %w", key, domain.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (l *LocalStorage) Delete(_ context.Context, key string) error {
+	err := os.Remove(l.path(key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testPutGetDelete(t, s, []byte("tender documentation"), -1)
+}
+
+func TestLocalStorageKeepsKeysUnderRoot(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+
+	s, err := NewLocalStorage(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Put(context.Background(), "../escaped", bytes.NewReader([]byte("content")), -1, "text/plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "escaped")); !os.IsNotExist(err) {
+		t.Fatalf("blob was written outside of the root: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escaped")); err != nil {
+		t.Fatalf("blob is not under the root: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+// newTestS3Storage connects to the storage from TEST_S3_ENDPOINT, for example the MinIO
+// of storage/minio-compose.yaml. The tests are skipped when the variable is not set.
+func newTestS3Storage(t *testing.T) *S3Storage {
+	t.Helper()
+
+	endpoint := os.Getenv("TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("TEST_S3_ENDPOINT is not set")
+	}
+
+	bucket := os.Getenv("TEST_S3_BUCKET")
+	if bucket == "" {
+		bucket = "tender-system-test"
+	}
+
+	s, err := NewS3Storage(context.Background(), S3Config{
+		Endpoint:  endpoint,
+		AccessKey: os.Getenv("TEST_S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("TEST_S3_SECRET_KEY"),
+		Bucket:    bucket,
+		UseSSL:    os.Getenv("TEST_S3_USE_SSL") == "true",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestS3Storage(t *testing.T) {
+	s := newTestS3Storage(t)
+
+	t.Run("known size", func(t *testing.T) {
+		testPutGetDelete(t, s, []byte("tender documentation"), int64(len("tender documentation")))
+	})
+
+	t.Run("unknown size", func(t *testing.T) {
+		testPutGetDelete(t, s, []byte("streamed upload"), -1)
+	})
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"testing"
+)
+
+// testPutGetDelete runs a blob through the storage, size is passed to Put as is
+func testPutGetDelete(t *testing.T, s abstraction.BlobStorage, content []byte, size int64) {
+	t.Helper()
+
+	ctx := context.Background()
+	key := "attachments/" + models.NewID().String()
+
+	err := s.Put(ctx, key, bytes.NewReader(content), size, "text/plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.Delete(context.Background(), key) })
+
+	reader, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := io.ReadAll(reader)
+	_ = reader.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(stored, content) {
+		t.Fatalf("got %q, want %q", stored, content)
+	}
+
+	err = s.Delete(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.Get(ctx, key)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v after delete, want ErrNotFound", err)
+	}
+
+	// deleting a missing blob is not an error
+	err = s.Delete(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,144 @@
+package token
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var _ abstraction.TokenManager = &JWTManager{}
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+const defaultAccessTokenTTL = 15 * time.Minute
+
+// JWTConfig holds the keys used to sign and verify JWTs.
+// PrivateKey is optional for RS256: without it tokens can only be verified.
+type JWTConfig struct {
+	Algorithm      string
+	Secret         []byte
+	PublicKey      *rsa.PublicKey
+	PrivateKey     *rsa.PrivateKey
+	AccessTokenTTL time.Duration
+}
+
+// JWTManager issues and verifies HS256 and RS256 signed JWTs
+type JWTManager struct {
+	config JWTConfig
+}
+
+// NewJWTManager creates a new instance of JWTManager
+func NewJWTManager(config JWTConfig) (*JWTManager, error) {
+	switch config.Algorithm {
+	case AlgorithmHS256:
+		if len(config.Secret) == 0 {
+			return nil, errors.New("HS256 requires a non-empty secret")
+		}
+	case AlgorithmRS256:
+		if config.PublicKey == nil {
+			return nil, errors.New("RS256 requires a public key")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", config.Algorithm)
+	}
+
+	if config.AccessTokenTTL == 0 {
+		config.AccessTokenTTL = defaultAccessTokenTTL
+	}
+
+	return &JWTManager{config: config}, nil
+}
+
+// LoadRSAPublicKey reads a PEM encoded RSA public key from path
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading public key: %w", err)
+	}
+
+	return jwt.ParseRSAPublicKeyFromPEM(data)
+}
+
+// LoadRSAPrivateKey reads a PEM encoded RSA private key from path
+func LoadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading private key: %w", err)
+	}
+
+	return jwt.ParseRSAPrivateKeyFromPEM(data)
+}
+
+func (j *JWTManager) keyFunc(*jwt.Token) (interface{}, error) {
+	if j.config.Algorithm == AlgorithmRS256 {
+		return j.config.PublicKey, nil
+	}
+
+	return j.config.Secret, nil
+}
+
+// accessTokenClaims are the registered claims plus the optional acting organization
+type accessTokenClaims struct {
+	jwt.RegisteredClaims
+	OrganizationID string `json:"org,omitempty"`
+}
+
+func (j *JWTManager) Parse(tokenString string) (abstraction.TokenClaims, error) {
+	var claims accessTokenClaims
+
+	_, err := jwt.ParseWithClaims(
+		tokenString, &claims, j.keyFunc,
+		jwt.WithValidMethods([]string{j.config.Algorithm}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return abstraction.TokenClaims{}, fmt.Errorf("invalid token: %s: %w", err, domain.ErrUnauthorized)
+	}
+
+	if claims.Subject == "" {
+		return abstraction.TokenClaims{}, fmt.Errorf("token has no subject: %w", domain.ErrUnauthorized)
+	}
+
+	return abstraction.TokenClaims{
+		Subject:        claims.Subject,
+		OrganizationID: claims.OrganizationID,
+		ExpiresAt:      claims.ExpiresAt.Time,
+	}, nil
+}
+
+func (j *JWTManager) Issue(subject string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(j.config.AccessTokenTTL)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   subject,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	var signed string
+	var err error
+	switch j.config.Algorithm {
+	case AlgorithmRS256:
+		if j.config.PrivateKey == nil {
+			return "", time.Time{}, errors.New("token issuing requires an RS256 private key")
+		}
+		signed, err = jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(j.config.PrivateKey)
+	default:
+		signed, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(j.config.Secret)
+	}
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("error signing token: %w", err)
+	}
+
+	return signed, expiresAt, nil
+}
@@ -0,0 +1,160 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"tenderSystem/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestIssuedTokenParses(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configs := map[string]JWTConfig{
+		AlgorithmHS256: {Algorithm: AlgorithmHS256, Secret: []byte("secret")},
+		AlgorithmRS256: {Algorithm: AlgorithmRS256, PublicKey: &key.PublicKey, PrivateKey: key},
+	}
+
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			manager, err := NewJWTManager(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signed, expiresAt, err := manager.Issue("user1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			claims, err := manager.Parse(signed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if claims.Subject != "user1" {
+				t.Errorf("subject is %q, want user1", claims.Subject)
+			}
+			if !claims.ExpiresAt.Equal(expiresAt.Truncate(time.Second)) {
+				t.Errorf("token expires at %s, want %s", claims.ExpiresAt, expiresAt)
+			}
+		})
+	}
+}
+
+func TestParseRejectsInvalidTokens(t *testing.T) {
+	secret := []byte("secret")
+	manager, err := NewJWTManager(JWTConfig{Algorithm: AlgorithmHS256, Secret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+		signed, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	future := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "malformed", token: "not-a-jwt"},
+		{name: "expired", token: sign(jwt.SigningMethodHS256, secret, jwt.MapClaims{"sub": "user1", "exp": time.Now().Add(-time.Minute).Unix()})},
+		{name: "without expiration", token: sign(jwt.SigningMethodHS256, secret, jwt.MapClaims{"sub": "user1"})},
+		{name: "without subject", token: sign(jwt.SigningMethodHS256, secret, jwt.MapClaims{"exp": future})},
+		{name: "other secret", token: sign(jwt.SigningMethodHS256, []byte("other"), jwt.MapClaims{"sub": "user1", "exp": future})},
+		{name: "other algorithm", token: sign(jwt.SigningMethodHS512, secret, jwt.MapClaims{"sub": "user1", "exp": future})},
+		{name: "unsigned", token: sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"sub": "user1", "exp": future})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := manager.Parse(tt.token)
+			if !errors.Is(err, domain.ErrUnauthorized) {
+				t.Fatalf("got %v, want unauthorized", err)
+			}
+		})
+	}
+}
+
+func TestParseReadsOrganizationClaim(t *testing.T) {
+	secret := []byte("secret")
+	manager, err := NewJWTManager(JWTConfig{Algorithm: AlgorithmHS256, Secret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "user1", "org": "550e8400-e29b-41d4-a716-446655440000", "exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := manager.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims.OrganizationID != "550e8400-e29b-41d4-a716-446655440000" {
+		t.Fatalf("organization is %q", claims.OrganizationID)
+	}
+}
+
+func TestRS256WithoutPrivateKeyOnlyVerifies(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager, err := NewJWTManager(JWTConfig{Algorithm: AlgorithmRS256, PublicKey: &key.PublicKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = manager.Issue("user1")
+	if err == nil {
+		t.Fatal("issued a token without a private key")
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "user1", "exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = manager.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewJWTManagerRequiresKeys(t *testing.T) {
+	configs := map[string]JWTConfig{
+		"HS256 without secret":     {Algorithm: AlgorithmHS256},
+		"RS256 without public key": {Algorithm: AlgorithmRS256},
+		"unsupported algorithm":    {Algorithm: "none", Secret: []byte("secret")},
+	}
+
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewJWTManager(config)
+			if err == nil {
+				t.Fatal("created a manager without the keys")
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+var _ abstraction.APIKeyUseCaseInterface = &APIKeyUseCase{}
+
+const (
+	apiKeyPrefix       = "tsk_"
+	apiKeyPrefixLength = 12
+)
+
+type APIKeyUseCase struct {
+	apiKeyRepo       abstraction.APIKeyRepository
+	organizationRepo abstraction.OrganizationRepository
+	employeeRepo     abstraction.EmployeeRepository
+}
+
+func NewAPIKeyUseCase(
+	apiKeyRepo abstraction.APIKeyRepository,
+	organizationRepo abstraction.OrganizationRepository,
+	employeeRepo abstraction.EmployeeRepository,
+) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo:       apiKeyRepo,
+		organizationRepo: organizationRepo,
+		employeeRepo:     employeeRepo,
+	}
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("error generating API key: %w", err)
+	}
+
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// authorizeManager checks that the caller is an employee allowed to manage the organization's keys
+func (a *APIKeyUseCase) authorizeManager(ctx context.Context, organizationID models.ID) (models.Employee, error) {
+	principal, o, err := actingOrganization(ctx, a.employeeRepo, models.PermissionManageKeys)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	u := principal.Employee
+
+	if o.ID != organizationID {
+		return models.Employee{}, fmt.Errorf("user %s is not responsible for organization %s: %w", u.Username, organizationID, domain.ErrForbidden)
+	}
+
+	return u, nil
+}
+
+func (a *APIKeyUseCase) Create(ctx context.Context, data *dto.CreateAPIKeyDTO) (models.APIKey, string, error) {
+	u, err := a.authorizeManager(ctx, data.OrganizationID)
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+
+	if data.Name == "" {
+		return models.APIKey{}, "", fmt.Errorf("API key name is required: %w", domain.ErrInvalidArgument)
+	}
+
+	if len(data.Scopes) == 0 {
+		return models.APIKey{}, "", fmt.Errorf("API key needs at least one scope: %w", domain.ErrInvalidArgument)
+	}
+
+	if data.ExpiresAt != nil && !data.ExpiresAt.After(time.Now()) {
+		return models.APIKey{}, "", fmt.Errorf("API key expiry must be in the future: %w", domain.ErrInvalidArgument)
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+
+	apiKeyModel := models.NewAPIKey(data.OrganizationID, data.Name, key[:apiKeyPrefixLength], hashAPIKey(key), data.Scopes, data.ExpiresAt, u.ID)
+
+	apiKey, err := a.apiKeyRepo.Create(ctx, &apiKeyModel)
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+
+	return apiKey, key, nil
+}
+
+func (a *APIKeyUseCase) GetByOrganizationID(ctx context.Context, organizationID models.ID) ([]models.APIKey, error) {
+	_, err := a.authorizeManager(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.apiKeyRepo.GetByOrganizationID(ctx, organizationID)
+}
+
+func (a *APIKeyUseCase) Revoke(ctx context.Context, organizationID, id models.ID) error {
+	_, err := a.authorizeManager(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	return a.apiKeyRepo.Revoke(ctx, organizationID, id)
+}
+
+func (a *APIKeyUseCase) Authenticate(ctx context.Context, key string) (models.Principal, error) {
+	invalidKey := fmt.Errorf("invalid API key: %w", domain.ErrUnauthorized)
+
+	apiKey, err := a.apiKeyRepo.GetByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return models.Principal{}, invalidKey
+		}
+		return models.Principal{}, err
+	}
+
+	now := time.Now()
+
+	if !apiKey.IsActive(now) {
+		return models.Principal{}, invalidKey
+	}
+
+	organization, err := a.organizationRepo.GetByID(ctx, apiKey.OrganizationID)
+	if err != nil {
+		return models.Principal{}, err
+	}
+
+	err = a.apiKeyRepo.TouchLastUsed(ctx, apiKey.ID, now)
+	if err != nil {
+		return models.Principal{}, err
+	}
+
+	return models.NewOrganizationPrincipal(organization, apiKey.Scopes), nil
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"testing"
+	"time"
+)
+
+// memoryAPIKeyRepository keeps API keys in memory
+type memoryAPIKeyRepository struct {
+	abstraction.APIKeyRepository
+
+	keys []models.APIKey
+}
+
+func (m *memoryAPIKeyRepository) Create(_ context.Context, data *models.APIKey) (models.APIKey, error) {
+	m.keys = append(m.keys, *data)
+	return *data, nil
+}
+
+func (m *memoryAPIKeyRepository) GetByHash(_ context.Context, keyHash string) (models.APIKey, error) {
+	for _, key := range m.keys {
+		if key.KeyHash == keyHash {
+			return key, nil
+		}
+	}
+
+	return models.APIKey{}, domain.ErrNotFound
+}
+
+func (m *memoryAPIKeyRepository) TouchLastUsed(_ context.Context, id models.ID, usedAt time.Time) error {
+	for i := range m.keys {
+		if m.keys[i].ID == id {
+			m.keys[i].LastUsedAt = &usedAt
+		}
+	}
+
+	return nil
+}
+
+// singleOrganizationRepository knows a single organization
+type singleOrganizationRepository struct {
+	abstraction.OrganizationRepository
+
+	organization models.Organization
+}
+
+func (s singleOrganizationRepository) GetByID(_ context.Context, id models.ID) (models.Organization, error) {
+	if id != s.organization.ID {
+		return models.Organization{}, domain.ErrNotFound
+	}
+
+	return s.organization, nil
+}
+
+func TestAPIKeyCreateAndAuthenticate(t *testing.T) {
+	organization := models.Organization{ID: models.NewID(), Name: "org"}
+	owner := models.Employee{ID: models.NewID(), Username: "owner"}
+	apiKeyRepo := &memoryAPIKeyRepository{}
+	apiKeyUseCase := NewAPIKeyUseCase(apiKeyRepo, singleOrganizationRepository{organization: organization}, nil)
+
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner).WithMembership(models.OrganizationMember{
+		Organization: organization, Employee: owner, Role: models.OrganizationRoleOwner,
+	}))
+
+	apiKey, key, err := apiKeyUseCase.Create(ctx, &dto.CreateAPIKeyDTO{
+		OrganizationID: organization.ID,
+		Name:           "erp",
+		Scopes:         []models.APIKeyScope{models.APIKeyScopeTendersRead},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(key, apiKey.Prefix) || apiKey.KeyHash == key || strings.Contains(apiKey.KeyHash, key) {
+		t.Fatalf("stored key %+v reveals the key %s", apiKey, key)
+	}
+
+	principal, err := apiKeyUseCase.Authenticate(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if principal.IsEmployee() || principal.Organization.ID != organization.ID {
+		t.Fatalf("got principal %+v, want organization %s", principal, organization.ID)
+	}
+	if !principal.HasScope(models.APIKeyScopeTendersRead) || principal.HasScope(models.APIKeyScopeTendersWrite) {
+		t.Fatalf("got scopes %v, want tenders:read only", principal.Scopes)
+	}
+	if apiKeyRepo.keys[0].LastUsedAt == nil {
+		t.Fatal("last use of the key is not recorded")
+	}
+}
+
+func TestAPIKeyAuthenticateRejectsInactiveKeys(t *testing.T) {
+	organization := models.Organization{ID: models.NewID(), Name: "org"}
+	past := time.Now().Add(-time.Minute)
+
+	active := models.NewAPIKey(organization.ID, "active", "tsk_active", hashAPIKey("active"), nil, nil, models.NewID())
+	revoked := models.NewAPIKey(organization.ID, "revoked", "tsk_revoked", hashAPIKey("revoked"), nil, nil, models.NewID())
+	revoked.RevokedAt = &past
+	expired := models.NewAPIKey(organization.ID, "expired", "tsk_expired", hashAPIKey("expired"), nil, &past, models.NewID())
+
+	apiKeyRepo := &memoryAPIKeyRepository{keys: []models.APIKey{active, revoked, expired}}
+	apiKeyUseCase := NewAPIKeyUseCase(apiKeyRepo, singleOrganizationRepository{organization: organization}, nil)
+
+	tests := []struct {
+		key     string
+		wantErr error
+	}{
+		{key: "active"},
+		{key: "revoked", wantErr: domain.ErrUnauthorized},
+		{key: "expired", wantErr: domain.ErrUnauthorized},
+		{key: "unknown", wantErr: domain.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			_, err := apiKeyUseCase.Authenticate(context.Background(), tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPIKeyCreateValidation(t *testing.T) {
+	organization := models.Organization{ID: models.NewID(), Name: "org"}
+	employee := models.Employee{ID: models.NewID(), Username: "user1"}
+	past := time.Now().Add(-time.Minute)
+	scopes := []models.APIKeyScope{models.APIKeyScopeBidsRead}
+
+	principal := func(role models.OrganizationRole) models.Principal {
+		return models.NewEmployeePrincipal(employee).WithMembership(models.OrganizationMember{
+			Organization: organization, Employee: employee, Role: role,
+		})
+	}
+
+	tests := []struct {
+		name      string
+		principal models.Principal
+		data      dto.CreateAPIKeyDTO
+		wantErr   error
+	}{
+		{name: "without name", principal: principal(models.OrganizationRoleOwner), data: dto.CreateAPIKeyDTO{OrganizationID: organization.ID, Scopes: scopes}, wantErr: domain.ErrInvalidArgument},
+		{name: "without scopes", principal: principal(models.OrganizationRoleOwner), data: dto.CreateAPIKeyDTO{OrganizationID: organization.ID, Name: "erp"}, wantErr: domain.ErrInvalidArgument},
+		{name: "expired", principal: principal(models.OrganizationRoleOwner), data: dto.CreateAPIKeyDTO{OrganizationID: organization.ID, Name: "erp", Scopes: scopes, ExpiresAt: &past}, wantErr: domain.ErrInvalidArgument},
+		{name: "not an owner", principal: principal(models.OrganizationRoleProcurementManager), data: dto.CreateAPIKeyDTO{OrganizationID: organization.ID, Name: "erp", Scopes: scopes}, wantErr: domain.ErrForbidden},
+		{name: "other organization", principal: principal(models.OrganizationRoleOwner), data: dto.CreateAPIKeyDTO{OrganizationID: models.NewID(), Name: "erp", Scopes: scopes}, wantErr: domain.ErrForbidden},
+		{name: "by an API key", principal: models.NewOrganizationPrincipal(organization, scopes), data: dto.CreateAPIKeyDTO{OrganizationID: organization.ID, Name: "erp", Scopes: scopes}, wantErr: domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyUseCase := NewAPIKeyUseCase(&memoryAPIKeyRepository{}, singleOrganizationRepository{organization: organization}, nil)
+
+			_, _, err := apiKeyUseCase.Create(auth.WithPrincipal(context.Background(), tt.principal), &tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPIKeyScopesGuardPermissions(t *testing.T) {
+	organization := models.Organization{ID: models.NewID(), Name: "org"}
+	ctx := auth.WithPrincipal(context.Background(), models.NewOrganizationPrincipal(organization, []models.APIKeyScope{models.APIKeyScopeTendersRead}))
+
+	tests := []struct {
+		permission models.Permission
+		wantErr    error
+	}{
+		{permission: models.PermissionTenderRead},
+		{permission: models.PermissionTenderCreate, wantErr: domain.ErrForbidden},
+		{permission: models.PermissionBidRead, wantErr: domain.ErrForbidden},
+		{permission: models.PermissionOrgEdit, wantErr: domain.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.permission.String(), func(t *testing.T) {
+			_, acting, err := actingOrganization(ctx, nil, tt.permission)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && acting.ID != organization.ID {
+				t.Fatalf("acting for %s, want %s", acting.ID, organization.ID)
+			}
+		})
+	}
+}
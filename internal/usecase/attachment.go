@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+)
+
+const defaultAttachmentContentType = "application/octet-stream"
+
+// AttachmentService stores the attachments of tenders and bids.
+// It does not check access, the tender and bid use cases authorize the caller first.
+type AttachmentService struct {
+	attachmentRepo abstraction.AttachmentRepository
+	storage        abstraction.BlobStorage
+
+	maxSize int64
+}
+
+func NewAttachmentService(attachmentRepo abstraction.AttachmentRepository, storage abstraction.BlobStorage, maxSize int64) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		storage:        storage,
+		maxSize:        maxSize,
+	}
+}
+
+// countingWriter counts the bytes written to it
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+// upload stores the content in the blob storage while computing its checksum and links
+// the attachment to the current version of the owner. The blob is removed if that fails.
+func (a *AttachmentService) upload(ctx context.Context, ownerType models.AttachmentOwnerType, ownerID models.ID, uploadedBy *models.ID, data *dto.UploadAttachmentDTO) (models.Attachment, error) {
+	fileName := path.Base(strings.ReplaceAll(data.FileName, "\\", "/"))
+	if fileName == "." || fileName == "/" {
+		return models.Attachment{}, fmt.Errorf("file name is required: %w", domain.ErrInvalidArgument)
+	}
+
+	if data.Size > a.maxSize {
+		return models.Attachment{}, fmt.Errorf("attachment must not be larger than %d bytes: %w", a.maxSize, domain.ErrInvalidArgument)
+	}
+
+	contentType := data.ContentType
+	if contentType == "" {
+		contentType = defaultAttachmentContentType
+	}
+
+	attachment := models.NewAttachment(ownerType, ownerID, fileName, contentType, data.Size, uploadedBy)
+
+	// one byte over the limit is read to detect contents larger than announced
+	hash := sha256.New()
+	counter := &countingWriter{}
+	content := io.TeeReader(io.LimitReader(data.Content, a.maxSize+1), io.MultiWriter(hash, counter))
+
+	err := a.storage.Put(ctx, attachment.StorageKey, content, data.Size, contentType)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	if counter.n > a.maxSize {
+		_ = a.storage.Delete(ctx, attachment.StorageKey)
+		return models.Attachment{}, fmt.Errorf("attachment must not be larger than %d bytes: %w", a.maxSize, domain.ErrInvalidArgument)
+	}
+
+	attachment.Size = counter.n
+	attachment.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	created, err := a.attachmentRepo.Create(ctx, &attachment)
+	if err != nil {
+		_ = a.storage.Delete(ctx, attachment.StorageKey)
+		return models.Attachment{}, err
+	}
+
+	return created, nil
+}
+
+func (a *AttachmentService) list(ctx context.Context, ownerType models.AttachmentOwnerType, ownerID models.ID, version *int) ([]models.Attachment, error) {
+	return a.attachmentRepo.GetByOwner(ctx, ownerType, ownerID, version)
+}
+
+// download returns the attachment and its content, the caller closes the content
+func (a *AttachmentService) download(ctx context.Context, ownerType models.AttachmentOwnerType, ownerID, attachmentID models.ID) (models.Attachment, io.ReadCloser, error) {
+	attachment, err := a.attachmentRepo.GetByID(ctx, attachmentID)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	if attachment.OwnerType != ownerType || attachment.OwnerID != ownerID {
+		return models.Attachment{}, nil, fmt.Errorf("attachment with ID %s not found: %w", attachmentID, domain.ErrNotFound)
+	}
+
+	content, err := a.storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	return attachment, content, nil
+}
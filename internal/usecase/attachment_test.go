@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"testing"
+)
+
+type memoryBlobStorage struct {
+	blobs map[string][]byte
+}
+
+func (m *memoryBlobStorage) Put(_ context.Context, key string, content io.Reader, _ int64, _ string) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	m.blobs[key] = data
+	return nil
+}
+
+func (m *memoryBlobStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	data, ok := m.blobs[key]
+	if !ok {
+		return nil, fmt.Errorf("blob %s not found: %w", key, domain.ErrNotFound)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memoryBlobStorage) Delete(_ context.Context, key string) error {
+	delete(m.blobs, key)
+	return nil
+}
+
+type memoryAttachmentRepository struct {
+	abstraction.AttachmentRepository
+	attachments map[models.ID]models.Attachment
+	createErr   error
+}
+
+func (m *memoryAttachmentRepository) Create(_ context.Context, data *models.Attachment) (models.Attachment, error) {
+	if m.createErr != nil {
+		return models.Attachment{}, m.createErr
+	}
+	m.attachments[data.ID] = *data
+	return *data, nil
+}
+
+func (m *memoryAttachmentRepository) GetByID(_ context.Context, id models.ID) (models.Attachment, error) {
+	attachment, ok := m.attachments[id]
+	if !ok {
+		return models.Attachment{}, fmt.Errorf("attachment with ID %s not found: %w", id, domain.ErrNotFound)
+	}
+	return attachment, nil
+}
+
+func newTestAttachmentService(maxSize int64) (*AttachmentService, *memoryAttachmentRepository, *memoryBlobStorage) {
+	repo := &memoryAttachmentRepository{attachments: map[models.ID]models.Attachment{}}
+	storage := &memoryBlobStorage{blobs: map[string][]byte{}}
+	return NewAttachmentService(repo, storage, maxSize), repo, storage
+}
+
+func TestUploadAttachment(t *testing.T) {
+	service, _, storage := newTestAttachmentService(1024)
+	ctx := context.Background()
+	tenderID := models.NewID()
+
+	content := "tender documentation"
+	attachment, err := service.upload(ctx, models.AttachmentOwnerTypeTender, tenderID, nil, &dto.UploadAttachmentDTO{
+		FileName: `C:\docs\spec.txt`,
+		Size:     -1,
+		Content:  strings.NewReader(content),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	if attachment.FileName != "spec.txt" {
+		t.Errorf("got file name %q, want the base name", attachment.FileName)
+	}
+	if attachment.ContentType != defaultAttachmentContentType {
+		t.Errorf("got content type %q, want %q", attachment.ContentType, defaultAttachmentContentType)
+	}
+	if attachment.Size != int64(len(content)) || attachment.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("got size %d and checksum %s of the content read", attachment.Size, attachment.SHA256)
+	}
+
+	got, body, err := service.download(ctx, models.AttachmentOwnerTypeTender, tenderID, attachment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != attachment.ID || string(data) != content {
+		t.Fatalf("downloaded %q, want the uploaded content", data)
+	}
+
+	// the attachment is not reachable through another owner
+	_, _, err = service.download(ctx, models.AttachmentOwnerTypeBid, tenderID, attachment.ID)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v for another owner type, want not found", err)
+	}
+	_, _, err = service.download(ctx, models.AttachmentOwnerTypeTender, models.NewID(), attachment.ID)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v for another tender, want not found", err)
+	}
+
+	if len(storage.blobs) != 1 {
+		t.Fatalf("got %d blobs, want 1", len(storage.blobs))
+	}
+}
+
+func TestUploadAttachmentLimitsSize(t *testing.T) {
+	tests := []struct {
+		name string
+		size int64
+	}{
+		{name: "announced", size: 11},
+		{name: "unknown", size: -1},
+		{name: "understated", size: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, storage := newTestAttachmentService(10)
+
+			_, err := service.upload(context.Background(), models.AttachmentOwnerTypeBid, models.NewID(), nil, &dto.UploadAttachmentDTO{
+				FileName: "price.csv",
+				Size:     tt.size,
+				Content:  strings.NewReader("0123456789A"),
+			})
+			if !errors.Is(err, domain.ErrInvalidArgument) {
+				t.Fatalf("got %v, want invalid argument", err)
+			}
+			if len(storage.blobs) != 0 || len(repo.attachments) != 0 {
+				t.Fatalf("kept %d blobs and %d attachments of a rejected upload", len(storage.blobs), len(repo.attachments))
+			}
+		})
+	}
+}
+
+func TestUploadAttachmentRemovesBlobOfFailedLink(t *testing.T) {
+	service, repo, storage := newTestAttachmentService(1024)
+	repo.createErr = fmt.Errorf("tender not found: %w", domain.ErrNotFound)
+
+	_, err := service.upload(context.Background(), models.AttachmentOwnerTypeTender, models.NewID(), nil, &dto.UploadAttachmentDTO{
+		FileName: "spec.txt",
+		Size:     4,
+		Content:  strings.NewReader("spec"),
+	})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v, want the repository error", err)
+	}
+	if len(storage.blobs) != 0 {
+		t.Fatalf("kept %d blobs without an attachment", len(storage.blobs))
+	}
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+var _ abstraction.AuthUseCaseInterface = &AuthUseCase{}
+
+const MinPasswordLength = 8
+
+// AuthConfig configures token lifetimes and the login lockout policy
+type AuthConfig struct {
+	RefreshTokenTTL  time.Duration
+	MaxLoginAttempts int
+	LockoutDuration  time.Duration
+}
+
+type AuthUseCase struct {
+	employeeRepo     abstraction.EmployeeRepository
+	credentialRepo   abstraction.CredentialRepository
+	refreshTokenRepo abstraction.RefreshTokenRepository
+
+	tokenManager   abstraction.TokenManager
+	passwordHasher abstraction.PasswordHasher
+
+	config AuthConfig
+}
+
+func NewAuthUseCase(
+	employeeRepo abstraction.EmployeeRepository,
+	credentialRepo abstraction.CredentialRepository,
+	refreshTokenRepo abstraction.RefreshTokenRepository,
+	tokenManager abstraction.TokenManager,
+	passwordHasher abstraction.PasswordHasher,
+	config AuthConfig,
+) *AuthUseCase {
+	return &AuthUseCase{
+		employeeRepo:     employeeRepo,
+		credentialRepo:   credentialRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		tokenManager:     tokenManager,
+		passwordHasher:   passwordHasher,
+		config:           config,
+	}
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("error generating refresh token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (a *AuthUseCase) issueTokenPair(ctx context.Context, employee models.Employee, familyID models.ID) (models.TokenPair, error) {
+	accessToken, accessTokenExpiresAt, err := a.tokenManager.Issue(employee.Username)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	refreshTokenModel := models.NewRefreshToken(employee.ID, familyID, hashRefreshToken(refreshToken), a.config.RefreshTokenTTL)
+
+	_, err = a.refreshTokenRepo.Create(ctx, &refreshTokenModel)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	return models.TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessTokenExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshTokenModel.ExpiresAt,
+	}, nil
+}
+
+func (a *AuthUseCase) Login(ctx context.Context, username, password string) (models.TokenPair, error) {
+	employee, err := a.employeeRepo.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return models.TokenPair{}, models.ErrInvalidCredentials
+		}
+		return models.TokenPair{}, err
+	}
+
+	credentials, err := a.credentialRepo.GetByEmployeeID(ctx, employee.ID)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	now := time.Now()
+
+	if credentials.IsLocked(now) {
+		return models.TokenPair{}, &models.AccountLockedError{Username: employee.Username, LockedUntil: *credentials.LockedUntil}
+	}
+
+	if credentials.PasswordHash == "" {
+		return models.TokenPair{}, models.ErrInvalidCredentials
+	}
+
+	err = a.passwordHasher.Compare(credentials.PasswordHash, password)
+	if err != nil {
+		if !errors.Is(err, domain.ErrUnauthorized) {
+			return models.TokenPair{}, err
+		}
+
+		err = a.credentialRepo.RegisterFailedAttempt(ctx, employee.ID, a.config.MaxLoginAttempts, now.Add(a.config.LockoutDuration))
+		if err != nil {
+			return models.TokenPair{}, err
+		}
+
+		return models.TokenPair{}, models.ErrInvalidCredentials
+	}
+
+	if credentials.FailedLoginAttempts > 0 || credentials.LockedUntil != nil {
+		err = a.credentialRepo.ResetFailedAttempts(ctx, employee.ID)
+		if err != nil {
+			return models.TokenPair{}, err
+		}
+	}
+
+	return a.issueTokenPair(ctx, employee, models.NewID())
+}
+
+func (a *AuthUseCase) Refresh(ctx context.Context, refreshToken string) (models.TokenPair, error) {
+	invalidToken := fmt.Errorf("invalid refresh token: %w", domain.ErrUnauthorized)
+
+	token, err := a.refreshTokenRepo.GetByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return models.TokenPair{}, invalidToken
+		}
+		return models.TokenPair{}, err
+	}
+
+	if token.IsExpired(time.Now()) {
+		return models.TokenPair{}, invalidToken
+	}
+
+	// A revoked token being presented again means it was leaked:
+	// revoke the whole family so the thief's copy stops working too
+	revoked, err := a.refreshTokenRepo.Revoke(ctx, token.ID)
+	if err != nil {
+		return models.TokenPair{}, err
+	}
+
+	if !revoked {
+		err = a.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID)
+		if err != nil {
+			return models.TokenPair{}, err
+		}
+
+		return models.TokenPair{}, fmt.Errorf("refresh token reuse detected: %w", domain.ErrUnauthorized)
+	}
+
+	employee, err := a.employeeRepo.GetByID(ctx, token.EmployeeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return models.TokenPair{}, invalidToken
+		}
+		return models.TokenPair{}, err
+	}
+
+	if !employee.IsActive() {
+		return models.TokenPair{}, invalidToken
+	}
+
+	return a.issueTokenPair(ctx, employee, token.FamilyID)
+}
+
+func (a *AuthUseCase) Logout(ctx context.Context, refreshToken string) error {
+	token, err := a.refreshTokenRepo.GetByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return a.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID)
+}
+
+func (a *AuthUseCase) SetPassword(ctx context.Context, currentPassword, newPassword string) error {
+	u, err := currentEmployee(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(newPassword) < MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters long: %w", MinPasswordLength, domain.ErrInvalidArgument)
+	}
+
+	credentials, err := a.credentialRepo.GetByEmployeeID(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+
+	if credentials.PasswordHash != "" {
+		err = a.passwordHasher.Compare(credentials.PasswordHash, currentPassword)
+		if err != nil {
+			return err
+		}
+	}
+
+	passwordHash, err := a.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return a.credentialRepo.SetPasswordHash(ctx, u.ID, passwordHash)
+}
@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/models"
+	"testing"
+	"time"
+)
+
+// loginEmployeeRepository knows a single employee
+type loginEmployeeRepository struct {
+	abstraction.EmployeeRepository
+
+	employee models.Employee
+}
+
+func (l loginEmployeeRepository) GetByUsername(_ context.Context, username string) (models.Employee, error) {
+	if username != l.employee.Username {
+		return models.Employee{}, domain.ErrNotFound
+	}
+
+	return l.employee, nil
+}
+
+func (l loginEmployeeRepository) GetByID(_ context.Context, id models.ID) (models.Employee, error) {
+	if id != l.employee.ID {
+		return models.Employee{}, domain.ErrNotFound
+	}
+
+	return l.employee, nil
+}
+
+// loginCredentialRepository keeps the credentials of the employee in memory
+type loginCredentialRepository struct {
+	abstraction.CredentialRepository
+
+	credentials models.EmployeeCredentials
+}
+
+func (l *loginCredentialRepository) GetByEmployeeID(_ context.Context, _ models.ID) (models.EmployeeCredentials, error) {
+	return l.credentials, nil
+}
+
+func (l *loginCredentialRepository) SetPasswordHash(_ context.Context, _ models.ID, passwordHash string) error {
+	l.credentials.PasswordHash = passwordHash
+	return nil
+}
+
+func (l *loginCredentialRepository) RegisterFailedAttempt(_ context.Context, _ models.ID, maxAttempts int, lockedUntil time.Time) error {
+	l.credentials.FailedLoginAttempts++
+	if l.credentials.FailedLoginAttempts >= maxAttempts {
+		l.credentials.LockedUntil = &lockedUntil
+	}
+
+	return nil
+}
+
+// memoryRefreshTokenRepository keeps refresh tokens by their hash
+type memoryRefreshTokenRepository struct {
+	abstraction.RefreshTokenRepository
+
+	tokens map[string]*models.RefreshToken
+}
+
+func (m *memoryRefreshTokenRepository) Create(_ context.Context, data *models.RefreshToken) (models.RefreshToken, error) {
+	if m.tokens == nil {
+		m.tokens = make(map[string]*models.RefreshToken)
+	}
+
+	token := *data
+	m.tokens[token.TokenHash] = &token
+
+	return token, nil
+}
+
+func (m *memoryRefreshTokenRepository) GetByHash(_ context.Context, tokenHash string) (models.RefreshToken, error) {
+	token, ok := m.tokens[tokenHash]
+	if !ok {
+		return models.RefreshToken{}, domain.ErrNotFound
+	}
+
+	return *token, nil
+}
+
+func (m *memoryRefreshTokenRepository) Revoke(_ context.Context, id models.ID) (bool, error) {
+	for _, token := range m.tokens {
+		if token.ID == id {
+			if token.IsRevoked() {
+				return false, nil
+			}
+
+			now := time.Now()
+			token.RevokedAt = &now
+			return true, nil
+		}
+	}
+
+	return false, domain.ErrNotFound
+}
+
+func (m *memoryRefreshTokenRepository) RevokeFamily(_ context.Context, familyID models.ID) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.FamilyID == familyID && !token.IsRevoked() {
+			token.RevokedAt = &now
+		}
+	}
+
+	return nil
+}
+
+// subjectTokenManager issues the subject itself as the access token
+type subjectTokenManager struct {
+	abstraction.TokenManager
+}
+
+func (subjectTokenManager) Issue(subject string) (string, time.Time, error) {
+	return subject, time.Now().Add(time.Minute), nil
+}
+
+// plainPasswordHasher stores passwords as they are
+type plainPasswordHasher struct{}
+
+func (plainPasswordHasher) Hash(password string) (string, error) {
+	return password, nil
+}
+
+func (plainPasswordHasher) Compare(hash, password string) error {
+	if hash != password {
+		return domain.ErrUnauthorized
+	}
+
+	return nil
+}
+
+func TestLoginLockedAccountLooksLikeInvalidCredentials(t *testing.T) {
+	employee := models.Employee{ID: models.NewID(), Username: "user1"}
+	credentialRepo := &loginCredentialRepository{credentials: models.EmployeeCredentials{EmployeeID: employee.ID, PasswordHash: "secret"}}
+	authUseCase := NewAuthUseCase(loginEmployeeRepository{employee: employee}, credentialRepo, nil, nil, plainPasswordHasher{},
+		AuthConfig{MaxLoginAttempts: 2, LockoutDuration: time.Hour})
+
+	ctx := context.Background()
+
+	_, unknownErr := authUseCase.Login(ctx, "nobody", "secret")
+	if !errors.Is(unknownErr, domain.ErrUnauthorized) {
+		t.Fatalf("got %v for an unknown username, want unauthorized", unknownErr)
+	}
+
+	for range 2 {
+		_, err := authUseCase.Login(ctx, employee.Username, "wrong")
+		if err == nil || err.Error() != unknownErr.Error() {
+			t.Fatalf("got %v for a wrong password, want %v", err, unknownErr)
+		}
+	}
+
+	// the correct password does not unlock the account either
+	_, err := authUseCase.Login(ctx, employee.Username, "secret")
+	if err == nil || err.Error() != unknownErr.Error() {
+		t.Fatalf("got %v for a locked account, want %v", err, unknownErr)
+	}
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("got %v for a locked account, want unauthorized", err)
+	}
+
+	var lockedErr *models.AccountLockedError
+	if !errors.As(err, &lockedErr) {
+		t.Fatalf("got %v for a locked account, want the lockout to be recorded", err)
+	}
+	if lockedErr.Username != employee.Username || !lockedErr.LockedUntil.Equal(*credentialRepo.credentials.LockedUntil) {
+		t.Fatalf("got lockout of %s until %s", lockedErr.Username, lockedErr.LockedUntil)
+	}
+}
+
+func newTestAuthUseCase(employee models.Employee, password string) (*AuthUseCase, *memoryRefreshTokenRepository) {
+	refreshTokenRepo := &memoryRefreshTokenRepository{}
+	credentialRepo := &loginCredentialRepository{credentials: models.EmployeeCredentials{EmployeeID: employee.ID, PasswordHash: password}}
+
+	authUseCase := NewAuthUseCase(loginEmployeeRepository{employee: employee}, credentialRepo, refreshTokenRepo,
+		subjectTokenManager{}, plainPasswordHasher{}, AuthConfig{RefreshTokenTTL: time.Hour, MaxLoginAttempts: 5, LockoutDuration: time.Hour})
+
+	return authUseCase, refreshTokenRepo
+}
+
+func TestRefreshRotatesTokens(t *testing.T) {
+	employee := models.Employee{ID: models.NewID(), Username: "user1"}
+	authUseCase, _ := newTestAuthUseCase(employee, "secret")
+	ctx := context.Background()
+
+	login, err := authUseCase.Login(ctx, employee.Username, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := authUseCase.Refresh(ctx, login.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if refreshed.RefreshToken == login.RefreshToken {
+		t.Fatal("refresh returned the same refresh token")
+	}
+
+	// the rotated token is spent, presenting it again revokes the whole login
+	_, err = authUseCase.Refresh(ctx, login.RefreshToken)
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("got %v for a reused refresh token, want unauthorized", err)
+	}
+
+	_, err = authUseCase.Refresh(ctx, refreshed.RefreshToken)
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("got %v for a token of a revoked family, want unauthorized", err)
+	}
+}
+
+func TestLogoutRevokesTokenFamily(t *testing.T) {
+	employee := models.Employee{ID: models.NewID(), Username: "user1"}
+	authUseCase, _ := newTestAuthUseCase(employee, "secret")
+	ctx := context.Background()
+
+	login, err := authUseCase.Login(ctx, employee.Username, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := authUseCase.Refresh(ctx, login.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = authUseCase.Logout(ctx, login.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = authUseCase.Refresh(ctx, refreshed.RefreshToken)
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("got %v after logout, want unauthorized", err)
+	}
+
+	err = authUseCase.Logout(ctx, "unknown")
+	if err != nil {
+		t.Fatalf("got %v for an unknown token, want logout to succeed", err)
+	}
+}
+
+func TestRefreshRejectsExpiredToken(t *testing.T) {
+	employee := models.Employee{ID: models.NewID(), Username: "user1"}
+	authUseCase, refreshTokenRepo := newTestAuthUseCase(employee, "secret")
+	ctx := context.Background()
+
+	login, err := authUseCase.Login(ctx, employee.Username, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, token := range refreshTokenRepo.tokens {
+		token.ExpiresAt = time.Now().Add(-time.Second)
+	}
+
+	_, err = authUseCase.Refresh(ctx, login.RefreshToken)
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("got %v for an expired token, want unauthorized", err)
+	}
+}
+
+func TestSetPassword(t *testing.T) {
+	employee := models.Employee{ID: models.NewID(), Username: "user1"}
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(employee))
+
+	tests := []struct {
+		name            string
+		stored          string
+		currentPassword string
+		newPassword     string
+		wantErr         error
+	}{
+		{name: "first password", stored: "", newPassword: "new-secret"},
+		{name: "change", stored: "old-secret", currentPassword: "old-secret", newPassword: "new-secret"},
+		{name: "wrong current password", stored: "old-secret", currentPassword: "wrong", newPassword: "new-secret", wantErr: domain.ErrUnauthorized},
+		{name: "too short", stored: "", newPassword: "short", wantErr: domain.ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authUseCase, _ := newTestAuthUseCase(employee, tt.stored)
+
+			err := authUseCase.SetPassword(ctx, tt.currentPassword, tt.newPassword)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			_, err = authUseCase.Login(context.Background(), employee.Username, tt.newPassword)
+			if err != nil {
+				t.Fatalf("login with the new password: %v", err)
+			}
+		})
+	}
+}
@@ -2,64 +2,82 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"tenderSystem/internal/abstraction"
 	"tenderSystem/internal/domain"
 	"tenderSystem/internal/domain/dto"
 	"tenderSystem/internal/domain/models"
+	"time"
 )
 
 var _ abstraction.BidUseCaseInterface = &BidUseCase{}
 
-const MaxQuorum = 3
-
 type BidUseCase struct {
-	employeeRepo    abstraction.EmployeeRepository
-	tenderRepo      abstraction.TenderRepository
-	bidRepo         abstraction.BidRepository
-	bidFeedbackRepo abstraction.BidFeedbackRepository
-	bidDecisionRepo abstraction.BidDecisionRepository
+	employeeRepo     abstraction.EmployeeRepository
+	organizationRepo abstraction.OrganizationRepository
+	tenderRepo       abstraction.TenderRepository
+	bidRepo          abstraction.BidRepository
+	bidFeedbackRepo  abstraction.BidFeedbackRepository
+	bidDecisionRepo  abstraction.BidDecisionRepository
+
+	transactionManager abstraction.TransactionManager
+
+	attachmentService *AttachmentService
 }
 
 func NewBidUseCase(
 	employeeRepo abstraction.EmployeeRepository,
+	organizationRepo abstraction.OrganizationRepository,
 	tenderRepo abstraction.TenderRepository,
 	bidRepo abstraction.BidRepository,
 	bidFeedbackRepo abstraction.BidFeedbackRepository,
 	bidDecisionRepo abstraction.BidDecisionRepository,
+	transactionManager abstraction.TransactionManager,
+	attachmentService *AttachmentService,
 ) *BidUseCase {
 	return &BidUseCase{
-		employeeRepo:    employeeRepo,
-		tenderRepo:      tenderRepo,
-		bidRepo:         bidRepo,
-		bidFeedbackRepo: bidFeedbackRepo,
-		bidDecisionRepo: bidDecisionRepo,
+		employeeRepo:       employeeRepo,
+		organizationRepo:   organizationRepo,
+		tenderRepo:         tenderRepo,
+		bidRepo:            bidRepo,
+		bidFeedbackRepo:    bidFeedbackRepo,
+		bidDecisionRepo:    bidDecisionRepo,
+		transactionManager: transactionManager,
+		attachmentService:  attachmentService,
 	}
 }
 
-func (b *BidUseCase) checkUserIsBidsAuthor(ctx context.Context, bid models.Bid, u models.Employee) error {
-	if bid.AuthorType == models.BidAuthorTypeUser {
-		if bid.AuthorID != u.ID {
-			return fmt.Errorf("user %s is not the author of bid %s: %w", u.Username, bid.ID, domain.ErrForbidden)
+// checkUserIsAuthor checks that u is the author or, for organization authors,
+// that u acts for it and their role holds the permission
+func (b *BidUseCase) checkUserIsAuthor(ctx context.Context, authorType models.BidAuthorType, authorID models.ID, u models.Employee, permission models.Permission) error {
+	if authorType == models.BidAuthorTypeUser {
+		if authorID != u.ID {
+			return fmt.Errorf("user %s is not the author %s: %w", u.Username, authorID, domain.ErrForbidden)
 		}
 
 		return nil
 	}
 
-	if bid.AuthorType == models.BidAuthorTypeOrganization {
-		organization, err := b.employeeRepo.GetOrganization(ctx, u.ID)
+	if authorType == models.BidAuthorTypeOrganization {
+		_, o, err := actingOrganization(ctx, b.employeeRepo, permission)
 		if err != nil {
 			return err
 		}
 
-		if bid.AuthorID != organization.ID {
-			return fmt.Errorf("organization %s is not the author of bid %s: %w", u.Username, bid.ID, domain.ErrForbidden)
+		if authorID != o.ID {
+			return fmt.Errorf("user %s does not act for organization %s: %w", u.Username, authorID, domain.ErrForbidden)
 		}
 
 		return nil
 	}
 
-	return fmt.Errorf("unknown author type %s: %w", bid.AuthorType, domain.ErrInternal)
+	return fmt.Errorf("unknown author type %s: %w", authorType, domain.ErrInternal)
+}
+
+func (b *BidUseCase) checkUserIsBidsAuthor(ctx context.Context, bid models.Bid, u models.Employee, permission models.Permission) error {
+	return b.checkUserIsAuthor(ctx, bid.AuthorType, bid.AuthorID, u, permission)
 }
 
 func (b *BidUseCase) checkUserHasAccess(ctx context.Context, bid models.Bid, u models.Employee) error {
@@ -67,18 +85,111 @@ func (b *BidUseCase) checkUserHasAccess(ctx context.Context, bid models.Bid, u m
 		return nil
 	}
 
-	if err := b.checkUserIsBidsAuthor(ctx, bid, u); err != nil {
+	if err := b.checkUserIsBidsAuthor(ctx, bid, u, models.PermissionBidRead); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// checkAuthorInvited checks that an invite-only tender is visible to the bid author: an invited
+// employee or a member of an invited organization for user authors, an invited organization otherwise
+func (b *BidUseCase) checkAuthorInvited(ctx context.Context, tenderID models.ID, authorType models.BidAuthorType, authorID models.ID) error {
+	tender, err := b.tenderRepo.GetByID(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	if tender.Visibility != models.TenderVisibilityInviteOnly {
+		return nil
+	}
+
+	viewer := models.TenderViewer{OrganizationIDs: []models.ID{authorID}}
+	if authorType == models.BidAuthorTypeUser {
+		viewer, err = employeeTenderViewer(ctx, b.employeeRepo, authorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	invitees, err := b.tenderRepo.GetInvitees(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	if !tender.IsVisibleTo(viewer, invitees) {
+		return fmt.Errorf("%s %s is not invited to tender %s: %w", authorType, authorID, tenderID, domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// checkTenderAcceptsBid checks that the submission deadline of the tender has not passed,
+// that the lot is open and that the price is in the currency of the lot budget.
+// Without a lot ID the only lot of a single-lot tender is used. Returns the lot.
+func (b *BidUseCase) checkTenderAcceptsBid(ctx context.Context, tenderID models.ID, lotID *models.ID, price *models.Money) (models.Lot, error) {
+	tender, err := b.tenderRepo.GetByID(ctx, tenderID)
+	if err != nil {
+		return models.Lot{}, err
+	}
+
+	err = tender.CheckAcceptsBids(time.Now())
+	if err != nil {
+		return models.Lot{}, err
+	}
+
+	var lot *models.Lot
+	if lotID != nil {
+		lot, err = tender.Lot(*lotID)
+	} else {
+		lot, err = tender.DefaultLot()
+	}
+	if err != nil {
+		return models.Lot{}, err
+	}
+
+	if !lot.IsOpen() {
+		return models.Lot{}, fmt.Errorf("lot %d of tender %s is %s: %w", lot.Number, tenderID, lot.Status, domain.ErrInvalidArgument)
+	}
+
+	err = tender.CheckPriceCurrency(*lot, price)
+	if err != nil {
+		return models.Lot{}, err
+	}
+
+	return *lot, nil
+}
+
 func (b *BidUseCase) Create(ctx context.Context, data *dto.CreateBidDTO) (models.Bid, error) {
+	u, err := currentEmployee(ctx)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	err = b.checkUserIsAuthor(ctx, data.AuthorType, data.AuthorID, u, models.PermissionBidWrite)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	lot, err := b.checkTenderAcceptsBid(ctx, data.TenderID, data.LotID, data.Price)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	err = b.checkAuthorInvited(ctx, data.TenderID, data.AuthorType, data.AuthorID)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
 	bidModel := models.NewBid(
-		data.TenderID, data.AuthorType, data.AuthorID, data.Name, data.Description,
+		data.TenderID, lot.ID, data.AuthorType, data.AuthorID, data.Name, data.Description, data.Price,
 	)
 
+	bidModel.VersionAuthor, err = currentVersionAuthor(ctx)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
 	bid, err := b.bidRepo.Create(ctx, &bidModel)
 	if err != nil {
 		return models.Bid{}, err
@@ -87,9 +198,9 @@ func (b *BidUseCase) Create(ctx context.Context, data *dto.CreateBidDTO) (models
 	return bid, nil
 }
 
-func (b *BidUseCase) GetMy(ctx context.Context, username string, options ...abstraction.PaginationOptFunc) ([]models.Bid, error) {
+func (b *BidUseCase) GetMy(ctx context.Context, options ...abstraction.GetBidsOptFunc) ([]models.Bid, error) {
 	// TODO: Figure out if we need to search only by user or by organization as well (or both)
-	u, err := b.employeeRepo.GetByUsername(ctx, username)
+	u, err := currentEmployee(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -102,24 +213,34 @@ func (b *BidUseCase) GetMy(ctx context.Context, username string, options ...abst
 	return bids, nil
 }
 
-func (b *BidUseCase) GetByTenderID(ctx context.Context, tenderID models.ID, username string, options ...abstraction.PaginationOptFunc) ([]models.Bid, error) {
-	u, err := b.employeeRepo.GetByUsername(ctx, username)
-	if err != nil {
-		return nil, err
-	}
-
-	o, err := b.employeeRepo.GetOrganization(ctx, u.ID)
+// checkUserSeesTenderBids checks that the caller acts for the organization of the tender
+// and that its bids are no longer sealed
+func (b *BidUseCase) checkUserSeesTenderBids(ctx context.Context, tenderID models.ID) error {
+	_, o, err := actingOrganization(ctx, b.employeeRepo, models.PermissionBidRead)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	tender, err := b.tenderRepo.GetByID(ctx, tenderID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if tender.OrganizationID != o.ID {
-		return nil, fmt.Errorf("organization %s is not the author of tender %s: %w", username, tenderID, domain.ErrForbidden)
+		return fmt.Errorf("organization %s is not the author of tender %s: %w", o.Name, tenderID, domain.ErrForbidden)
+	}
+
+	if tender.BidsSealed(time.Now()) {
+		return fmt.Errorf("bids of tender %s are sealed until %s: %w", tenderID, tender.Deadlines.BidOpeningAt.Format(time.RFC3339), domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+func (b *BidUseCase) GetByTenderID(ctx context.Context, tenderID models.ID, options ...abstraction.GetBidsOptFunc) ([]models.Bid, error) {
+	err := b.checkUserSeesTenderBids(ctx, tenderID)
+	if err != nil {
+		return nil, err
 	}
 
 	bids, err := b.bidRepo.GetByTenderID(ctx, tenderID, options...)
@@ -130,27 +251,26 @@ func (b *BidUseCase) GetByTenderID(ctx context.Context, tenderID models.ID, user
 	return bids, nil
 }
 
-func (b *BidUseCase) GetStatus(ctx context.Context, id models.ID, username string) (models.BidStatus, error) {
-	u, err := b.employeeRepo.GetByUsername(ctx, username)
+func (b *BidUseCase) SearchByTenderID(ctx context.Context, tenderID models.ID, text string, options ...abstraction.GetBidsOptFunc) ([]models.BidSearchResult, error) {
+	err := b.checkUserSeesTenderBids(ctx, tenderID)
 	if err != nil {
-		return models.BidStatusUnknown, err
+		return nil, err
 	}
 
-	bid, err := b.bidRepo.GetByID(ctx, id)
-	if err != nil {
-		return models.BidStatusUnknown, err
-	}
+	return b.bidRepo.SearchByTenderID(ctx, tenderID, text, options...)
+}
 
-	err = b.checkUserHasAccess(ctx, bid, u)
+func (b *BidUseCase) GetStatus(ctx context.Context, id models.ID) (models.BidStatus, int, error) {
+	bid, err := b.checkUserReadsBid(ctx, id)
 	if err != nil {
-		return models.BidStatusUnknown, err
+		return models.BidStatusUnknown, 0, err
 	}
 
-	return bid.Status, nil
+	return bid.Status, bid.Revision, nil
 }
 
-func (b *BidUseCase) SetStatus(ctx context.Context, id models.ID, username string, status models.BidStatus) (models.Bid, error) {
-	u, err := b.employeeRepo.GetByUsername(ctx, username)
+func (b *BidUseCase) SetStatus(ctx context.Context, id models.ID, status models.BidStatus, expectedRevisions []int) (models.Bid, error) {
+	u, err := currentEmployee(ctx)
 	if err != nil {
 		return models.Bid{}, err
 	}
@@ -160,9 +280,38 @@ func (b *BidUseCase) SetStatus(ctx context.Context, id models.ID, username strin
 		return models.Bid{}, err
 	}
 
-	err = b.checkUserIsBidsAuthor(ctx, bid, u)
+	err = b.checkUserIsBidsAuthor(ctx, bid, u, models.PermissionBidWrite)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	err = b.transactionManager.Do(ctx, func(ctx context.Context) error {
+		bid, err = b.bidRepo.LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = models.CheckCurrentRevision(bid.Revision, expectedRevisions)
+		if err != nil {
+			return err
+		}
+
+		err = bid.TransitionTo(status)
+		if err != nil {
+			return err
+		}
+
+		// Publishing submits the bid, so it is bound by the submission deadline
+		if status == models.BidStatusPublished {
+			_, err = b.checkTenderAcceptsBid(ctx, bid.TenderID, &bid.LotID, bid.Price)
+			if err != nil {
+				return err
+			}
+		}
 
-	bid, err = b.bidRepo.SetStatus(ctx, id, status)
+		bid, err = b.bidRepo.SetStatus(ctx, id, status)
+		return err
+	})
 	if err != nil {
 		return models.Bid{}, err
 	}
@@ -170,8 +319,54 @@ func (b *BidUseCase) SetStatus(ctx context.Context, id models.ID, username strin
 	return bid, nil
 }
 
-func (b *BidUseCase) Update(ctx context.Context, id models.ID, username string, data *dto.UpdateBidDTO) (models.Bid, error) {
-	u, err := b.employeeRepo.GetByUsername(ctx, username)
+// GetAllowedStatuses returns the current status of the bid and the statuses the caller may move it to:
+// authors may publish or cancel it, the tender organization may approve or reject it by decisions
+func (b *BidUseCase) GetAllowedStatuses(ctx context.Context, id models.ID) (models.BidStatus, []models.BidStatus, error) {
+	u, err := currentEmployee(ctx)
+	if err != nil {
+		return models.BidStatusUnknown, nil, err
+	}
+
+	bid, err := b.bidRepo.GetByID(ctx, id)
+	if err != nil {
+		return models.BidStatusUnknown, nil, err
+	}
+
+	err = b.checkUserHasAccess(ctx, bid, u)
+	if err != nil {
+		return models.BidStatusUnknown, nil, err
+	}
+
+	allowed := make([]models.BidStatus, 0)
+
+	err = b.checkUserIsBidsAuthor(ctx, bid, u, models.PermissionBidWrite)
+	switch {
+	case err == nil:
+		allowed = append(allowed, bid.Status.AuthorNextStatuses()...)
+	case !errors.Is(err, domain.ErrForbidden):
+		return models.BidStatusUnknown, nil, err
+	}
+
+	tender, err := b.tenderRepo.GetByID(ctx, bid.TenderID)
+	if err != nil {
+		return models.BidStatusUnknown, nil, err
+	}
+
+	_, o, err := actingOrganization(ctx, b.employeeRepo, models.PermissionBidDecide)
+	switch {
+	case err == nil:
+		if validateSubmitDecision(tender, bid, o) == nil {
+			allowed = append(allowed, bid.Status.DecisionNextStatuses()...)
+		}
+	case !errors.Is(err, domain.ErrForbidden):
+		return models.BidStatusUnknown, nil, err
+	}
+
+	return bid.Status, allowed, nil
+}
+
+func (b *BidUseCase) Update(ctx context.Context, id models.ID, data *dto.UpdateBidDTO, expectedRevisions []int) (models.Bid, error) {
+	u, err := currentEmployee(ctx)
 	if err != nil {
 		return models.Bid{}, err
 	}
@@ -181,12 +376,36 @@ func (b *BidUseCase) Update(ctx context.Context, id models.ID, username string,
 		return models.Bid{}, err
 	}
 
-	err = b.checkUserIsBidsAuthor(ctx, bid, u)
+	err = b.checkUserIsBidsAuthor(ctx, bid, u, models.PermissionBidWrite)
 	if err != nil {
 		return models.Bid{}, err
 	}
 
-	latestVersion, err := b.bidRepo.GetLatestVersionNumber(ctx, id)
+	// the bid stays locked from the version check to the insert of the new version
+	err = b.transactionManager.Do(ctx, func(ctx context.Context) error {
+		bid, err = b.bidRepo.LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = models.CheckCurrentRevision(bid.Revision, expectedRevisions)
+		if err != nil {
+			return err
+		}
+
+		bid, err = b.update(ctx, bid, data)
+		return err
+	})
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	return bid, nil
+}
+
+// update saves the data as a new version of the locked bid
+func (b *BidUseCase) update(ctx context.Context, bid models.Bid, data *dto.UpdateBidDTO) (models.Bid, error) {
+	latestVersion, err := b.bidRepo.GetLatestVersionNumber(ctx, bid.ID)
 	if err != nil {
 		return models.Bid{}, err
 	}
@@ -194,6 +413,7 @@ func (b *BidUseCase) Update(ctx context.Context, id models.ID, username string,
 	var input struct {
 		Name        string
 		Description string
+		Price       *models.Money
 	}
 	{
 		if data.Name != nil {
@@ -207,13 +427,31 @@ func (b *BidUseCase) Update(ctx context.Context, id models.ID, username string,
 		} else {
 			input.Description = bid.Description
 		}
+
+		if data.Price != nil {
+			input.Price = data.Price
+		} else {
+			input.Price = bid.Price
+		}
+	}
+
+	_, err = b.checkTenderAcceptsBid(ctx, bid.TenderID, &bid.LotID, input.Price)
+	if err != nil {
+		return models.Bid{}, err
 	}
 
 	bid.Name = input.Name
 	bid.Description = input.Description
+	bid.Price = input.Price
 	bid.Version = latestVersion + 1
+	bid.RolledBackFrom = nil
 
-	_, err = b.bidRepo.Update(ctx, id, &bid)
+	bid.VersionAuthor, err = currentVersionAuthor(ctx)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	_, err = b.bidRepo.Update(ctx, bid.ID, &bid)
 	if err != nil {
 		return models.Bid{}, err
 	}
@@ -221,13 +459,32 @@ func (b *BidUseCase) Update(ctx context.Context, id models.ID, username string,
 	return bid, nil
 }
 
-func (b *BidUseCase) getQuorum(ctx context.Context, tenderID models.ID) (int, error) {
-	employees, err := b.employeeRepo.GetByOrganizationID(ctx, tenderID)
+// isQuorumReached applies the tender's quorum policy to the approvals of the bid.
+// Voters are the tender organization's members whose role may decide on bids.
+func (b *BidUseCase) isQuorumReached(ctx context.Context, tender models.Tender, decisions []models.BidDecision) (bool, error) {
+	members, err := b.organizationRepo.GetMembers(ctx, tender.OrganizationID)
 	if err != nil {
-		return 0, err
+		return false, err
 	}
 
-	return min(MaxQuorum, len(employees)), nil
+	roles := make(map[models.ID]models.OrganizationRole, len(members))
+	voters := make([]models.OrganizationRole, 0, len(members))
+	for _, member := range members {
+		if member.Role.Can(models.PermissionBidDecide) {
+			roles[member.Employee.ID] = member.Role
+			voters = append(voters, member.Role)
+		}
+	}
+
+	approvals := make([]models.OrganizationRole, 0, len(decisions))
+	for _, decision := range decisions {
+		role, ok := roles[decision.EmployeeID]
+		if ok && decision.Decision == models.BidDecisionTypeApproved {
+			approvals = append(approvals, role)
+		}
+	}
+
+	return tender.QuorumPolicy.IsReached(approvals, voters), nil
 }
 
 func validateSubmitDecision(tender models.Tender, bid models.Bid, userOrganization models.Organization) error {
@@ -243,84 +500,129 @@ func validateSubmitDecision(tender models.Tender, bid models.Bid, userOrganizati
 		return fmt.Errorf("organization %s is not the author of tender %s: %w", userOrganization.Name, tender.ID, domain.ErrForbidden)
 	}
 
-	return nil
+	lot, err := tender.Lot(bid.LotID)
+	if err != nil {
+		return err
+	}
+
+	if !lot.IsOpen() {
+		return fmt.Errorf("lot %d of tender %s is %s: %w", lot.Number, tender.ID, lot.Status, domain.ErrInvalidArgument)
+	}
+
+	return tender.CheckAcceptsDecisions(time.Now())
 }
 
-func (b *BidUseCase) checkTenderDecision(ctx context.Context, tender models.Tender, bid models.Bid, decision models.BidDecisionType) error {
+// checkTenderDecision applies the outcome of the decision: a rejection rejects the bid,
+// reaching the tender's quorum approves the bid and awards its lot. The tender is closed
+// once none of its lots is open. Must run while the tender is locked.
+func (b *BidUseCase) checkTenderDecision(ctx context.Context, tender models.Tender, bid models.Bid, decision models.BidDecisionType) (models.Bid, error) {
 	if decision == models.BidDecisionTypeRejected {
-		bid.Status = models.BidStatusRejected
-		_, err := b.bidRepo.Update(ctx, bid.ID, &bid)
+		err := bid.Reject()
 		if err != nil {
-			return err
+			return models.Bid{}, err
 		}
 
-		return nil
+		return b.bidRepo.SetStatus(ctx, bid.ID, bid.Status)
 	}
 
-	quorum, err := b.getQuorum(ctx, tender.ID)
+	decisions, err := b.bidDecisionRepo.GetByBidID(ctx, bid.ID)
 	if err != nil {
-		return err
+		return models.Bid{}, err
 	}
 
-	decisions, err := b.bidDecisionRepo.GetByBidID(ctx, bid.ID)
+	reached, err := b.isQuorumReached(ctx, tender, decisions)
 	if err != nil {
-		return err
+		return models.Bid{}, err
 	}
 
-	if len(decisions) >= quorum {
-		bid.Status = models.BidStatusApproved
-		_, err = b.bidRepo.Update(ctx, bid.ID, &bid)
-		if err != nil {
-			return err
-		}
+	if !reached {
+		return bid, nil
+	}
 
-		tender.Status = models.TenderStatusClosed
-		_, err = b.tenderRepo.Update(ctx, tender.ID, &tender)
-		if err != nil {
-			return err
-		}
+	err = bid.Approve()
+	if err != nil {
+		return models.Bid{}, err
 	}
 
-	return nil
-}
+	lot, err := tender.Lot(bid.LotID)
+	if err != nil {
+		return models.Bid{}, err
+	}
 
-func (b *BidUseCase) SubmitDecision(ctx context.Context, id models.ID, username string, decision models.BidDecisionType) (models.Bid, error) {
-	// Get data
-	u, err := b.employeeRepo.GetByUsername(ctx, username)
+	err = lot.Award(bid.ID)
 	if err != nil {
 		return models.Bid{}, err
 	}
 
-	o, err := b.employeeRepo.GetOrganization(ctx, u.ID)
+	bid, err = b.bidRepo.SetStatus(ctx, bid.ID, bid.Status)
 	if err != nil {
 		return models.Bid{}, err
 	}
 
-	bid, err := b.bidRepo.GetByID(ctx, id)
+	err = b.tenderRepo.SetLotStatus(ctx, lot.ID, lot.Status, lot.AwardedBidID)
 	if err != nil {
 		return models.Bid{}, err
 	}
 
-	tender, err := b.tenderRepo.GetByID(ctx, bid.TenderID)
+	if !tender.AllLotsFinished() {
+		return bid, nil
+	}
+
+	err = closeTender(ctx, b.tenderRepo, &tender)
 	if err != nil {
 		return models.Bid{}, err
 	}
 
-	// Validate
-	err = validateSubmitDecision(tender, bid, o)
+	return bid, nil
+}
+
+func (b *BidUseCase) SubmitDecision(ctx context.Context, id models.ID, decision models.BidDecisionType) (models.Bid, error) {
+	// Get data
+	u, err := currentEmployee(ctx)
 	if err != nil {
 		return models.Bid{}, err
 	}
 
-	decisionModel := models.NewBidDecision(bid.ID, u.ID, decision)
+	_, o, err := actingOrganization(ctx, b.employeeRepo, models.PermissionBidDecide)
+	if err != nil {
+		return models.Bid{}, err
+	}
 
-	_, err = b.bidDecisionRepo.Create(ctx, &decisionModel)
+	bid, err := b.bidRepo.GetByID(ctx, id)
 	if err != nil {
 		return models.Bid{}, err
 	}
 
-	// Check if all decisions are made
-	err = b.checkTenderDecision(ctx, tender, bid, decision)
+	// Decisions on bids of one tender are serialized by the tender row lock,
+	// so the quorum is counted on fresh data and only one bid can win
+	err = b.transactionManager.Do(ctx, func(ctx context.Context) error {
+		tender, err := b.tenderRepo.LockByID(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+
+		bid, err = b.bidRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// Validate
+		err = validateSubmitDecision(tender, bid, o)
+		if err != nil {
+			return err
+		}
+
+		decisionModel := models.NewBidDecision(bid.ID, u.ID, decision)
+
+		_, err = b.bidDecisionRepo.Create(ctx, &decisionModel)
+		if err != nil {
+			return err
+		}
+
+		// Check if all decisions are made
+		bid, err = b.checkTenderDecision(ctx, tender, bid, decision)
+		return err
+	})
 	if err != nil {
 		return models.Bid{}, err
 	}
@@ -328,13 +630,13 @@ func (b *BidUseCase) SubmitDecision(ctx context.Context, id models.ID, username
 	return bid, nil
 }
 
-func (b *BidUseCase) LeaveFeedback(ctx context.Context, bidID models.ID, username string, feedback string) (models.Bid, error) {
-	u, err := b.employeeRepo.GetByUsername(ctx, username)
+func (b *BidUseCase) LeaveFeedback(ctx context.Context, bidID models.ID, feedback string) (models.Bid, error) {
+	u, err := currentEmployee(ctx)
 	if err != nil {
 		return models.Bid{}, err
 	}
 
-	o, err := b.employeeRepo.GetOrganization(ctx, u.ID)
+	_, o, err := actingOrganization(ctx, b.employeeRepo, models.PermissionBidFeedback)
 	if err != nil {
 		return models.Bid{}, err
 	}
@@ -350,7 +652,7 @@ func (b *BidUseCase) LeaveFeedback(ctx context.Context, bidID models.ID, usernam
 	}
 
 	if tender.OrganizationID != o.ID {
-		return models.Bid{}, fmt.Errorf("organization %s is not the author of tender %s: %w", username, tender.ID, domain.ErrForbidden)
+		return models.Bid{}, fmt.Errorf("organization %s is not the author of tender %s: %w", o.Name, tender.ID, domain.ErrForbidden)
 	}
 
 	feedbackModel := models.NewBidFeedback(bidID, feedback, u.ID)
@@ -362,8 +664,8 @@ func (b *BidUseCase) LeaveFeedback(ctx context.Context, bidID models.ID, usernam
 	return bid, nil
 }
 
-func (b *BidUseCase) Rollback(ctx context.Context, id models.ID, username string, version int) (models.Bid, error) {
-	u, err := b.employeeRepo.GetByUsername(ctx, username)
+func (b *BidUseCase) Rollback(ctx context.Context, id models.ID, version int, expectedRevisions []int) (models.Bid, error) {
+	u, err := currentEmployee(ctx)
 	if err != nil {
 		return models.Bid{}, err
 	}
@@ -373,26 +675,101 @@ func (b *BidUseCase) Rollback(ctx context.Context, id models.ID, username string
 		return models.Bid{}, err
 	}
 
-	err = b.checkUserIsBidsAuthor(ctx, bid, u)
+	err = b.checkUserIsBidsAuthor(ctx, bid, u, models.PermissionBidWrite)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	_, err = b.checkTenderAcceptsBid(ctx, bid.TenderID, &bid.LotID, nil)
 	if err != nil {
 		return models.Bid{}, err
 	}
 
-	newBid, err := b.bidRepo.Rollback(ctx, id, version)
+	author, err := currentVersionAuthor(ctx)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	err = b.transactionManager.Do(ctx, func(ctx context.Context) error {
+		bid, err = b.bidRepo.LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = models.CheckCurrentRevision(bid.Revision, expectedRevisions)
+		if err != nil {
+			return err
+		}
+
+		bid, err = b.bidRepo.Rollback(ctx, id, version, author)
+		return err
+	})
 	if err != nil {
 		return models.Bid{}, err
 	}
 
-	return newBid, nil
+	return bid, nil
 }
 
-func (b *BidUseCase) validateGetAuthorsFeedback(ctx context.Context, tenderID models.ID, requesterUsername, authorUsername string) error {
-	requester, err := b.employeeRepo.GetByUsername(ctx, requesterUsername)
+// checkUserReadsBid returns the bid when the caller has access to it, like GetStatus
+func (b *BidUseCase) checkUserReadsBid(ctx context.Context, id models.ID) (models.Bid, error) {
+	u, err := currentEmployee(ctx)
 	if err != nil {
-		return err
+		return models.Bid{}, err
+	}
+
+	bid, err := b.bidRepo.GetByID(ctx, id)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	err = b.checkUserHasAccess(ctx, bid, u)
+	if err != nil {
+		return models.Bid{}, err
 	}
 
-	requesterOrganization, err := b.employeeRepo.GetOrganization(ctx, requester.ID)
+	return bid, nil
+}
+
+func (b *BidUseCase) GetVersions(ctx context.Context, id models.ID, options ...abstraction.PaginationOptFunc) ([]models.Bid, error) {
+	_, err := b.checkUserReadsBid(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.bidRepo.GetVersions(ctx, id, options...)
+}
+
+func (b *BidUseCase) GetVersion(ctx context.Context, id models.ID, version int) (models.Bid, error) {
+	_, err := b.checkUserReadsBid(ctx, id)
+	if err != nil {
+		return models.Bid{}, err
+	}
+
+	return b.bidRepo.GetSpecificVersion(ctx, id, version)
+}
+
+func (b *BidUseCase) Diff(ctx context.Context, id models.ID, from, to int) ([]models.FieldChange, error) {
+	_, err := b.checkUserReadsBid(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	fromBid, err := b.bidRepo.GetSpecificVersion(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toBid, err := b.bidRepo.GetSpecificVersion(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.DiffBidVersions(fromBid, toBid), nil
+}
+
+func (b *BidUseCase) validateGetAuthorsFeedback(ctx context.Context, tenderID models.ID, authorUsername string) error {
+	_, requesterOrganization, err := actingOrganization(ctx, b.employeeRepo, models.PermissionBidRead)
 	if err != nil {
 		return err
 	}
@@ -403,7 +780,7 @@ func (b *BidUseCase) validateGetAuthorsFeedback(ctx context.Context, tenderID mo
 	}
 
 	if tender.OrganizationID != requesterOrganization.ID {
-		return fmt.Errorf("organization %s is not the author of tender %s: %w", requesterUsername, tenderID, domain.ErrForbidden)
+		return fmt.Errorf("organization %s is not the author of tender %s: %w", requesterOrganization.Name, tenderID, domain.ErrForbidden)
 	}
 
 	author, err := b.employeeRepo.GetByUsername(ctx, authorUsername)
@@ -411,11 +788,16 @@ func (b *BidUseCase) validateGetAuthorsFeedback(ctx context.Context, tenderID mo
 		return err
 	}
 
-	authorOrganization, err := b.employeeRepo.GetOrganization(ctx, author.ID)
+	authorMemberships, err := b.employeeRepo.GetMemberships(ctx, author.ID)
 	if err != nil {
 		return err
 	}
 
+	authorOrganizations := make(map[models.ID]struct{}, len(authorMemberships))
+	for _, member := range authorMemberships {
+		authorOrganizations[member.Organization.ID] = struct{}{}
+	}
+
 	bids, err := b.bidRepo.GetByTenderID(ctx, tenderID)
 	if err != nil {
 		return err
@@ -441,7 +823,7 @@ func (b *BidUseCase) validateGetAuthorsFeedback(ctx context.Context, tenderID mo
 			}
 		}
 		if bid.AuthorType == models.BidAuthorTypeOrganization {
-			if bid.AuthorID == authorOrganization.ID {
+			if _, ok := authorOrganizations[bid.AuthorID]; ok {
 				matched = true
 				break
 			}
@@ -455,8 +837,8 @@ func (b *BidUseCase) validateGetAuthorsFeedback(ctx context.Context, tenderID mo
 	return nil
 }
 
-func (b *BidUseCase) GetAuthorsFeedback(ctx context.Context, tenderID models.ID, requesterUsername, authorUsername string, options ...abstraction.PaginationOptFunc) ([]models.BidFeedback, error) {
-	err := b.validateGetAuthorsFeedback(ctx, tenderID, requesterUsername, authorUsername)
+func (b *BidUseCase) GetAuthorsFeedback(ctx context.Context, tenderID models.ID, authorUsername string, options ...abstraction.PaginationOptFunc) ([]models.BidFeedback, error) {
+	err := b.validateGetAuthorsFeedback(ctx, tenderID, authorUsername)
 	if err != nil {
 		return nil, err
 	}
@@ -473,3 +855,81 @@ func (b *BidUseCase) GetAuthorsFeedback(ctx context.Context, tenderID models.ID,
 
 	return feedbacks, nil
 }
+
+// UploadAttachment attaches the document to the current version of the bid while the tender accepts bids
+func (b *BidUseCase) UploadAttachment(ctx context.Context, id models.ID, data *dto.UploadAttachmentDTO) (models.Attachment, error) {
+	u, err := currentEmployee(ctx)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	bid, err := b.bidRepo.GetByID(ctx, id)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	err = b.checkUserIsBidsAuthor(ctx, bid, u, models.PermissionBidWrite)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	_, err = b.checkTenderAcceptsBid(ctx, bid.TenderID, &bid.LotID, nil)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	return b.attachmentService.upload(ctx, models.AttachmentOwnerTypeBid, id, &u.ID, data)
+}
+
+// checkAttachmentAccess checks that the caller may read the bid and, unless they are
+// its author, that the bids of the tender are not sealed
+func (b *BidUseCase) checkAttachmentAccess(ctx context.Context, id models.ID) error {
+	u, err := currentEmployee(ctx)
+	if err != nil {
+		return err
+	}
+
+	bid, err := b.bidRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = b.checkUserHasAccess(ctx, bid, u)
+	if err != nil {
+		return err
+	}
+
+	if b.checkUserIsBidsAuthor(ctx, bid, u, models.PermissionBidRead) == nil {
+		return nil
+	}
+
+	tender, err := b.tenderRepo.GetByID(ctx, bid.TenderID)
+	if err != nil {
+		return err
+	}
+
+	if tender.BidsSealed(time.Now()) {
+		return fmt.Errorf("bids of tender %s are sealed until %s: %w", tender.ID, tender.Deadlines.BidOpeningAt.Format(time.RFC3339), domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// GetAttachments returns the attachments of the bid version, of the current one when version is nil
+func (b *BidUseCase) GetAttachments(ctx context.Context, id models.ID, version *int) ([]models.Attachment, error) {
+	err := b.checkAttachmentAccess(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.attachmentService.list(ctx, models.AttachmentOwnerTypeBid, id, version)
+}
+
+func (b *BidUseCase) DownloadAttachment(ctx context.Context, id, attachmentID models.ID) (models.Attachment, io.ReadCloser, error) {
+	err := b.checkAttachmentAccess(ctx, id)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	return b.attachmentService.download(ctx, models.AttachmentOwnerTypeBid, id, attachmentID)
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"tenderSystem/internal/infrastructure/postgres/postgrestest"
+	"tenderSystem/internal/infrastructure/repositories/bid"
+	"tenderSystem/internal/infrastructure/repositories/bid/decision"
+	"tenderSystem/internal/infrastructure/repositories/bid/feedback"
+	"tenderSystem/internal/infrastructure/repositories/employee"
+	"tenderSystem/internal/infrastructure/repositories/organization"
+	"tenderSystem/internal/infrastructure/repositories/tender"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// closeCountingTenderRepository counts how many times tenders are closed
+type closeCountingTenderRepository struct {
+	abstraction.TenderRepository
+
+	closed atomic.Int32
+}
+
+func (c *closeCountingTenderRepository) SetStatus(ctx context.Context, id models.ID, status models.TenderStatus) (models.Tender, error) {
+	updated, err := c.TenderRepository.SetStatus(ctx, id, status)
+	if err == nil && status == models.TenderStatusClosed {
+		c.closed.Add(1)
+	}
+
+	return updated, err
+}
+
+// decisionFixture is a published single-lot tender with published bids, the owner and evaluators of the tender organization
+type decisionFixture struct {
+	db         *postgres.DB
+	tenderRepo *closeCountingTenderRepository
+	useCase    *BidUseCase
+
+	tender     models.Tender
+	bids       []models.Bid
+	owner      models.Employee
+	evaluators []models.Employee
+}
+
+func newDecisionFixture(t *testing.T, quorumPolicy models.QuorumPolicy, bidCount, evaluatorCount int) *decisionFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	db := postgrestest.New(t)
+
+	tenderRepo := &closeCountingTenderRepository{TenderRepository: tender.NewPGXRepository(db)}
+	bidRepo := bid.NewPGXRepository(db)
+
+	f := &decisionFixture{
+		db:         db,
+		tenderRepo: tenderRepo,
+		useCase: NewBidUseCase(
+			employee.NewPGXRepository(db), organization.NewPGXRepository(db), tenderRepo, bidRepo, feedback.NewPGXRepository(db), decision.NewPGXRepository(db),
+			postgres.NewTransactionManager(db), nil,
+		),
+	}
+
+	customer, owner := createTestOrganization(t, db, "customer")
+	supplier, _ := createTestOrganization(t, db, "supplier")
+	f.owner = owner
+
+	for i := 0; i < evaluatorCount; i++ {
+		f.evaluators = append(f.evaluators, createTestEmployee(t, db, customer.ID, fmt.Sprintf("evaluator_%d", i), models.OrganizationRoleEvaluator))
+	}
+
+	f.tender = createTestTender(t, tenderRepo, customer.ID, quorumPolicy)
+
+	for i := 0; i < bidCount; i++ {
+		bidModel := models.NewBid(
+			f.tender.ID, f.tender.Lots[0].ID, models.BidAuthorTypeOrganization, supplier.ID, fmt.Sprintf("bid %d", i), "description", nil,
+		)
+		_, err := bidRepo.Create(ctx, &bidModel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := bidRepo.SetStatus(ctx, bidModel.ID, models.BidStatusPublished)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.bids = append(f.bids, b)
+	}
+
+	return f
+}
+
+// submitInParallel sends the decisions at once and returns how many succeeded
+func (f *decisionFixture) submitInParallel(t *testing.T, voters []models.Employee, bids []models.Bid, decisionType models.BidDecisionType) int {
+	t.Helper()
+
+	var (
+		wg        sync.WaitGroup
+		start     = make(chan struct{})
+		succeeded atomic.Int32
+	)
+
+	for i := range voters {
+		wg.Add(1)
+		go func(voter models.Employee, b models.Bid) {
+			defer wg.Done()
+
+			ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(voter))
+			<-start
+
+			_, err := f.useCase.SubmitDecision(ctx, b.ID, decisionType)
+			if err == nil {
+				succeeded.Add(1)
+			}
+		}(voters[i], bids[i])
+	}
+
+	close(start)
+	wg.Wait()
+
+	return int(succeeded.Load())
+}
+
+func (f *decisionFixture) countDecisions(t *testing.T) int {
+	t.Helper()
+
+	var count int
+	err := f.db.QueryRow(context.Background(), `
+		SELECT COUNT(*) FROM bid_decision d JOIN bid b ON b.id = d.bid_id WHERE b.tender_id = $1
+	`, uuid.UUID(f.tender.ID)).Scan(&count)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return count
+}
+
+func TestSubmitDecisionParallelApprovalsAwardOneBid(t *testing.T) {
+	const n = 8
+
+	policy, err := models.NewQuorumPolicy(models.QuorumPolicyFixed, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := newDecisionFixture(t, policy, n, n)
+
+	// every evaluator approves another bid, any approval reaches the quorum
+	succeeded := f.submitInParallel(t, f.evaluators, f.bids, models.BidDecisionTypeApproved)
+	if succeeded != 1 {
+		t.Fatalf("%d decisions succeeded, want 1", succeeded)
+	}
+
+	if count := f.countDecisions(t); count != 1 {
+		t.Fatalf("%d decisions stored, want 1", count)
+	}
+
+	ctx := context.Background()
+	var approved []models.ID
+	for _, b := range f.bids {
+		stored, err := f.useCase.bidRepo.GetByID(ctx, b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status == models.BidStatusApproved {
+			approved = append(approved, stored.ID)
+		}
+	}
+	if len(approved) != 1 {
+		t.Fatalf("%d bids approved, want 1", len(approved))
+	}
+
+	tenderModel, err := f.tenderRepo.GetByID(ctx, f.tender.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tenderModel.Status != models.TenderStatusClosed {
+		t.Fatalf("tender is %s, want closed", tenderModel.Status)
+	}
+	if awarded := tenderModel.Lots[0].AwardedBidID; awarded == nil || *awarded != approved[0] {
+		t.Fatalf("lot awarded to %v, want %s", awarded, approved[0])
+	}
+	if closed := f.tenderRepo.closed.Load(); closed != 1 {
+		t.Fatalf("tender closed %d times, want 1", closed)
+	}
+}
+
+func TestSubmitDecisionParallelVotesOfOneEmployee(t *testing.T) {
+	const n = 8
+
+	// the quorum is not reached by a single evaluator voting many times
+	f := newDecisionFixture(t, models.DefaultQuorumPolicy(), 1, 3)
+
+	voters := make([]models.Employee, n)
+	bids := make([]models.Bid, n)
+	for i := range voters {
+		voters[i] = f.evaluators[0]
+		bids[i] = f.bids[0]
+	}
+
+	succeeded := f.submitInParallel(t, voters, bids, models.BidDecisionTypeApproved)
+	if succeeded != 1 {
+		t.Fatalf("%d decisions succeeded, want 1", succeeded)
+	}
+
+	if count := f.countDecisions(t); count != 1 {
+		t.Fatalf("%d decisions stored, want 1", count)
+	}
+
+	stored, err := f.useCase.bidRepo.GetByID(context.Background(), f.bids[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != models.BidStatusPublished {
+		t.Fatalf("bid is %s, want published", stored.Status)
+	}
+	if closed := f.tenderRepo.closed.Load(); closed != 0 {
+		t.Fatalf("tender closed %d times, want 0", closed)
+	}
+}
+
+func TestQuorumIgnoresDeactivatedVoters(t *testing.T) {
+	policy, err := models.NewQuorumPolicy(models.QuorumPolicyUnanimity, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := newDecisionFixture(t, policy, 1, 2)
+
+	// the deactivated evaluator can't vote, so unanimity is reached without them
+	err = employee.NewPGXRepository(f.db).Deactivate(context.Background(), f.evaluators[1].ID, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, voter := range []models.Employee{f.owner, f.evaluators[0]} {
+		ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(voter))
+		_, err = f.useCase.SubmitDecision(ctx, f.bids[0].ID, models.BidDecisionTypeApproved)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stored, err := f.useCase.bidRepo.GetByID(context.Background(), f.bids[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != models.BidStatusApproved {
+		t.Fatalf("bid is %s, want approved by the active voters", stored.Status)
+	}
+}
+
+func TestBidTransitionsOfTenderOrganization(t *testing.T) {
+	f := newDecisionFixture(t, models.DefaultQuorumPolicy(), 1, 1)
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(f.evaluators[0]))
+	bidModel := f.bids[0]
+
+	status, allowed, err := f.useCase.GetAllowedStatuses(ctx, bidModel.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != models.BidStatusPublished || !slices.Equal(allowed, []models.BidStatus{models.BidStatusApproved, models.BidStatusRejected}) {
+		t.Fatalf("got %s with %v, want decisions on the published bid", status, allowed)
+	}
+
+	// the status endpoint belongs to the bid author, the tender organization approves by decisions
+	_, err = f.useCase.SetStatus(ctx, bidModel.ID, models.BidStatusApproved, nil)
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("got %v setting the approved status directly, want forbidden", err)
+	}
+
+	stored, err := f.useCase.bidRepo.GetByID(context.Background(), bidModel.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != models.BidStatusPublished {
+		t.Fatalf("bid is %s, want published", stored.Status)
+	}
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+)
+
+var _ abstraction.CategoryUseCaseInterface = &CategoryUseCase{}
+
+type CategoryUseCase struct {
+	categoryRepo abstraction.CategoryRepository
+}
+
+func NewCategoryUseCase(categoryRepo abstraction.CategoryRepository) *CategoryUseCase {
+	return &CategoryUseCase{categoryRepo: categoryRepo}
+}
+
+func (c *CategoryUseCase) GetChildren(ctx context.Context, parentCode *models.CategoryCode) ([]models.Category, error) {
+	if parentCode != nil {
+		// unknown parents are reported instead of returning an empty list
+		_, err := c.categoryRepo.GetByCode(ctx, *parentCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.categoryRepo.GetChildren(ctx, parentCode)
+}
+
+func (c *CategoryUseCase) Get(ctx context.Context, code models.CategoryCode) (models.Category, []models.Category, error) {
+	path, err := c.categoryRepo.GetPath(ctx, code)
+	if err != nil {
+		return models.Category{}, nil, err
+	}
+
+	return path[len(path)-1], path, nil
+}
+
+// Import is meant for operators loading a classifier file, so it does not check the caller.
+// Categories of the file may refer to each other and to the existing ones in any order.
+func (c *CategoryUseCase) Import(ctx context.Context, categories []dto.ImportCategoryDTO) (int, error) {
+	existing, err := c.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	parents := make(map[models.CategoryCode]*models.CategoryCode, len(existing)+len(categories))
+	for _, category := range existing {
+		parents[category.Code] = category.ParentCode
+	}
+
+	imported := make([]models.Category, 0, len(categories))
+	seen := make(map[models.CategoryCode]struct{}, len(categories))
+	for i, data := range categories {
+		code, err := models.NewCategoryCode(data.Code)
+		if err != nil {
+			return 0, fmt.Errorf("category %d: %w", i+1, err)
+		}
+
+		if _, ok := seen[code]; ok {
+			return 0, fmt.Errorf("category %s is listed twice: %w", code, domain.ErrInvalidArgument)
+		}
+		seen[code] = struct{}{}
+
+		var parentCode *models.CategoryCode
+		if data.ParentCode != "" {
+			parent, err := models.NewCategoryCode(data.ParentCode)
+			if err != nil {
+				return 0, fmt.Errorf("parent of category %s: %w", code, err)
+			}
+			parentCode = &parent
+		}
+
+		category, err := models.NewCategory(code, parentCode, data.Name)
+		if err != nil {
+			return 0, err
+		}
+
+		parents[code] = parentCode
+		imported = append(imported, category)
+	}
+
+	for _, category := range imported {
+		err = checkCategoryAncestors(parents, category.Code)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = c.categoryRepo.Upsert(ctx, imported)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(imported), nil
+}
+
+// checkCategoryAncestors walks up from the category and checks that every parent exists
+// and that the walk reaches a root, i.e. the import does not create a cycle
+func checkCategoryAncestors(parents map[models.CategoryCode]*models.CategoryCode, code models.CategoryCode) error {
+	visited := make(map[models.CategoryCode]struct{})
+	for current := code; ; {
+		if _, ok := visited[current]; ok {
+			return fmt.Errorf("category %s is its own ancestor: %w", code, domain.ErrInvalidArgument)
+		}
+		visited[current] = struct{}{}
+
+		parent := parents[current]
+		if parent == nil {
+			return nil
+		}
+
+		if _, ok := parents[*parent]; !ok {
+			return fmt.Errorf("parent %s of category %s not found: %w", *parent, current, domain.ErrInvalidArgument)
+		}
+
+		current = *parent
+	}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"testing"
+)
+
+type memoryCategoryRepository struct {
+	abstraction.CategoryRepository
+	categories map[models.CategoryCode]models.Category
+}
+
+func (m *memoryCategoryRepository) GetAll(_ context.Context) ([]models.Category, error) {
+	categories := make([]models.Category, 0, len(m.categories))
+	for _, category := range m.categories {
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+func (m *memoryCategoryRepository) Upsert(_ context.Context, categories []models.Category) error {
+	for _, category := range categories {
+		m.categories[category.Code] = category
+	}
+	return nil
+}
+
+func TestImportCategories(t *testing.T) {
+	construction := models.CategoryConstruction
+
+	tests := []struct {
+		name       string
+		categories []dto.ImportCategoryDTO
+		wantErr    error
+	}{
+		{
+			name: "children before parents",
+			categories: []dto.ImportCategoryDTO{
+				{Code: "41.20.1", ParentCode: "41.20", Name: "Здания жилые"},
+				{Code: "41.20", ParentCode: "41", Name: "Здания"},
+				{Code: "41", ParentCode: "construction", Name: "Здания и работы"},
+			},
+		},
+		{
+			name:       "new root",
+			categories: []dto.ImportCategoryDTO{{Code: "services", Name: "Услуги"}},
+		},
+		{
+			name:       "existing category moved",
+			categories: []dto.ImportCategoryDTO{{Code: "delivery", ParentCode: "construction", Name: "Доставка"}},
+		},
+		{
+			name:       "unknown parent",
+			categories: []dto.ImportCategoryDTO{{Code: "41", ParentCode: "40", Name: "Здания"}},
+			wantErr:    domain.ErrInvalidArgument,
+		},
+		{
+			name: "cycle",
+			categories: []dto.ImportCategoryDTO{
+				{Code: "41", ParentCode: "42", Name: "Здания"},
+				{Code: "42", ParentCode: "41", Name: "Сооружения"},
+			},
+			wantErr: domain.ErrInvalidArgument,
+		},
+		{
+			name:       "root moved under its descendant",
+			categories: []dto.ImportCategoryDTO{{Code: "construction", ParentCode: "41", Name: "Строительство"}},
+			wantErr:    domain.ErrInvalidArgument,
+		},
+		{
+			name: "listed twice",
+			categories: []dto.ImportCategoryDTO{
+				{Code: "41", ParentCode: "construction", Name: "Здания"},
+				{Code: "41", ParentCode: "construction", Name: "Здания"},
+			},
+			wantErr: domain.ErrInvalidArgument,
+		},
+		{
+			name:       "invalid code",
+			categories: []dto.ImportCategoryDTO{{Code: "41 20", Name: "Здания"}},
+			wantErr:    domain.ErrInvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryCategoryRepository{categories: map[models.CategoryCode]models.Category{
+				models.CategoryConstruction: {Code: models.CategoryConstruction, Name: "Строительство"},
+				models.CategoryDelivery:     {Code: models.CategoryDelivery, Name: "Доставка"},
+				"41":                        {Code: "41", ParentCode: &construction, Name: "Здания"},
+			}}
+			before := len(repo.categories)
+
+			imported, err := NewCategoryUseCase(repo).Import(context.Background(), tt.categories)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if len(repo.categories) != before {
+					t.Fatalf("stored categories of a rejected import")
+				}
+				return
+			}
+			if imported != len(tt.categories) {
+				t.Fatalf("imported %d categories, want %d", imported, len(tt.categories))
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+var _ abstraction.EmployeeUseCaseInterface = &EmployeeUseCase{}
+
+const (
+	invitationTokenPrefix = "tsi_"
+	maxUsernameLength     = 50
+)
+
+// EmployeeConfig configures the invitation flow
+type EmployeeConfig struct {
+	InvitationTTL time.Duration
+}
+
+type EmployeeUseCase struct {
+	employeeRepo     abstraction.EmployeeRepository
+	invitationRepo   abstraction.InvitationRepository
+	refreshTokenRepo abstraction.RefreshTokenRepository
+
+	passwordHasher     abstraction.PasswordHasher
+	transactionManager abstraction.TransactionManager
+
+	config EmployeeConfig
+}
+
+func NewEmployeeUseCase(
+	employeeRepo abstraction.EmployeeRepository,
+	invitationRepo abstraction.InvitationRepository,
+	refreshTokenRepo abstraction.RefreshTokenRepository,
+	passwordHasher abstraction.PasswordHasher,
+	transactionManager abstraction.TransactionManager,
+	config EmployeeConfig,
+) *EmployeeUseCase {
+	return &EmployeeUseCase{
+		employeeRepo:       employeeRepo,
+		invitationRepo:     invitationRepo,
+		refreshTokenRepo:   refreshTokenRepo,
+		passwordHasher:     passwordHasher,
+		transactionManager: transactionManager,
+		config:             config,
+	}
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateInvitationToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("error generating invitation token: %w", err)
+	}
+
+	return invitationTokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func validateUsername(username string) error {
+	if username == "" || len(username) > maxUsernameLength {
+		return fmt.Errorf("username must be between 1 and %d characters long: %w", maxUsernameLength, domain.ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+// hashOptionalPassword validates and hashes the password, an empty password is left unset
+func (e *EmployeeUseCase) hashOptionalPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	if len(password) < MinPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters long: %w", MinPasswordLength, domain.ErrInvalidArgument)
+	}
+
+	return e.passwordHasher.Hash(password)
+}
+
+// authorizeStaffManager checks that the caller may manage employees of the target's organization
+func (e *EmployeeUseCase) authorizeStaffManager(ctx context.Context, employeeID models.ID) (models.Employee, error) {
+	principal, organization, err := actingOrganization(ctx, e.employeeRepo, models.PermissionManageStaff)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	_, err = e.employeeRepo.GetMembership(ctx, employeeID, organization.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return models.Employee{}, fmt.Errorf("employee %s is not responsible for organization %s: %w", employeeID, organization.ID, domain.ErrForbidden)
+		}
+		return models.Employee{}, err
+	}
+
+	return principal.Employee, nil
+}
+
+// Create registers an employee in the caller's organization
+func (e *EmployeeUseCase) Create(ctx context.Context, data *dto.CreateEmployeeDTO) (models.Employee, error) {
+	_, organization, err := actingOrganization(ctx, e.employeeRepo, models.PermissionManageStaff)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	err = validateUsername(data.Username)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	passwordHash, err := e.hashOptionalPassword(data.Password)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	now := time.Now()
+	employee := models.Employee{
+		ID:        models.NewID(),
+		Username:  data.Username,
+		FirstName: data.FirstName,
+		LastName:  data.LastName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return e.employeeRepo.Create(ctx, &employee, passwordHash, organization.ID, data.Role)
+}
+
+func (e *EmployeeUseCase) GetByID(ctx context.Context, id models.ID) (models.Employee, error) {
+	return e.employeeRepo.GetByID(ctx, id)
+}
+
+// Update changes the employee's name, allowed to the employee and to the owners of their organization
+func (e *EmployeeUseCase) Update(ctx context.Context, id models.ID, data *dto.UpdateEmployeeDTO) (models.Employee, error) {
+	u, err := currentEmployee(ctx)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	if u.ID != id {
+		_, err = e.authorizeStaffManager(ctx, id)
+		if err != nil {
+			return models.Employee{}, err
+		}
+	}
+
+	employee, err := e.employeeRepo.GetByID(ctx, id)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	if !employee.IsActive() {
+		return models.Employee{}, fmt.Errorf("employee %s is deactivated: %w", id, domain.ErrNotFound)
+	}
+
+	if data.FirstName != nil {
+		employee.FirstName = *data.FirstName
+	}
+
+	if data.LastName != nil {
+		employee.LastName = *data.LastName
+	}
+
+	employee.UpdatedAt = time.Now()
+
+	return e.employeeRepo.Update(ctx, id, &employee)
+}
+
+// Deactivate disables the employee's account and revokes their refresh tokens
+func (e *EmployeeUseCase) Deactivate(ctx context.Context, id models.ID) error {
+	u, err := e.authorizeStaffManager(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if u.ID == id {
+		return fmt.Errorf("employees cannot deactivate themselves: %w", domain.ErrInvalidArgument)
+	}
+
+	return e.transactionManager.Do(ctx, func(ctx context.Context) error {
+		err := e.employeeRepo.Deactivate(ctx, id, time.Now())
+		if err != nil {
+			return err
+		}
+
+		return e.refreshTokenRepo.RevokeByEmployeeID(ctx, id)
+	})
+}
+
+// GetMyOrganizations lists the organizations the caller can select as acting organization
+func (e *EmployeeUseCase) GetMyOrganizations(ctx context.Context) ([]models.OrganizationMember, error) {
+	u, err := currentEmployee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.employeeRepo.GetMemberships(ctx, u.ID)
+}
+
+// CreateInvitation issues a single-use token that lets a new employee join the organization.
+// The token is returned only once, only its hash is stored.
+func (e *EmployeeUseCase) CreateInvitation(ctx context.Context, data *dto.CreateInvitationDTO) (models.Invitation, string, error) {
+	principal, organization, err := actingOrganization(ctx, e.employeeRepo, models.PermissionManageStaff)
+	if err != nil {
+		return models.Invitation{}, "", err
+	}
+
+	if organization.ID != data.OrganizationID {
+		return models.Invitation{}, "", fmt.Errorf("user %s is not responsible for organization %s: %w", principal.Employee.Username, data.OrganizationID, domain.ErrForbidden)
+	}
+
+	token, err := generateInvitationToken()
+	if err != nil {
+		return models.Invitation{}, "", err
+	}
+
+	invitation := models.NewInvitation(data.OrganizationID, data.Role, hashInvitationToken(token), e.config.InvitationTTL, principal.Employee.ID)
+
+	invitation, err = e.invitationRepo.Create(ctx, &invitation)
+	if err != nil {
+		return models.Invitation{}, "", err
+	}
+
+	return invitation, token, nil
+}
+
+// RedeemInvitation creates the invited employee and links them to the inviting organization
+func (e *EmployeeUseCase) RedeemInvitation(ctx context.Context, data *dto.RedeemInvitationDTO) (models.Employee, error) {
+	invalidInvitation := fmt.Errorf("invalid invitation token: %w", domain.ErrInvalidArgument)
+
+	invitation, err := e.invitationRepo.GetByHash(ctx, hashInvitationToken(data.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return models.Employee{}, invalidInvitation
+		}
+		return models.Employee{}, err
+	}
+
+	now := time.Now()
+	if invitation.IsRedeemed() || invitation.IsExpired(now) {
+		return models.Employee{}, invalidInvitation
+	}
+
+	err = validateUsername(data.Username)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	if data.Password == "" {
+		return models.Employee{}, fmt.Errorf("password is required: %w", domain.ErrInvalidArgument)
+	}
+
+	passwordHash, err := e.hashOptionalPassword(data.Password)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	employee := models.Employee{
+		ID:        models.NewID(),
+		Username:  data.Username,
+		FirstName: data.FirstName,
+		LastName:  data.LastName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = e.invitationRepo.Redeem(ctx, &invitation, &employee, passwordHash)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	return employee, nil
+}
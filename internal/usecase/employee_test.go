@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"tenderSystem/internal/infrastructure/postgres/postgrestest"
+	"tenderSystem/internal/infrastructure/repositories/employee"
+	"tenderSystem/internal/infrastructure/repositories/organization/invitation"
+	"tenderSystem/internal/infrastructure/repositories/refreshtoken"
+	"testing"
+	"time"
+)
+
+func newTestEmployeeUseCase(db *postgres.DB, invitationTTL time.Duration) *EmployeeUseCase {
+	return NewEmployeeUseCase(
+		employee.NewPGXRepository(db), invitation.NewPGXRepository(db), refreshtoken.NewPGXRepository(db),
+		plainPasswordHasher{}, postgres.NewTransactionManager(db), EmployeeConfig{InvitationTTL: invitationTTL},
+	)
+}
+
+func TestInvitationIsRedeemedOnce(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestEmployeeUseCase(db, time.Hour)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	created, token, err := useCase.CreateInvitation(ctx, &dto.CreateInvitationDTO{OrganizationID: o.ID, Role: models.OrganizationRoleEvaluator})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(token, invitationTokenPrefix) || created.TokenHash == token {
+		t.Fatalf("got token %q stored as %q, want a prefixed token stored as a hash", token, created.TokenHash)
+	}
+
+	redeemed, err := useCase.RedeemInvitation(context.Background(), &dto.RedeemInvitationDTO{Token: token, Username: "invited", Password: "password123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	membership, err := useCase.employeeRepo.GetMembership(context.Background(), redeemed.ID, o.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if membership.Role != models.OrganizationRoleEvaluator {
+		t.Fatalf("invited employee role is %s, want evaluator", membership.Role)
+	}
+
+	_, err = useCase.RedeemInvitation(context.Background(), &dto.RedeemInvitationDTO{Token: token, Username: "second", Password: "password123"})
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("redeemed the invitation twice: %v", err)
+	}
+
+	_, err = useCase.RedeemInvitation(context.Background(), &dto.RedeemInvitationDTO{Token: "tsi_unknown", Username: "third", Password: "password123"})
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("redeemed an unknown token: %v", err)
+	}
+}
+
+func TestInvitationExpires(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestEmployeeUseCase(db, -time.Minute)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	_, token, err := useCase.CreateInvitation(ctx, &dto.CreateInvitationDTO{OrganizationID: o.ID, Role: models.OrganizationRoleViewer})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = useCase.RedeemInvitation(context.Background(), &dto.RedeemInvitationDTO{Token: token, Username: "late", Password: "password123"})
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("redeemed an expired invitation: %v", err)
+	}
+}
+
+func TestInvitationRequiresOwnerOfOrganization(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestEmployeeUseCase(db, time.Hour)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	other, _ := createTestOrganization(t, db, "other")
+	manager := createTestEmployee(t, db, o.ID, "manager", models.OrganizationRoleProcurementManager)
+
+	_, _, err := useCase.CreateInvitation(auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner)), &dto.CreateInvitationDTO{OrganizationID: other.ID})
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("got %v inviting to another organization, want forbidden", err)
+	}
+
+	_, _, err = useCase.CreateInvitation(auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(manager)), &dto.CreateInvitationDTO{OrganizationID: o.ID})
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("got %v for a procurement manager, want forbidden", err)
+	}
+}
+
+func TestDeactivatedEmployeeIsRejected(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestEmployeeUseCase(db, time.Hour)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	other, _ := createTestOrganization(t, db, "other")
+	member := createTestEmployee(t, db, o.ID, "member", models.OrganizationRoleViewer)
+	stranger := createTestEmployee(t, db, other.ID, "stranger", models.OrganizationRoleViewer)
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	err := useCase.Deactivate(ctx, owner.ID)
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v deactivating oneself, want invalid argument", err)
+	}
+
+	err = useCase.Deactivate(ctx, stranger.ID)
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("got %v deactivating an employee of another organization, want forbidden", err)
+	}
+
+	err = useCase.Deactivate(ctx, member.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = useCase.employeeRepo.GetByUsername(context.Background(), member.Username)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v looking up a deactivated employee, want not found", err)
+	}
+
+	firstName := "renamed"
+	_, err = useCase.Update(ctx, member.ID, &dto.UpdateEmployeeDTO{FirstName: &firstName})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v updating a deactivated employee, want not found", err)
+	}
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"tenderSystem/internal/infrastructure/repositories/employee"
+	"tenderSystem/internal/infrastructure/repositories/organization"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// createTestOrganization creates an organization with a new owner and returns both
+func createTestOrganization(t *testing.T, db *postgres.DB, name string) (models.Organization, models.Employee) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	// the owner is inserted directly, employees are otherwise created inside an existing organization
+	owner := models.Employee{ID: models.NewID(), Username: name + "_owner", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	_, err := db.Exec(ctx, `INSERT INTO employee (id, username) VALUES ($1, $2)`, uuid.UUID(owner.ID), owner.Username)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := models.Organization{
+		ID: models.NewID(), Name: name, Type: models.OrganizationTypeLimitedLiabilityCompany,
+		CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	o, err = organization.NewPGXRepository(db).Create(ctx, &o, owner.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return o, owner
+}
+
+func createTestEmployee(t *testing.T, db *postgres.DB, organizationID models.ID, username string, role models.OrganizationRole) models.Employee {
+	t.Helper()
+
+	e := models.Employee{ID: models.NewID(), Username: username, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	e, err := employee.NewPGXRepository(db).Create(context.Background(), &e, "", organizationID, role)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return e
+}
+
+// createTestTender creates a published single-lot tender of the organization
+func createTestTender(t *testing.T, tenderRepo abstraction.TenderRepository, organizationID models.ID, quorumPolicy models.QuorumPolicy) models.Tender {
+	t.Helper()
+
+	ctx := context.Background()
+
+	tenderModel := models.NewTender("tender", "description", "delivery", organizationID, quorumPolicy, models.TenderDeadlines{}, nil)
+	tenderModel.AddLot("tender", "description", nil)
+	_, err := tenderRepo.Create(ctx, &tenderModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	published, err := tenderRepo.SetStatus(ctx, tenderModel.ID, models.TenderStatusPublished)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return published
+}
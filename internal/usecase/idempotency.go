@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+var _ abstraction.IdempotencyUseCaseInterface = &IdempotencyUseCase{}
+
+// MaxIdempotencyKeyLength bounds the idempotency keys sent by the callers
+const MaxIdempotencyKeyLength = 255
+
+// anonymousScope holds the keys sent to the endpoints that do not require authentication
+const anonymousScope = "anonymous"
+
+// IdempotencyConfig configures how long the responses are kept for repeated requests
+type IdempotencyConfig struct {
+	KeyTTL time.Duration
+}
+
+type IdempotencyUseCase struct {
+	idempotencyRepo abstraction.IdempotencyRepository
+
+	config IdempotencyConfig
+}
+
+func NewIdempotencyUseCase(idempotencyRepo abstraction.IdempotencyRepository, config IdempotencyConfig) *IdempotencyUseCase {
+	return &IdempotencyUseCase{
+		idempotencyRepo: idempotencyRepo,
+		config:          config,
+	}
+}
+
+// idempotencyScope returns the caller the keys belong to
+func idempotencyScope(ctx context.Context) string {
+	principal, err := auth.PrincipalFromContext(ctx)
+	if err != nil {
+		return anonymousScope
+	}
+
+	if principal.IsEmployee() {
+		return fmt.Sprintf("%s:%s", models.PrincipalTypeEmployee, principal.Employee.ID)
+	}
+
+	return fmt.Sprintf("%s:%s", models.PrincipalTypeOrganization, principal.Organization.ID)
+}
+
+// Begin fails with ErrUnprocessable when the key was used for another request and with
+// ErrAlreadyExists while the first request with the key is in progress
+func (i *IdempotencyUseCase) Begin(ctx context.Context, key, fingerprint string) (*models.StoredResponse, error) {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return nil, fmt.Errorf("idempotency key must have 1 to %d characters: %w", MaxIdempotencyKeyLength, domain.ErrInvalidArgument)
+	}
+
+	now := time.Now()
+	request := models.IdempotentRequest{
+		Scope:       idempotencyScope(ctx),
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(i.config.KeyTTL),
+	}
+
+	stored, reserved, err := i.idempotencyRepo.Reserve(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	if reserved {
+		return nil, nil
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, fmt.Errorf("idempotency key %s was used for another request: %w", key, domain.ErrUnprocessable)
+	}
+
+	if stored.Response == nil {
+		return nil, fmt.Errorf("request with idempotency key %s is in progress: %w", key, domain.ErrAlreadyExists)
+	}
+
+	return stored.Response, nil
+}
+
+func (i *IdempotencyUseCase) Complete(ctx context.Context, key string, response models.StoredResponse) error {
+	return i.idempotencyRepo.SetResponse(ctx, idempotencyScope(ctx), key, response)
+}
+
+func (i *IdempotencyUseCase) Abort(ctx context.Context, key string) error {
+	return i.idempotencyRepo.Delete(ctx, idempotencyScope(ctx), key)
+}
+
+// PurgeExpired deletes the keys expired by now and returns how many were deleted
+func (i *IdempotencyUseCase) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	return i.idempotencyRepo.DeleteExpired(ctx, now)
+}
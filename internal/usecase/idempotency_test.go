@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/models"
+	"testing"
+	"time"
+)
+
+// memoryIdempotencyRepository keeps the requests in memory by scope and key, the keys never expire
+type memoryIdempotencyRepository struct {
+	requests map[string]models.IdempotentRequest
+}
+
+func newMemoryIdempotencyRepository() *memoryIdempotencyRepository {
+	return &memoryIdempotencyRepository{requests: map[string]models.IdempotentRequest{}}
+}
+
+func (m *memoryIdempotencyRepository) Reserve(_ context.Context, data *models.IdempotentRequest) (models.IdempotentRequest, bool, error) {
+	stored, ok := m.requests[data.Scope+" "+data.Key]
+	if ok {
+		return stored, false, nil
+	}
+
+	m.requests[data.Scope+" "+data.Key] = *data
+	return *data, true, nil
+}
+
+func (m *memoryIdempotencyRepository) SetResponse(_ context.Context, scope, key string, response models.StoredResponse) error {
+	stored, ok := m.requests[scope+" "+key]
+	if !ok {
+		return domain.ErrNotFound
+	}
+
+	stored.Response = &response
+	m.requests[scope+" "+key] = stored
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) Delete(_ context.Context, scope, key string) error {
+	delete(m.requests, scope+" "+key)
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) DeleteExpired(context.Context, time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestIdempotencyBegin(t *testing.T) {
+	useCase := NewIdempotencyUseCase(newMemoryIdempotencyRepository(), IdempotencyConfig{KeyTTL: time.Hour})
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(models.Employee{ID: models.NewID()}))
+
+	stored, err := useCase.Begin(ctx, "key", "first")
+	if err != nil || stored != nil {
+		t.Fatalf("got %v, %v for a new key, want it reserved", stored, err)
+	}
+
+	_, err = useCase.Begin(ctx, "key", "first")
+	if !errors.Is(err, domain.ErrAlreadyExists) {
+		t.Fatalf("got %v while the first request is in progress, want ErrAlreadyExists", err)
+	}
+
+	_, err = useCase.Begin(ctx, "key", "second")
+	if !errors.Is(err, domain.ErrUnprocessable) {
+		t.Fatalf("got %v for another request, want ErrUnprocessable", err)
+	}
+
+	err = useCase.Complete(ctx, "key", models.StoredResponse{StatusCode: 201, Body: []byte("created")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err = useCase.Begin(ctx, "key", "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored == nil || stored.StatusCode != 201 || string(stored.Body) != "created" {
+		t.Fatalf("got %v, want the stored response", stored)
+	}
+}
+
+func TestIdempotencyKeysBelongToCaller(t *testing.T) {
+	useCase := NewIdempotencyUseCase(newMemoryIdempotencyRepository(), IdempotencyConfig{KeyTTL: time.Hour})
+
+	callers := []context.Context{
+		context.Background(),
+		auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(models.Employee{ID: models.NewID()})),
+		auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(models.Employee{ID: models.NewID()})),
+		auth.WithPrincipal(context.Background(), models.NewOrganizationPrincipal(models.Organization{ID: models.NewID()}, nil)),
+	}
+
+	for i, ctx := range callers {
+		stored, err := useCase.Begin(ctx, "key", "request")
+		if err != nil || stored != nil {
+			t.Fatalf("caller %d: got %v, %v, want the key reserved", i, stored, err)
+		}
+	}
+}
+
+func TestIdempotencyBeginRejectsKeyLength(t *testing.T) {
+	useCase := NewIdempotencyUseCase(newMemoryIdempotencyRepository(), IdempotencyConfig{KeyTTL: time.Hour})
+
+	for _, key := range []string{"", strings.Repeat("k", MaxIdempotencyKeyLength+1)} {
+		_, err := useCase.Begin(context.Background(), key, "request")
+		if !errors.Is(err, domain.ErrInvalidArgument) {
+			t.Fatalf("got %v for a key of %d characters, want ErrInvalidArgument", err, len(key))
+		}
+	}
+
+	_, err := useCase.Begin(context.Background(), strings.Repeat("k", MaxIdempotencyKeyLength), "request")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
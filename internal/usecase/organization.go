@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"time"
+)
+
+var _ abstraction.OrganizationUseCaseInterface = &OrganizationUseCase{}
+
+type OrganizationUseCase struct {
+	organizationRepo abstraction.OrganizationRepository
+	employeeRepo     abstraction.EmployeeRepository
+}
+
+func NewOrganizationUseCase(organizationRepo abstraction.OrganizationRepository, employeeRepo abstraction.EmployeeRepository) *OrganizationUseCase {
+	return &OrganizationUseCase{
+		organizationRepo: organizationRepo,
+		employeeRepo:     employeeRepo,
+	}
+}
+
+// authorizeOrganization checks that the caller acts for the organization and holds the permission
+func (o *OrganizationUseCase) authorizeOrganization(ctx context.Context, organizationID models.ID, permission models.Permission) error {
+	_, organization, err := actingOrganization(ctx, o.employeeRepo, permission)
+	if err != nil {
+		return err
+	}
+
+	if organization.ID != organizationID {
+		return fmt.Errorf("caller is not responsible for organization %s: %w", organizationID, domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// Create registers a new organization, the caller becomes its owner
+func (o *OrganizationUseCase) Create(ctx context.Context, data *dto.CreateOrganizationDTO) (models.Organization, error) {
+	employee, err := currentEmployee(ctx)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	if data.Name == "" {
+		return models.Organization{}, fmt.Errorf("organization name is required: %w", domain.ErrInvalidArgument)
+	}
+
+	now := time.Now()
+	organization := models.Organization{
+		ID:          models.NewID(),
+		Name:        data.Name,
+		Description: data.Description,
+		Type:        data.Type,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	return o.organizationRepo.Create(ctx, &organization, employee.ID)
+}
+
+func (o *OrganizationUseCase) GetAll(ctx context.Context, options ...abstraction.PaginationOptFunc) ([]models.Organization, error) {
+	return o.organizationRepo.GetAll(ctx, options...)
+}
+
+func (o *OrganizationUseCase) GetByID(ctx context.Context, id models.ID) (models.Organization, error) {
+	return o.organizationRepo.GetByID(ctx, id)
+}
+
+func (o *OrganizationUseCase) Update(ctx context.Context, id models.ID, data *dto.UpdateOrganizationDTO) (models.Organization, error) {
+	err := o.authorizeOrganization(ctx, id, models.PermissionOrgEdit)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	organization, err := o.organizationRepo.GetByID(ctx, id)
+	if err != nil {
+		return models.Organization{}, err
+	}
+
+	if data.Name != nil {
+		if *data.Name == "" {
+			return models.Organization{}, fmt.Errorf("organization name is required: %w", domain.ErrInvalidArgument)
+		}
+		organization.Name = *data.Name
+	}
+
+	if data.Description != nil {
+		organization.Description = *data.Description
+	}
+
+	if data.Type != nil {
+		organization.Type = *data.Type
+	}
+
+	organization.UpdatedAt = time.Now()
+
+	return o.organizationRepo.Update(ctx, id, &organization)
+}
+
+func (o *OrganizationUseCase) GetMembers(ctx context.Context, organizationID models.ID) ([]models.OrganizationMember, error) {
+	err := o.authorizeOrganization(ctx, organizationID, models.PermissionOrgRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.organizationRepo.GetMembers(ctx, organizationID)
+}
+
+// checkNotLastOwner prevents an organization from losing its last owner
+func (o *OrganizationUseCase) checkNotLastOwner(ctx context.Context, member models.OrganizationMember) error {
+	if member.Role != models.OrganizationRoleOwner {
+		return nil
+	}
+
+	members, err := o.organizationRepo.GetMembers(ctx, member.Organization.ID)
+	if err != nil {
+		return err
+	}
+
+	owners := 0
+	for _, m := range members {
+		if m.Role == models.OrganizationRoleOwner {
+			owners++
+		}
+	}
+
+	if owners <= 1 {
+		return fmt.Errorf("organization %s must keep at least one owner: %w", member.Organization.ID, domain.ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+func (o *OrganizationUseCase) GrantRole(ctx context.Context, organizationID, employeeID models.ID, role models.OrganizationRole) (models.OrganizationMember, error) {
+	err := o.authorizeOrganization(ctx, organizationID, models.PermissionManageRoles)
+	if err != nil {
+		return models.OrganizationMember{}, err
+	}
+
+	member, err := o.organizationRepo.GetMember(ctx, organizationID, employeeID)
+	if err != nil {
+		return models.OrganizationMember{}, err
+	}
+
+	if role != models.OrganizationRoleOwner {
+		err = o.checkNotLastOwner(ctx, member)
+		if err != nil {
+			return models.OrganizationMember{}, err
+		}
+	}
+
+	err = o.organizationRepo.SetMemberRole(ctx, organizationID, employeeID, role)
+	if err != nil {
+		return models.OrganizationMember{}, err
+	}
+
+	member.Role = role
+
+	return member, nil
+}
+
+// AddMember makes the employee responsible for the organization with the given role
+func (o *OrganizationUseCase) AddMember(ctx context.Context, organizationID, employeeID models.ID, role models.OrganizationRole) (models.OrganizationMember, error) {
+	err := o.authorizeOrganization(ctx, organizationID, models.PermissionManageRoles)
+	if err != nil {
+		return models.OrganizationMember{}, err
+	}
+
+	_, err = o.employeeRepo.GetByID(ctx, employeeID)
+	if err != nil {
+		return models.OrganizationMember{}, err
+	}
+
+	err = o.organizationRepo.AddMember(ctx, organizationID, employeeID, role)
+	if err != nil {
+		return models.OrganizationMember{}, err
+	}
+
+	return o.organizationRepo.GetMember(ctx, organizationID, employeeID)
+}
+
+func (o *OrganizationUseCase) RemoveMember(ctx context.Context, organizationID, employeeID models.ID) error {
+	err := o.authorizeOrganization(ctx, organizationID, models.PermissionManageRoles)
+	if err != nil {
+		return err
+	}
+
+	member, err := o.organizationRepo.GetMember(ctx, organizationID, employeeID)
+	if err != nil {
+		return err
+	}
+
+	err = o.checkNotLastOwner(ctx, member)
+	if err != nil {
+		return err
+	}
+
+	return o.organizationRepo.RemoveMember(ctx, organizationID, employeeID)
+}
+
+// RevokeRole takes the member's role away, leaving them with read-only access
+func (o *OrganizationUseCase) RevokeRole(ctx context.Context, organizationID, employeeID models.ID) (models.OrganizationMember, error) {
+	return o.GrantRole(ctx, organizationID, employeeID, models.OrganizationRoleViewer)
+}
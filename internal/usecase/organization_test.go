@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"testing"
+)
+
+// memoryMemberRepository keeps the members of organizations in memory
+type memoryMemberRepository struct {
+	abstraction.OrganizationRepository
+
+	organizations []models.Organization
+	members       []models.OrganizationMember
+}
+
+func (m *memoryMemberRepository) GetMembers(_ context.Context, organizationID models.ID) ([]models.OrganizationMember, error) {
+	var members []models.OrganizationMember
+	for _, member := range m.members {
+		if member.Organization.ID == organizationID {
+			members = append(members, member)
+		}
+	}
+
+	return members, nil
+}
+
+func (m *memoryMemberRepository) GetMember(_ context.Context, organizationID, employeeID models.ID) (models.OrganizationMember, error) {
+	for _, member := range m.members {
+		if member.Organization.ID == organizationID && member.Employee.ID == employeeID {
+			return member, nil
+		}
+	}
+
+	return models.OrganizationMember{}, domain.ErrNotFound
+}
+
+func (m *memoryMemberRepository) SetMemberRole(_ context.Context, organizationID, employeeID models.ID, role models.OrganizationRole) error {
+	for i := range m.members {
+		if m.members[i].Organization.ID == organizationID && m.members[i].Employee.ID == employeeID {
+			m.members[i].Role = role
+			return nil
+		}
+	}
+
+	return domain.ErrNotFound
+}
+
+func (m *memoryMemberRepository) Create(_ context.Context, data *models.Organization, ownerID models.ID) (models.Organization, error) {
+	m.organizations = append(m.organizations, *data)
+	m.members = append(m.members, models.OrganizationMember{
+		Organization: *data, Employee: models.Employee{ID: ownerID}, Role: models.OrganizationRoleOwner,
+	})
+
+	return *data, nil
+}
+
+func (m *memoryMemberRepository) GetByID(_ context.Context, id models.ID) (models.Organization, error) {
+	for _, organization := range m.organizations {
+		if organization.ID == id {
+			return organization, nil
+		}
+	}
+
+	return models.Organization{}, domain.ErrNotFound
+}
+
+func (m *memoryMemberRepository) Update(_ context.Context, id models.ID, data *models.Organization) (models.Organization, error) {
+	for i := range m.organizations {
+		if m.organizations[i].ID == id {
+			m.organizations[i] = *data
+			return *data, nil
+		}
+	}
+
+	return models.Organization{}, domain.ErrNotFound
+}
+
+func (m *memoryMemberRepository) AddMember(_ context.Context, organizationID, employeeID models.ID, role models.OrganizationRole) error {
+	organization := models.Organization{ID: organizationID}
+	for _, o := range m.organizations {
+		if o.ID == organizationID {
+			organization = o
+		}
+	}
+
+	m.members = append(m.members, models.OrganizationMember{Organization: organization, Employee: models.Employee{ID: employeeID}, Role: role})
+	return nil
+}
+
+func (m *memoryMemberRepository) RemoveMember(_ context.Context, organizationID, employeeID models.ID) error {
+	for i, member := range m.members {
+		if member.Organization.ID == organizationID && member.Employee.ID == employeeID {
+			m.members = append(m.members[:i], m.members[i+1:]...)
+			return nil
+		}
+	}
+
+	return domain.ErrNotFound
+}
+
+// membershipEmployeeRepository answers the memberships from the member repository
+type membershipEmployeeRepository struct {
+	abstraction.EmployeeRepository
+
+	members *memoryMemberRepository
+}
+
+func (m membershipEmployeeRepository) GetByID(_ context.Context, id models.ID) (models.Employee, error) {
+	for _, member := range m.members.members {
+		if member.Employee.ID == id {
+			return member.Employee, nil
+		}
+	}
+
+	return models.Employee{ID: id}, nil
+}
+
+func (m membershipEmployeeRepository) GetMemberships(_ context.Context, employeeID models.ID) ([]models.OrganizationMember, error) {
+	var memberships []models.OrganizationMember
+	for _, member := range m.members.members {
+		if member.Employee.ID == employeeID {
+			memberships = append(memberships, member)
+		}
+	}
+
+	return memberships, nil
+}
+
+func newTestOrganizationUseCase(members ...models.OrganizationMember) (*OrganizationUseCase, *memoryMemberRepository) {
+	organizationRepo := &memoryMemberRepository{members: members}
+	return NewOrganizationUseCase(organizationRepo, membershipEmployeeRepository{members: organizationRepo}), organizationRepo
+}
+
+// asMember returns a context of the employee acting for the member's organization
+func asMember(member models.OrganizationMember) context.Context {
+	return auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(member.Employee).WithMembership(member))
+}
+
+func TestGrantRoleKeepsLastOwner(t *testing.T) {
+	organization := models.Organization{ID: models.NewID(), Name: "org"}
+	owner := models.OrganizationMember{Organization: organization, Employee: models.Employee{ID: models.NewID(), Username: "owner"}, Role: models.OrganizationRoleOwner}
+	manager := models.OrganizationMember{Organization: organization, Employee: models.Employee{ID: models.NewID(), Username: "manager"}, Role: models.OrganizationRoleProcurementManager}
+
+	organizationUseCase, organizationRepo := newTestOrganizationUseCase(owner, manager)
+	ctx := asMember(owner)
+
+	_, err := organizationUseCase.GrantRole(ctx, organization.ID, owner.Employee.ID, models.OrganizationRoleViewer)
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("demoted the last owner: %v", err)
+	}
+
+	_, err = organizationUseCase.RevokeRole(ctx, organization.ID, owner.Employee.ID)
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("revoked the role of the last owner: %v", err)
+	}
+
+	_, err = organizationUseCase.GrantRole(ctx, organization.ID, manager.Employee.ID, models.OrganizationRoleOwner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	member, err := organizationUseCase.RevokeRole(ctx, organization.ID, owner.Employee.ID)
+	if err != nil {
+		t.Fatalf("could not step down with another owner: %v", err)
+	}
+	if member.Role != models.OrganizationRoleViewer {
+		t.Fatalf("role is %s after the revocation, want viewer", member.Role)
+	}
+
+	stored, _ := organizationRepo.GetMember(context.Background(), organization.ID, owner.Employee.ID)
+	if stored.Role != models.OrganizationRoleViewer {
+		t.Fatalf("stored role is %s, want viewer", stored.Role)
+	}
+}
+
+func TestGrantRoleRequiresManageRoles(t *testing.T) {
+	organization := models.Organization{ID: models.NewID(), Name: "org"}
+	owner := models.OrganizationMember{Organization: organization, Employee: models.Employee{ID: models.NewID(), Username: "owner"}, Role: models.OrganizationRoleOwner}
+
+	for _, role := range []models.OrganizationRole{models.OrganizationRoleProcurementManager, models.OrganizationRoleEvaluator, models.OrganizationRoleViewer} {
+		t.Run(role.String(), func(t *testing.T) {
+			caller := models.OrganizationMember{Organization: organization, Employee: models.Employee{ID: models.NewID(), Username: "caller"}, Role: role}
+			organizationUseCase, _ := newTestOrganizationUseCase(owner, caller)
+
+			_, err := organizationUseCase.GrantRole(asMember(caller), organization.ID, caller.Employee.ID, models.OrganizationRoleOwner)
+			if !errors.Is(err, domain.ErrForbidden) {
+				t.Fatalf("got %v, want forbidden", err)
+			}
+		})
+	}
+}
+
+func TestGrantRoleInOtherOrganization(t *testing.T) {
+	own := models.Organization{ID: models.NewID(), Name: "own"}
+	other := models.Organization{ID: models.NewID(), Name: "other"}
+	owner := models.OrganizationMember{Organization: own, Employee: models.Employee{ID: models.NewID(), Username: "owner"}, Role: models.OrganizationRoleOwner}
+	stranger := models.OrganizationMember{Organization: other, Employee: models.Employee{ID: models.NewID(), Username: "stranger"}, Role: models.OrganizationRoleViewer}
+
+	organizationUseCase, _ := newTestOrganizationUseCase(owner, stranger)
+
+	_, err := organizationUseCase.GrantRole(asMember(owner), other.ID, stranger.Employee.ID, models.OrganizationRoleOwner)
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("got %v, want forbidden", err)
+	}
+}
+
+func TestActingOrganizationSelection(t *testing.T) {
+	first := models.Organization{ID: models.NewID(), Name: "first"}
+	second := models.Organization{ID: models.NewID(), Name: "second"}
+	employee := models.Employee{ID: models.NewID(), Username: "employee"}
+
+	single := &memoryMemberRepository{members: []models.OrganizationMember{
+		{Organization: first, Employee: employee, Role: models.OrganizationRoleOwner},
+	}}
+	several := &memoryMemberRepository{members: []models.OrganizationMember{
+		{Organization: first, Employee: employee, Role: models.OrganizationRoleOwner},
+		{Organization: second, Employee: employee, Role: models.OrganizationRoleViewer},
+	}}
+
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(employee))
+
+	_, organization, err := actingOrganization(ctx, membershipEmployeeRepository{members: single}, models.PermissionOrgEdit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if organization.ID != first.ID {
+		t.Fatalf("acting for %s, want the only membership", organization.Name)
+	}
+
+	_, _, err = actingOrganization(ctx, membershipEmployeeRepository{members: several}, models.PermissionOrgRead)
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v without a selected organization, want invalid argument", err)
+	}
+
+	selected := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(employee).WithMembership(several.members[1]))
+	_, _, err = actingOrganization(selected, membershipEmployeeRepository{members: several}, models.PermissionOrgEdit)
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("got %v for a viewer, want forbidden", err)
+	}
+}
+
+func TestCreateOrganizationMakesCallerOwner(t *testing.T) {
+	organizationUseCase, organizationRepo := newTestOrganizationUseCase()
+	employee := models.Employee{ID: models.NewID(), Username: "founder"}
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(employee))
+
+	_, err := organizationUseCase.Create(ctx, &dto.CreateOrganizationDTO{Type: models.OrganizationTypeIndividualEntrepreneur})
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("created an organization without a name: %v", err)
+	}
+
+	organization, err := organizationUseCase.Create(ctx, &dto.CreateOrganizationDTO{Name: "ИП Иванов", Type: models.OrganizationTypeIndividualEntrepreneur})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owner, err := organizationRepo.GetMember(context.Background(), organization.ID, employee.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner.Role != models.OrganizationRoleOwner {
+		t.Fatalf("creator role is %s, want owner", owner.Role)
+	}
+
+	apiKeyCtx := auth.WithPrincipal(context.Background(), models.NewOrganizationPrincipal(organization, []models.APIKeyScope{models.APIKeyScopeTendersWrite}))
+	_, err = organizationUseCase.Create(apiKeyCtx, &dto.CreateOrganizationDTO{Name: "other"})
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("created an organization with an API key: %v", err)
+	}
+}
+
+func TestUpdateOrganization(t *testing.T) {
+	organization := models.Organization{ID: models.NewID(), Name: "org", Type: models.OrganizationTypeLimitedLiabilityCompany}
+	owner := models.OrganizationMember{Organization: organization, Employee: models.Employee{ID: models.NewID(), Username: "owner"}, Role: models.OrganizationRoleOwner}
+	manager := models.OrganizationMember{Organization: organization, Employee: models.Employee{ID: models.NewID(), Username: "manager"}, Role: models.OrganizationRoleProcurementManager}
+
+	organizationUseCase, organizationRepo := newTestOrganizationUseCase(owner, manager)
+	organizationRepo.organizations = []models.Organization{organization}
+
+	name := "renamed"
+	_, err := organizationUseCase.Update(asMember(manager), organization.ID, &dto.UpdateOrganizationDTO{Name: &name})
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("got %v for a procurement manager, want forbidden", err)
+	}
+
+	empty := ""
+	_, err = organizationUseCase.Update(asMember(owner), organization.ID, &dto.UpdateOrganizationDTO{Name: &empty})
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v for an empty name, want invalid argument", err)
+	}
+
+	organizationType := models.OrganizationTypeJointStockCompany
+	updated, err := organizationUseCase.Update(asMember(owner), organization.ID, &dto.UpdateOrganizationDTO{Name: &name, Type: &organizationType})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Name != name || updated.Type != organizationType {
+		t.Fatalf("got %s %s, want %s %s", updated.Name, updated.Type, name, organizationType)
+	}
+}
+
+func TestAddAndRemoveMembers(t *testing.T) {
+	organization := models.Organization{ID: models.NewID(), Name: "org"}
+	owner := models.OrganizationMember{Organization: organization, Employee: models.Employee{ID: models.NewID(), Username: "owner"}, Role: models.OrganizationRoleOwner}
+
+	organizationUseCase, _ := newTestOrganizationUseCase(owner)
+	ctx := asMember(owner)
+
+	employeeID := models.NewID()
+	member, err := organizationUseCase.AddMember(ctx, organization.ID, employeeID, models.OrganizationRoleEvaluator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.Role != models.OrganizationRoleEvaluator {
+		t.Fatalf("member role is %s, want evaluator", member.Role)
+	}
+
+	_, err = organizationUseCase.AddMember(asMember(member), organization.ID, models.NewID(), models.OrganizationRoleOwner)
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("got %v for an evaluator adding members, want forbidden", err)
+	}
+
+	err = organizationUseCase.RemoveMember(ctx, organization.ID, owner.Employee.ID)
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("removed the last owner: %v", err)
+	}
+
+	err = organizationUseCase.RemoveMember(ctx, organization.ID, employeeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := organizationUseCase.GetMembers(ctx, organization.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].Employee.ID != owner.Employee.ID {
+		t.Fatalf("got %d members, want only the owner", len(members))
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/models"
+)
+
+// currentEmployee returns the employee behind the request, API key principals are rejected
+func currentEmployee(ctx context.Context) (models.Employee, error) {
+	principal, err := auth.PrincipalFromContext(ctx)
+	if err != nil {
+		return models.Employee{}, err
+	}
+
+	if !principal.IsEmployee() {
+		return models.Employee{}, fmt.Errorf("operation is not available with an API key: %w", domain.ErrForbidden)
+	}
+
+	return principal.Employee, nil
+}
+
+// actingOrganization returns the organization the caller acts for and checks the
+// permission against the employee's role or, for API key principals, the key scopes.
+// Employees responsible for several organizations must select one explicitly.
+func actingOrganization(ctx context.Context, employeeRepo abstraction.EmployeeRepository, permission models.Permission) (models.Principal, models.Organization, error) {
+	principal, err := auth.PrincipalFromContext(ctx)
+	if err != nil {
+		return models.Principal{}, models.Organization{}, err
+	}
+
+	if !principal.IsEmployee() {
+		scope, ok := permission.RequiredScope()
+		if !ok || !principal.HasScope(scope) {
+			return models.Principal{}, models.Organization{}, fmt.Errorf("API key is not allowed to %s: %w", permission, domain.ErrForbidden)
+		}
+
+		return principal, principal.Organization, nil
+	}
+
+	if !principal.HasOrganization() {
+		memberships, err := employeeRepo.GetMemberships(ctx, principal.Employee.ID)
+		if err != nil {
+			return models.Principal{}, models.Organization{}, err
+		}
+
+		switch len(memberships) {
+		case 0:
+			return models.Principal{}, models.Organization{}, fmt.Errorf("user %s is not responsible for any organization: %w", principal.Employee.Username, domain.ErrForbidden)
+		case 1:
+			principal = principal.WithMembership(memberships[0])
+		default:
+			return models.Principal{}, models.Organization{}, fmt.Errorf("user %s is responsible for several organizations, select one with the X-Organization-ID header: %w", principal.Employee.Username, domain.ErrInvalidArgument)
+		}
+	}
+
+	if !principal.Role.Can(permission) {
+		return models.Principal{}, models.Organization{}, fmt.Errorf("role %s is not allowed to %s: %w", principal.Role, permission, domain.ErrForbidden)
+	}
+
+	return principal, principal.Organization, nil
+}
+
+// currentVersionAuthor returns the caller as the author of a new version
+func currentVersionAuthor(ctx context.Context) (*models.VersionAuthor, error) {
+	principal, err := auth.PrincipalFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	author := models.NewVersionAuthor(principal)
+	return &author, nil
+}
+
+// currentTenderViewer returns the caller as a viewer of invite-only tenders: an employee with all
+// the organizations they belong to, or the organization of an API key
+func currentTenderViewer(ctx context.Context, employeeRepo abstraction.EmployeeRepository) (models.TenderViewer, error) {
+	principal, err := auth.PrincipalFromContext(ctx)
+	if err != nil {
+		return models.TenderViewer{}, err
+	}
+
+	if !principal.IsEmployee() {
+		return models.TenderViewer{OrganizationIDs: []models.ID{principal.Organization.ID}}, nil
+	}
+
+	return employeeTenderViewer(ctx, employeeRepo, principal.Employee.ID)
+}
+
+func employeeTenderViewer(ctx context.Context, employeeRepo abstraction.EmployeeRepository, employeeID models.ID) (models.TenderViewer, error) {
+	memberships, err := employeeRepo.GetMemberships(ctx, employeeID)
+	if err != nil {
+		return models.TenderViewer{}, err
+	}
+
+	viewer := models.TenderViewer{EmployeeID: &employeeID}
+	for _, membership := range memberships {
+		viewer.OrganizationIDs = append(viewer.OrganizationIDs, membership.Organization.ID)
+	}
+
+	return viewer, nil
+}
@@ -2,11 +2,14 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"tenderSystem/internal/abstraction"
 	"tenderSystem/internal/domain"
 	"tenderSystem/internal/domain/dto"
 	"tenderSystem/internal/domain/models"
+	"time"
 )
 
 var _ abstraction.TenderUseCaseInterface = &TenderUseCase{}
@@ -15,32 +18,229 @@ type TenderUseCase struct {
 	tenderRepo abstraction.TenderRepository
 
 	employeeRepo abstraction.EmployeeRepository
+
+	organizationRepo abstraction.OrganizationRepository
+
+	categoryRepo abstraction.CategoryRepository
+
+	transactionManager abstraction.TransactionManager
+
+	attachmentService *AttachmentService
 }
 
-func (t *TenderUseCase) SetStatus(ctx context.Context, id models.ID, username string, status models.TenderStatus) (models.Tender, error) {
-	_, _, tender, err := t.authorizeUser(ctx, id, username)
+// expiredTendersBatchSize limits how many expired tenders are loaded at once by CloseExpired
+const expiredTendersBatchSize = 100
+
+func (t *TenderUseCase) SetStatus(ctx context.Context, id models.ID, status models.TenderStatus, expectedRevisions []int) (models.Tender, error) {
+	_, _, tender, err := t.authorizeUser(ctx, id, models.PermissionTenderStatus)
 	if err != nil {
 		return models.Tender{}, err
 	}
 
-	_, err = t.tenderRepo.SetStatus(ctx, id, status)
+	err = t.transactionManager.Do(ctx, func(ctx context.Context) error {
+		tender, err = t.tenderRepo.LockByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = models.CheckCurrentRevision(tender.Revision, expectedRevisions)
+		if err != nil {
+			return err
+		}
+
+		if status == models.TenderStatusClosed {
+			return closeTender(ctx, t.tenderRepo, &tender)
+		}
+
+		err = tender.TransitionTo(status)
+		if err != nil {
+			return err
+		}
+
+		_, err = t.tenderRepo.SetStatus(ctx, id, tender.Status)
+		return err
+	})
 	if err != nil {
 		return models.Tender{}, err
 	}
 
-	return tender, nil
+	return t.tenderRepo.GetByID(ctx, id)
+}
+
+// closeTender closes the tender and cancels its lots that were not awarded.
+// Must run while the tender is locked.
+func closeTender(ctx context.Context, tenderRepo abstraction.TenderRepository, tender *models.Tender) error {
+	err := tender.Close()
+	if err != nil {
+		return err
+	}
+
+	for i := range tender.Lots {
+		lot := &tender.Lots[i]
+		if !lot.IsOpen() {
+			continue
+		}
+
+		err = lot.Cancel()
+		if err != nil {
+			return err
+		}
+
+		err = tenderRepo.SetLotStatus(ctx, lot.ID, lot.Status, lot.AwardedBidID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tenderRepo.SetStatus(ctx, tender.ID, tender.Status)
+	return err
+}
+
+// CancelLot cancels an open lot, the tender is closed once none of its lots is open
+func (t *TenderUseCase) CancelLot(ctx context.Context, tenderID, lotID models.ID) (models.Tender, error) {
+	_, _, _, err := t.authorizeUser(ctx, tenderID, models.PermissionTenderStatus)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	err = t.transactionManager.Do(ctx, func(ctx context.Context) error {
+		tender, err := t.tenderRepo.LockByID(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+
+		lot, err := tender.Lot(lotID)
+		if err != nil {
+			return err
+		}
+
+		err = lot.Cancel()
+		if err != nil {
+			return err
+		}
+
+		err = t.tenderRepo.SetLotStatus(ctx, lot.ID, lot.Status, lot.AwardedBidID)
+		if err != nil {
+			return err
+		}
+
+		if !tender.AllLotsFinished() || tender.Status == models.TenderStatusClosed {
+			return nil
+		}
+
+		return closeTender(ctx, t.tenderRepo, &tender)
+	})
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	return t.tenderRepo.GetByID(ctx, tenderID)
+}
+
+// GetAllowedStatuses returns the current status of the tender and the statuses the caller may move it to
+func (t *TenderUseCase) GetAllowedStatuses(ctx context.Context, id models.ID) (models.TenderStatus, []models.TenderStatus, error) {
+	_, _, tender, err := t.authorizeUser(ctx, id, models.PermissionTenderRead)
+	if err != nil {
+		return models.TenderStatusUnknown, nil, err
+	}
+
+	_, _, _, err = t.authorizeUser(ctx, id, models.PermissionTenderStatus)
+	if errors.Is(err, domain.ErrForbidden) {
+		return tender.Status, []models.TenderStatus{}, nil
+	}
+	if err != nil {
+		return models.TenderStatusUnknown, nil, err
+	}
+
+	return tender.Status, tender.Status.NextStatuses(), nil
 }
 
 func (t *TenderUseCase) GetAll(ctx context.Context, options ...abstraction.GetTendersOptFunc) ([]models.Tender, error) {
-	return t.tenderRepo.GetAll(ctx, options...)
+	viewer, err := currentTenderViewer(ctx, t.employeeRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// the viewer goes last, so the caller can't override it
+	return t.tenderRepo.GetAll(ctx, append(options, abstraction.WithViewer(viewer))...)
+}
+
+func (t *TenderUseCase) Search(ctx context.Context, text string, options ...abstraction.GetTendersOptFunc) ([]models.TenderSearchResult, error) {
+	viewer, err := currentTenderViewer(ctx, t.employeeRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.tenderRepo.Search(ctx, text, append(options, abstraction.WithViewer(viewer))...)
 }
 
 func (t *TenderUseCase) Create(ctx context.Context, data *dto.CreateTenderDTO) (models.Tender, error) {
+	_, o, err := actingOrganization(ctx, t.employeeRepo, models.PermissionTenderCreate)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	if o.ID != data.OrganizationID {
+		return models.Tender{}, fmt.Errorf("caller is not responsible for organization %s: %w", data.OrganizationID, domain.ErrForbidden)
+	}
+
+	err = t.checkCategoryExists(ctx, data.ServiceType)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	quorumPolicy := models.DefaultQuorumPolicy()
+	if data.QuorumPolicy != nil {
+		quorumPolicy = *data.QuorumPolicy
+	}
+
+	deadlines, err := models.NewTenderDeadlines(data.SubmissionDeadline, data.BidOpeningAt, data.DecisionDeadline)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	err = checkSubmissionDeadlineInFuture(data.SubmissionDeadline, time.Now())
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	err = t.checkInviteesExist(ctx, data.Invitees)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
 	tenderModel := models.NewTender(
-		data.Name, data.Description, data.ServiceType, data.OrganizationID,
+		data.Name, data.Description, data.ServiceType, data.OrganizationID, quorumPolicy, deadlines, data.Budget,
 	)
+	if data.Visibility != nil {
+		tenderModel.Visibility = *data.Visibility
+	}
+
+	if len(data.Lots) == 0 {
+		tenderModel.AddLot(data.Name, data.Description, nil)
+	}
+	for _, lot := range data.Lots {
+		tenderModel.AddLot(lot.Name, lot.Description, lot.Budget)
+	}
 
-	_, err := t.tenderRepo.Create(ctx, &tenderModel)
+	err = tenderModel.CheckLotBudgets()
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	tenderModel.VersionAuthor, err = currentVersionAuthor(ctx)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	err = t.transactionManager.Do(ctx, func(ctx context.Context) error {
+		_, err := t.tenderRepo.Create(ctx, &tenderModel)
+		if err != nil {
+			return err
+		}
+
+		return t.tenderRepo.AddInvitees(ctx, tenderModel.ID, data.Invitees)
+	})
 	if err != nil {
 		return models.Tender{}, err
 	}
@@ -48,29 +248,111 @@ func (t *TenderUseCase) Create(ctx context.Context, data *dto.CreateTenderDTO) (
 	return tenderModel, nil
 }
 
-func (t *TenderUseCase) GetMy(ctx context.Context, username string, options ...abstraction.PaginationOptFunc) ([]models.Tender, error) {
-	//TODO: Learn if we need to return all organization tenders or tenders created by the user
-	u, err := t.employeeRepo.GetByUsername(ctx, username)
+// checkInviteesExist reports unknown invitees as invalid arguments rather than missing resources
+func (t *TenderUseCase) checkInviteesExist(ctx context.Context, invitees []models.TenderInvitee) error {
+	for _, invitee := range invitees {
+		var err error
+		if invitee.Type == models.PrincipalTypeEmployee {
+			_, err = t.employeeRepo.GetByID(ctx, invitee.ID)
+		} else {
+			_, err = t.organizationRepo.GetByID(ctx, invitee.ID)
+		}
+
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("unknown %s %s: %w", invitee.Type, invitee.ID, domain.ErrInvalidArgument)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *TenderUseCase) SetVisibility(ctx context.Context, id models.ID, visibility models.TenderVisibility) (models.Tender, error) {
+	_, _, _, err := t.authorizeUser(ctx, id, models.PermissionTenderEdit)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	return t.tenderRepo.SetVisibility(ctx, id, visibility)
+}
+
+func (t *TenderUseCase) GetInvitees(ctx context.Context, id models.ID) ([]models.TenderInvitee, error) {
+	_, _, _, err := t.authorizeUser(ctx, id, models.PermissionTenderRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.tenderRepo.GetInvitees(ctx, id)
+}
+
+func (t *TenderUseCase) AddInvitees(ctx context.Context, id models.ID, invitees []models.TenderInvitee) ([]models.TenderInvitee, error) {
+	_, _, _, err := t.authorizeUser(ctx, id, models.PermissionTenderEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.checkInviteesExist(ctx, invitees)
 	if err != nil {
 		return nil, err
 	}
 
-	o, err := t.employeeRepo.GetOrganization(ctx, u.ID)
+	err = t.tenderRepo.AddInvitees(ctx, id, invitees)
 	if err != nil {
 		return nil, err
 	}
 
-	return t.tenderRepo.GetByOrganizationID(ctx, o.ID, options...)
+	return t.tenderRepo.GetInvitees(ctx, id)
 }
 
-func (t *TenderUseCase) authorizeUser(ctx context.Context, tenderID models.ID, username string) (models.Employee, models.Organization, models.Tender, error) {
+func (t *TenderUseCase) RemoveInvitee(ctx context.Context, id models.ID, invitee models.TenderInvitee) ([]models.TenderInvitee, error) {
+	_, _, _, err := t.authorizeUser(ctx, id, models.PermissionTenderEdit)
+	if err != nil {
+		return nil, err
+	}
 
-	u, err := t.employeeRepo.GetByUsername(ctx, username)
+	err = t.tenderRepo.RemoveInvitee(ctx, id, invitee)
 	if err != nil {
-		return models.Employee{}, models.Organization{}, models.Tender{}, err
+		return nil, err
+	}
+
+	return t.tenderRepo.GetInvitees(ctx, id)
+}
+
+// checkCategoryExists reports an unknown category as an invalid argument rather than a missing resource
+func (t *TenderUseCase) checkCategoryExists(ctx context.Context, code models.CategoryCode) error {
+	_, err := t.categoryRepo.GetByCode(ctx, code)
+	if errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("unknown category %s: %w", code, domain.ErrInvalidArgument)
 	}
 
-	o, err := t.employeeRepo.GetOrganization(ctx, u.ID)
+	return err
+}
+
+func checkSubmissionDeadlineInFuture(submissionDeadline *time.Time, now time.Time) error {
+	if submissionDeadline != nil && !submissionDeadline.After(now) {
+		return fmt.Errorf("submission deadline must be in the future: %w", domain.ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+func (t *TenderUseCase) GetMy(ctx context.Context, options ...abstraction.GetTendersOptFunc) ([]models.Tender, error) {
+	//TODO: Learn if we need to return all organization tenders or tenders created by the user
+	_, o, err := actingOrganization(ctx, t.employeeRepo, models.PermissionTenderRead)
+	if err != nil {
+		return nil, err
+	}
+
+	// the organization filter goes last, so the caller can't override it
+	return t.tenderRepo.GetAll(ctx, append(options, abstraction.WithOrganizationID(o.ID))...)
+}
+
+// authorizeUser checks that the caller acts for the organization owning the tender
+// and holds the permission. The returned employee is empty for API key principals.
+func (t *TenderUseCase) authorizeUser(ctx context.Context, tenderID models.ID, permission models.Permission) (models.Employee, models.Organization, models.Tender, error) {
+	principal, o, err := actingOrganization(ctx, t.employeeRepo, permission)
 	if err != nil {
 		return models.Employee{}, models.Organization{}, models.Tender{}, err
 	}
@@ -85,25 +367,50 @@ func (t *TenderUseCase) authorizeUser(ctx context.Context, tenderID models.ID, u
 			fmt.Errorf("tender does not belong to the organization: %w", domain.ErrForbidden)
 	}
 
-	return u, o, tender, nil
+	return principal.Employee, o, tender, nil
 }
 
-func (t *TenderUseCase) GetStatus(ctx context.Context, id models.ID, username string) (models.TenderStatus, error) {
-	_, _, tender, err := t.authorizeUser(ctx, id, username)
+func (t *TenderUseCase) GetStatus(ctx context.Context, id models.ID) (models.TenderStatus, int, error) {
+	_, _, tender, err := t.authorizeUser(ctx, id, models.PermissionTenderRead)
 	if err != nil {
-		return models.TenderStatusUnknown, err
+		return models.TenderStatusUnknown, 0, err
 	}
 
-	return tender.Status, nil
+	return tender.Status, tender.Revision, nil
 }
 
-func (t *TenderUseCase) Update(ctx context.Context, tenderID models.ID, username string, data *dto.UpdateTenderDTO) (models.Tender, error) {
-	_, _, tender, err := t.authorizeUser(ctx, tenderID, username)
+func (t *TenderUseCase) Update(ctx context.Context, tenderID models.ID, data *dto.UpdateTenderDTO, expectedRevisions []int) (models.Tender, error) {
+	_, _, _, err := t.authorizeUser(ctx, tenderID, models.PermissionTenderEdit)
 	if err != nil {
 		return models.Tender{}, err
 	}
 
-	latestVersion, err := t.tenderRepo.GetLatestVersionNumber(ctx, tenderID)
+	// the tender stays locked from the version check to the insert of the new version
+	var updated models.Tender
+	err = t.transactionManager.Do(ctx, func(ctx context.Context) error {
+		tender, err := t.tenderRepo.LockByID(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+
+		err = models.CheckCurrentRevision(tender.Revision, expectedRevisions)
+		if err != nil {
+			return err
+		}
+
+		updated, err = t.update(ctx, tender, data)
+		return err
+	})
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	return updated, nil
+}
+
+// update saves the data as a new version of the locked tender
+func (t *TenderUseCase) update(ctx context.Context, tender models.Tender, data *dto.UpdateTenderDTO) (models.Tender, error) {
+	latestVersion, err := t.tenderRepo.GetLatestVersionNumber(ctx, tender.ID)
 	if err != nil {
 		return models.Tender{}, err
 	}
@@ -111,7 +418,12 @@ func (t *TenderUseCase) Update(ctx context.Context, tenderID models.ID, username
 	var input struct {
 		Name        string
 		Description string
-		ServiceType models.TenderType
+		ServiceType models.CategoryCode
+		Budget      *models.Money
+
+		SubmissionDeadline *time.Time
+		BidOpeningAt       *time.Time
+		DecisionDeadline   *time.Time
 	}
 	{
 		if data.Name != nil {
@@ -127,32 +439,326 @@ func (t *TenderUseCase) Update(ctx context.Context, tenderID models.ID, username
 		}
 
 		if data.ServiceType != nil {
+			err = t.checkCategoryExists(ctx, *data.ServiceType)
+			if err != nil {
+				return models.Tender{}, err
+			}
 			input.ServiceType = *data.ServiceType
 		} else {
 			input.ServiceType = tender.ServiceType
 		}
+
+		if data.Budget != nil {
+			input.Budget = data.Budget
+		} else {
+			input.Budget = tender.Budget
+		}
+
+		if data.SubmissionDeadline != nil {
+			input.SubmissionDeadline = data.SubmissionDeadline
+		} else {
+			input.SubmissionDeadline = tender.Deadlines.SubmissionDeadline
+		}
+
+		if data.BidOpeningAt != nil {
+			input.BidOpeningAt = data.BidOpeningAt
+		} else {
+			input.BidOpeningAt = tender.Deadlines.BidOpeningAt
+		}
+
+		if data.DecisionDeadline != nil {
+			input.DecisionDeadline = data.DecisionDeadline
+		} else {
+			input.DecisionDeadline = tender.Deadlines.DecisionDeadline
+		}
+	}
+
+	deadlines, err := models.NewTenderDeadlines(input.SubmissionDeadline, input.BidOpeningAt, input.DecisionDeadline)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	err = checkSubmissionDeadlineInFuture(data.SubmissionDeadline, time.Now())
+	if err != nil {
+		return models.Tender{}, err
 	}
 
 	tender.Name = input.Name
 	tender.Description = input.Description
 	tender.ServiceType = input.ServiceType
+	tender.Budget = input.Budget
+	tender.Deadlines = deadlines
 	tender.Version = latestVersion + 1
 
-	return t.tenderRepo.Update(ctx, tenderID, &tender)
+	err = updateLots(&tender, data.Lots)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	tender.VersionAuthor, err = currentVersionAuthor(ctx)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	return t.tenderRepo.Update(ctx, tender.ID, &tender)
+}
+
+// updateLots edits the lots with an ID and adds the others as new lots
+func updateLots(tender *models.Tender, lots []dto.UpdateLotDTO) error {
+	for _, data := range lots {
+		if data.ID == nil {
+			if data.Name == nil {
+				return fmt.Errorf("name of a new lot is required: %w", domain.ErrInvalidArgument)
+			}
+
+			var description string
+			if data.Description != nil {
+				description = *data.Description
+			}
+
+			tender.AddLot(*data.Name, description, data.Budget)
+			continue
+		}
+
+		lot, err := tender.Lot(*data.ID)
+		if err != nil {
+			return err
+		}
+
+		if data.Name != nil {
+			lot.Name = *data.Name
+		}
+
+		if data.Description != nil {
+			lot.Description = *data.Description
+		}
+
+		if data.Budget != nil {
+			lot.Budget = data.Budget
+		}
+	}
+
+	return tender.CheckLotBudgets()
 }
 
-func (t *TenderUseCase) Rollback(ctx context.Context, tenderID models.ID, username string, version int) (models.Tender, error) {
-	_, _, _, err := t.authorizeUser(ctx, tenderID, username)
+func (t *TenderUseCase) Rollback(ctx context.Context, tenderID models.ID, version int, expectedRevisions []int) (models.Tender, error) {
+	_, _, _, err := t.authorizeUser(ctx, tenderID, models.PermissionTenderEdit)
 	if err != nil {
 		return models.Tender{}, err
 	}
 
-	return t.tenderRepo.Rollback(ctx, tenderID, version)
+	author, err := currentVersionAuthor(ctx)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	var rolledBack models.Tender
+	err = t.transactionManager.Do(ctx, func(ctx context.Context) error {
+		tender, err := t.tenderRepo.LockByID(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+
+		err = models.CheckCurrentRevision(tender.Revision, expectedRevisions)
+		if err != nil {
+			return err
+		}
+
+		target, err := t.tenderRepo.GetSpecificVersion(ctx, tenderID, version)
+		if err != nil {
+			return err
+		}
+
+		// Lots added after the version keep their bids, so they cannot disappear
+		for _, lot := range tender.Lots {
+			_, err = target.Lot(lot.ID)
+			if err != nil {
+				return fmt.Errorf("version %d has no lot %d: %w", version, lot.Number, domain.ErrInvalidArgument)
+			}
+		}
+
+		rolledBack, err = t.tenderRepo.Rollback(ctx, tenderID, version, author)
+		return err
+	})
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	return rolledBack, nil
+}
+
+func (t *TenderUseCase) GetVersions(ctx context.Context, id models.ID, options ...abstraction.PaginationOptFunc) ([]models.Tender, error) {
+	_, _, _, err := t.authorizeUser(ctx, id, models.PermissionTenderRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.tenderRepo.GetVersions(ctx, id, options...)
+}
+
+func (t *TenderUseCase) GetVersion(ctx context.Context, id models.ID, version int) (models.Tender, error) {
+	_, _, _, err := t.authorizeUser(ctx, id, models.PermissionTenderRead)
+	if err != nil {
+		return models.Tender{}, err
+	}
+
+	return t.tenderRepo.GetSpecificVersion(ctx, id, version)
+}
+
+func (t *TenderUseCase) Diff(ctx context.Context, id models.ID, from, to int) ([]models.FieldChange, error) {
+	_, _, _, err := t.authorizeUser(ctx, id, models.PermissionTenderRead)
+	if err != nil {
+		return nil, err
+	}
+
+	fromTender, err := t.tenderRepo.GetSpecificVersion(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toTender, err := t.tenderRepo.GetSpecificVersion(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.DiffTenderVersions(fromTender, toTender), nil
+}
+
+// CloseExpired closes the published tenders whose closing time has come and returns how many were closed.
+// It runs on behalf of the system, so no caller is authorized.
+func (t *TenderUseCase) CloseExpired(ctx context.Context, now time.Time) (int, error) {
+	closed := 0
+	for {
+		tenders, err := t.tenderRepo.GetExpired(ctx, now, abstraction.WithLimit(expiredTendersBatchSize))
+		if err != nil {
+			return closed, err
+		}
+
+		for _, tender := range tenders {
+			// the tender is counted once the transaction is committed
+			isClosed := false
+			err = t.transactionManager.Do(ctx, func(ctx context.Context) error {
+				// The tender may have been closed by a decision since it was loaded
+				tender, err := t.tenderRepo.LockByID(ctx, tender.ID)
+				if err != nil {
+					return err
+				}
+
+				if !tender.IsExpired(now) {
+					return nil
+				}
+
+				err = closeTender(ctx, t.tenderRepo, &tender)
+				if err != nil {
+					return err
+				}
+
+				isClosed = true
+				return nil
+			})
+			if err != nil {
+				return closed, fmt.Errorf("error closing tender %s: %w", tender.ID, err)
+			}
+
+			if isClosed {
+				closed++
+			}
+		}
+
+		if len(tenders) < expiredTendersBatchSize {
+			return closed, nil
+		}
+	}
+}
+
+// UploadAttachment attaches the document to the current version of the tender
+func (t *TenderUseCase) UploadAttachment(ctx context.Context, id models.ID, data *dto.UploadAttachmentDTO) (models.Attachment, error) {
+	u, _, _, err := t.authorizeUser(ctx, id, models.PermissionTenderEdit)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+
+	var uploadedBy *models.ID
+	if u.ID != (models.ID{}) {
+		uploadedBy = &u.ID
+	}
+
+	return t.attachmentService.upload(ctx, models.AttachmentOwnerTypeTender, id, uploadedBy, data)
+}
+
+// checkAttachmentAccess lets anyone the tender is visible to read the attachments of published
+// and closed tenders, the attachments of other tenders are only available to the organization
+func (t *TenderUseCase) checkAttachmentAccess(ctx context.Context, id models.ID) error {
+	tender, err := t.tenderRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if tender.Status == models.TenderStatusPublished || tender.Status == models.TenderStatusClosed {
+		visible, err := t.isVisibleToCaller(ctx, tender)
+		if err != nil {
+			return err
+		}
+		if visible {
+			return nil
+		}
+	}
+
+	_, _, _, err = t.authorizeUser(ctx, id, models.PermissionTenderRead)
+	return err
+}
+
+func (t *TenderUseCase) isVisibleToCaller(ctx context.Context, tender models.Tender) (bool, error) {
+	if tender.Visibility != models.TenderVisibilityInviteOnly {
+		return true, nil
+	}
+
+	viewer, err := currentTenderViewer(ctx, t.employeeRepo)
+	if err != nil {
+		return false, err
+	}
+
+	invitees, err := t.tenderRepo.GetInvitees(ctx, tender.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return tender.IsVisibleTo(viewer, invitees), nil
+}
+
+// GetAttachments returns the attachments of the tender version, of the current one when version is nil
+func (t *TenderUseCase) GetAttachments(ctx context.Context, id models.ID, version *int) ([]models.Attachment, error) {
+	err := t.checkAttachmentAccess(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.attachmentService.list(ctx, models.AttachmentOwnerTypeTender, id, version)
+}
+
+func (t *TenderUseCase) DownloadAttachment(ctx context.Context, id, attachmentID models.ID) (models.Attachment, io.ReadCloser, error) {
+	err := t.checkAttachmentAccess(ctx, id)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	return t.attachmentService.download(ctx, models.AttachmentOwnerTypeTender, id, attachmentID)
 }
 
-func NewTenderUseCase(tenderRepo abstraction.TenderRepository, employeeRepo abstraction.EmployeeRepository) *TenderUseCase {
+func NewTenderUseCase(
+	tenderRepo abstraction.TenderRepository,
+	employeeRepo abstraction.EmployeeRepository,
+	organizationRepo abstraction.OrganizationRepository,
+	categoryRepo abstraction.CategoryRepository,
+	transactionManager abstraction.TransactionManager,
+	attachmentService *AttachmentService,
+) *TenderUseCase {
 	return &TenderUseCase{
-		tenderRepo:   tenderRepo,
-		employeeRepo: employeeRepo,
+		tenderRepo:         tenderRepo,
+		employeeRepo:       employeeRepo,
+		organizationRepo:   organizationRepo,
+		categoryRepo:       categoryRepo,
+		transactionManager: transactionManager,
+		attachmentService:  attachmentService,
 	}
 }
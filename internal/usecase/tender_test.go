@@ -0,0 +1,589 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"tenderSystem/internal/abstraction"
+	"tenderSystem/internal/domain"
+	"tenderSystem/internal/domain/auth"
+	"tenderSystem/internal/domain/dto"
+	"tenderSystem/internal/domain/models"
+	"tenderSystem/internal/infrastructure/postgres"
+	"tenderSystem/internal/infrastructure/postgres/postgrestest"
+	"tenderSystem/internal/infrastructure/repositories/category"
+	"tenderSystem/internal/infrastructure/repositories/employee"
+	"tenderSystem/internal/infrastructure/repositories/organization"
+	"tenderSystem/internal/infrastructure/repositories/tender"
+	"testing"
+	"time"
+)
+
+func newTestTenderUseCase(db *postgres.DB) *TenderUseCase {
+	return NewTenderUseCase(
+		tender.NewPGXRepository(db), employee.NewPGXRepository(db), organization.NewPGXRepository(db), category.NewPGXRepository(db),
+		postgres.NewTransactionManager(db), nil,
+	)
+}
+
+func TestTenderStatusChangeBumpsRevision(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestTenderUseCase(db)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	created := models.NewTender("tender", "description", "delivery", o.ID, models.DefaultQuorumPolicy(), models.TenderDeadlines{}, nil)
+	created.AddLot("tender", "description", nil)
+	_, err := useCase.tenderRepo.Create(ctx, &created)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, seen, err := useCase.GetStatus(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	published, err := useCase.SetStatus(ctx, created.ID, models.TenderStatusPublished, []int{seen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published.Revision == seen {
+		t.Fatalf("revision %d did not change with the status", seen)
+	}
+
+	// the version is the same, but the status the caller has seen is not
+	_, err = useCase.SetStatus(ctx, created.ID, models.TenderStatusClosed, []int{seen})
+	if !errors.Is(err, domain.ErrPreconditionFailed) {
+		t.Fatalf("got %v, want ErrPreconditionFailed", err)
+	}
+
+	closed, err := useCase.SetStatus(ctx, created.ID, models.TenderStatusClosed, []int{seen, published.Revision})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Version != created.Version {
+		t.Fatalf("version changed from %d to %d", created.Version, closed.Version)
+	}
+}
+
+func TestGetMyUsesActingOrganization(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestTenderUseCase(db)
+	ctx := context.Background()
+
+	first, consultant := createTestOrganization(t, db, "first")
+	second, _ := createTestOrganization(t, db, "second")
+
+	err := organization.NewPGXRepository(db).AddMember(ctx, second.ID, consultant.ID, models.OrganizationRoleViewer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstTender := createTestTender(t, useCase.tenderRepo, first.ID, models.DefaultQuorumPolicy())
+	secondTender := createTestTender(t, useCase.tenderRepo, second.ID, models.DefaultQuorumPolicy())
+
+	_, err = useCase.GetMy(auth.WithPrincipal(ctx, models.NewEmployeePrincipal(consultant)))
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v without an acting organization, want invalid argument", err)
+	}
+
+	for _, tt := range []struct {
+		organization models.Organization
+		want         models.Tender
+	}{
+		{organization: first, want: firstTender},
+		{organization: second, want: secondTender},
+	} {
+		member, err := useCase.employeeRepo.GetMembership(ctx, consultant.ID, tt.organization.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tenders, err := useCase.GetMy(auth.WithPrincipal(ctx, models.NewEmployeePrincipal(consultant).WithMembership(member)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tenders) != 1 || tenders[0].ID != tt.want.ID {
+			t.Fatalf("got %d tenders acting for %s, want only its own", len(tenders), tt.organization.Name)
+		}
+	}
+}
+
+func TestCancelLastLotClosesTender(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestTenderUseCase(db)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	created := models.NewTender("tender", "description", "delivery", o.ID, models.DefaultQuorumPolicy(), models.TenderDeadlines{}, nil)
+	paper := created.AddLot("paper", "", nil)
+	toner := created.AddLot("toner", "", nil)
+	_, err := useCase.tenderRepo.Create(ctx, &created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = useCase.tenderRepo.SetStatus(ctx, created.ID, models.TenderStatusPublished)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	canceled, err := useCase.CancelLot(ctx, created.ID, paper.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canceled.Status != models.TenderStatusPublished {
+		t.Fatalf("tender is %s with an open lot, want published", canceled.Status)
+	}
+
+	_, err = useCase.CancelLot(ctx, created.ID, paper.ID)
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v canceling a canceled lot, want invalid argument", err)
+	}
+
+	closed, err := useCase.CancelLot(ctx, created.ID, toner.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Status != models.TenderStatusClosed {
+		t.Fatalf("tender is %s without open lots, want closed", closed.Status)
+	}
+	for _, lot := range closed.Lots {
+		if lot.Status != models.LotStatusCanceled {
+			t.Fatalf("lot %d is %s, want canceled", lot.Number, lot.Status)
+		}
+	}
+}
+
+func TestSearchUsesCurrentVersion(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestTenderUseCase(db)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	created := createTestTender(t, useCase.tenderRepo, o.ID, models.DefaultQuorumPolicy())
+	other := createTestTender(t, useCase.tenderRepo, o.ID, models.DefaultQuorumPolicy())
+
+	description := "Поставка бетона М400 на объект"
+	_, err := useCase.Update(ctx, created.ID, &dto.UpdateTenderDTO{Description: &description}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := useCase.Search(ctx, "бетон")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Tender.ID != created.ID {
+		t.Fatalf("got %d results, want only the edited tender", len(results))
+	}
+	if results[0].Rank <= 0 || !strings.Contains(results[0].Snippet, "<mark>бетона</mark>") {
+		t.Fatalf("got rank %f and snippet %q, want the match marked", results[0].Rank, results[0].Snippet)
+	}
+
+	// the previous description is only in the old version now
+	results, err = useCase.Search(ctx, "description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Tender.ID != other.ID {
+		t.Fatalf("got %d results, want only the unchanged tender", len(results))
+	}
+}
+
+func TestGetAllFiltersAndSorts(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestTenderUseCase(db)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	var created []models.Tender
+	for _, budget := range []*models.Money{{Amount: 200, Currency: "RUB"}, nil, {Amount: 100, Currency: "RUB"}} {
+		tenderModel := models.NewTender("tender", "description", "delivery", o.ID, models.DefaultQuorumPolicy(), models.TenderDeadlines{}, budget)
+		tenderModel.AddLot("tender", "description", nil)
+		_, err := useCase.tenderRepo.Create(ctx, &tenderModel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, tenderModel)
+	}
+
+	// the tender without a budget is still created, the others are published
+	for _, tender := range []models.Tender{created[0], created[2]} {
+		_, err := useCase.tenderRepo.SetStatus(ctx, tender.ID, models.TenderStatusPublished)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := func(tenders []models.Tender) []models.ID {
+		var ids []models.ID
+		for _, tender := range tenders {
+			ids = append(ids, tender.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name    string
+		options []abstraction.GetTendersOptFunc
+		want    []models.ID
+	}{
+		{
+			name:    "budget ascending, without budget last",
+			options: []abstraction.GetTendersOptFunc{abstraction.WithTendersSortOrder(abstraction.TenderSortBudgetAsc)},
+			want:    []models.ID{created[2].ID, created[0].ID, created[1].ID},
+		},
+		{
+			name:    "budget descending, without budget last",
+			options: []abstraction.GetTendersOptFunc{abstraction.WithTendersSortOrder(abstraction.TenderSortBudgetDesc)},
+			want:    []models.ID{created[0].ID, created[2].ID, created[1].ID},
+		},
+		{
+			name:    "status",
+			options: []abstraction.GetTendersOptFunc{abstraction.WithTenderStatus(models.TenderStatusCreated)},
+			want:    []models.ID{created[1].ID},
+		},
+		{
+			name: "budget range",
+			options: []abstraction.GetTendersOptFunc{
+				abstraction.WithBudgetRange(nil, &created[2].Budget.Amount, "RUB"),
+			},
+			want: []models.ID{created[2].ID},
+		},
+		{
+			name: "other currency",
+			options: []abstraction.GetTendersOptFunc{
+				abstraction.WithBudgetRange(nil, nil, "USD"),
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenders, err := useCase.GetAll(ctx, append(tt.options, abstraction.WithOrganizationID(o.ID))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ids(tenders); !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAllCursorPages(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestTenderUseCase(db)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	var want []models.ID
+	for range 5 {
+		want = append(want, createTestTender(t, useCase.tenderRepo, o.ID, models.DefaultQuorumPolicy()).ID)
+	}
+	slices.Reverse(want)
+
+	var got []models.ID
+	var cursor *abstraction.Cursor
+	for page := 0; ; page++ {
+		if page > len(want) {
+			t.Fatal("the pages do not end")
+		}
+
+		var info abstraction.PageInfo
+		paginationOptions, err := abstraction.NewPaginationOptions(abstraction.WithLimit(2), abstraction.WithPageInfo(&info, true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		paginationOptions.Cursor = cursor
+
+		tenders, err := useCase.GetAll(ctx, abstraction.WithPaginationOptions(paginationOptions), abstraction.WithOrganizationID(o.ID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantTotal := len(want)
+		if page > 0 {
+			wantTotal++
+		}
+		if info.Total == nil || *info.Total != wantTotal {
+			t.Fatalf("got total %v on page %d, want %d", info.Total, page, wantTotal)
+		}
+
+		for _, tender := range tenders {
+			got = append(got, tender.ID)
+		}
+
+		// a tender created between the pages goes before the cursor and does not shift them
+		if page == 0 {
+			createTestTender(t, useCase.tenderRepo, o.ID, models.DefaultQuorumPolicy())
+		}
+
+		if info.NextCursor == "" {
+			break
+		}
+		next, err := abstraction.ParseCursor(info.NextCursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cursor = &next
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestTenderVersionHistory(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestTenderUseCase(db)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	created := createTestTender(t, useCase.tenderRepo, o.ID, models.DefaultQuorumPolicy())
+
+	name := "renamed"
+	_, err := useCase.Update(ctx, created.ID, &dto.UpdateTenderDTO{Name: &name}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := useCase.GetVersions(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 || versions[0].Version != 2 || versions[1].Version != 1 {
+		t.Fatalf("got %d versions, want 2 and 1 from the newest", len(versions))
+	}
+
+	want := models.VersionAuthor{Type: models.PrincipalTypeEmployee, ID: owner.ID}
+	if versions[0].Name != name || versions[0].VersionAuthor == nil || *versions[0].VersionAuthor != want {
+		t.Fatalf("got version %q by %v, want the edit by the owner", versions[0].Name, versions[0].VersionAuthor)
+	}
+
+	first, err := useCase.GetVersion(ctx, created.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Name != created.Name || len(first.Lots) != 1 {
+		t.Fatalf("got version 1 named %q with %d lots", first.Name, len(first.Lots))
+	}
+
+	_, err = useCase.GetVersion(ctx, created.ID, 3)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v for a missing version, want not found", err)
+	}
+
+	// the history is not visible outside of the organization
+	_, stranger := createTestOrganization(t, db, "stranger")
+	_, err = useCase.GetVersions(auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(stranger)), created.ID)
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("got %v for another organization, want forbidden", err)
+	}
+}
+
+func TestRollbackCreatesVersion(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestTenderUseCase(db)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	created := createTestTender(t, useCase.tenderRepo, o.ID, models.DefaultQuorumPolicy())
+
+	name := "renamed"
+	_, err := useCase.Update(ctx, created.ID, &dto.UpdateTenderDTO{Name: &name}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rolledBack, err := useCase.Rollback(ctx, created.ID, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rolledBack.Version != 3 || rolledBack.Name != created.Name {
+		t.Fatalf("got version %d named %q, want version 3 copied from version 1", rolledBack.Version, rolledBack.Name)
+	}
+	if rolledBack.RolledBackFrom == nil || *rolledBack.RolledBackFrom != 1 {
+		t.Fatalf("got version rolled back from %v, want 1", rolledBack.RolledBackFrom)
+	}
+
+	// the rolled back edit stays in the history
+	edited, err := useCase.GetVersion(ctx, created.ID, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edited.Name != name || edited.RolledBackFrom != nil {
+		t.Fatalf("got version 2 named %q, want the edit", edited.Name)
+	}
+
+	versions, err := useCase.GetVersions(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("got %d versions, want 3", len(versions))
+	}
+
+	_, err = useCase.Rollback(ctx, created.ID, 4, nil)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v for a missing version, want not found", err)
+	}
+}
+
+func TestInviteOnlyTenderVisibleToInvitees(t *testing.T) {
+	db := postgrestest.New(t)
+	useCase := newTestTenderUseCase(db)
+
+	o, owner := createTestOrganization(t, db, "customer")
+	ctx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(owner))
+
+	invited, stranger := createTestOrganization(t, db, "supplier")
+	strangerCtx := auth.WithPrincipal(context.Background(), models.NewEmployeePrincipal(stranger))
+
+	created := createTestTender(t, useCase.tenderRepo, o.ID, models.DefaultQuorumPolicy())
+
+	visible := func() bool {
+		t.Helper()
+
+		tenders, err := useCase.GetAll(strangerCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return slices.ContainsFunc(tenders, func(tender models.Tender) bool { return tender.ID == created.ID })
+	}
+
+	if !visible() {
+		t.Fatal("public tender is hidden")
+	}
+
+	hidden, err := useCase.SetVisibility(ctx, created.ID, models.TenderVisibilityInviteOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hidden.Visibility != models.TenderVisibilityInviteOnly || hidden.Version != created.Version {
+		t.Fatalf("got %s tender version %d, want an invite-only tender of the same version", hidden.Visibility, hidden.Version)
+	}
+	if visible() {
+		t.Fatal("invite-only tender is visible to another organization")
+	}
+
+	// organizations of the tender see their tenders regardless of the visibility
+	tenders, err := useCase.GetAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tenders) != 1 {
+		t.Fatalf("got %d tenders for the owner, want 1", len(tenders))
+	}
+
+	invitee := models.TenderInvitee{Type: models.PrincipalTypeOrganization, ID: invited.ID}
+	invitees, err := useCase.AddInvitees(ctx, created.ID, []models.TenderInvitee{invitee, invitee})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invitees) != 1 || invitees[0] != invitee {
+		t.Fatalf("got invitees %v, want the organization once", invitees)
+	}
+	if !visible() {
+		t.Fatal("invite-only tender is hidden from an employee of the invited organization")
+	}
+
+	invitees, err = useCase.RemoveInvitee(ctx, created.ID, invitee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invitees) != 0 || visible() {
+		t.Fatalf("got invitees %v, want the invitation revoked", invitees)
+	}
+
+	_, err = useCase.AddInvitees(ctx, created.ID, []models.TenderInvitee{{Type: models.PrincipalTypeEmployee, ID: models.NewID()}})
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("got %v for an unknown employee, want ErrInvalidArgument", err)
+	}
+
+	_, err = useCase.AddInvitees(strangerCtx, created.ID, []models.TenderInvitee{{Type: models.PrincipalTypeEmployee, ID: stranger.ID}})
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("got %v for another organization, want forbidden", err)
+	}
+}
+
+// expiredTenderRepository holds a single expired tender in memory
+type expiredTenderRepository struct {
+	abstraction.TenderRepository
+
+	tender models.Tender
+}
+
+func (e *expiredTenderRepository) GetExpired(context.Context, time.Time, ...abstraction.PaginationOptFunc) ([]models.Tender, error) {
+	if !e.tender.IsExpired(time.Now()) {
+		return nil, nil
+	}
+
+	return []models.Tender{e.tender}, nil
+}
+
+func (e *expiredTenderRepository) LockByID(context.Context, models.ID) (models.Tender, error) {
+	return e.tender, nil
+}
+
+func (e *expiredTenderRepository) SetLotStatus(context.Context, models.ID, models.LotStatus, *models.ID) error {
+	return nil
+}
+
+func (e *expiredTenderRepository) SetStatus(_ context.Context, _ models.ID, status models.TenderStatus) (models.Tender, error) {
+	closed := e.tender
+	closed.Status = status
+	return closed, nil
+}
+
+// failingCommitTransactionManager runs the transaction and fails to commit it
+type failingCommitTransactionManager struct {
+	err error
+}
+
+func (f failingCommitTransactionManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	if err != nil {
+		return err
+	}
+
+	return f.err
+}
+
+func TestCloseExpiredCountsCommittedTenders(t *testing.T) {
+	deadline := time.Now().Add(-time.Hour)
+	expired := models.NewTender("tender", "description", "delivery", models.NewID(), models.DefaultQuorumPolicy(), models.TenderDeadlines{DecisionDeadline: &deadline}, nil)
+	expired.AddLot("tender", "description", nil)
+	expired.Status = models.TenderStatusPublished
+
+	commitErr := errors.New("commit failed")
+	useCase := NewTenderUseCase(&expiredTenderRepository{tender: expired}, nil, nil, nil, failingCommitTransactionManager{err: commitErr}, nil)
+
+	closed, err := useCase.CloseExpired(context.Background(), time.Now())
+	if !errors.Is(err, commitErr) {
+		t.Fatalf("got %v, want the commit error", err)
+	}
+	if closed != 0 {
+		t.Fatalf("got %d closed tenders, want none as the transaction was not committed", closed)
+	}
+
+	useCase = NewTenderUseCase(&expiredTenderRepository{tender: expired}, nil, nil, nil, failingCommitTransactionManager{}, nil)
+
+	closed, err = useCase.CloseExpired(context.Background(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed != 1 {
+		t.Fatalf("got %d closed tenders, want 1", closed)
+	}
+}
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE employee
+    ADD COLUMN password_hash         VARCHAR(255),
+    ADD COLUMN failed_login_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN locked_until          TIMESTAMPTZ;
+
+CREATE TABLE refresh_token
+(
+    id          UUID PRIMARY KEY,
+    employee_id UUID        NOT NULL REFERENCES employee (id) ON DELETE CASCADE,
+    family_id   UUID        NOT NULL,
+    token_hash  VARCHAR(64) NOT NULL UNIQUE,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at  TIMESTAMPTZ
+);
+
+-- Индекс на поле family_id
+CREATE INDEX idx_refresh_token_family_id ON refresh_token (family_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE refresh_token CASCADE;
+
+ALTER TABLE employee
+    DROP COLUMN password_hash,
+    DROP COLUMN failed_login_attempts,
+    DROP COLUMN locked_until;
+-- +goose StatementEnd
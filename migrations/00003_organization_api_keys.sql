@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TABLE organization_api_key
+(
+    id              UUID PRIMARY KEY,
+    organization_id UUID          NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    name            VARCHAR(100)  NOT NULL,
+    prefix          VARCHAR(16)   NOT NULL,
+    key_hash        VARCHAR(64)   NOT NULL UNIQUE,
+    scopes          VARCHAR(50)[] NOT NULL,
+    expires_at      TIMESTAMPTZ,
+    last_used_at    TIMESTAMPTZ,
+    created_by      UUID          REFERENCES employee (id) ON DELETE SET NULL,
+    created_at      TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    revoked_at      TIMESTAMPTZ
+);
+
+-- Индекс на поле organization_id
+CREATE INDEX idx_organization_api_key_organization_id ON organization_api_key (organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE organization_api_key CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+CREATE TYPE organization_role AS ENUM (
+    'owner',
+    'procurement_manager',
+    'evaluator',
+    'viewer'
+    );
+
+-- Существующие ответственные могли выполнять любые действия, поэтому становятся владельцами
+ALTER TABLE organization_responsible
+    ADD COLUMN role organization_role NOT NULL DEFAULT 'owner';
+
+ALTER TABLE organization_responsible
+    ALTER COLUMN role SET DEFAULT 'viewer';
+
+DELETE
+FROM organization_responsible a
+    USING organization_responsible b
+WHERE a.organization_id = b.organization_id
+  AND a.user_id = b.user_id
+  AND a.id > b.id;
+
+ALTER TABLE organization_responsible
+    ADD CONSTRAINT uq_organization_responsible UNIQUE (organization_id, user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TABLE organization_responsible
+    DROP CONSTRAINT uq_organization_responsible;
+
+ALTER TABLE organization_responsible
+    DROP COLUMN role;
+
+DROP TYPE organization_role;
+-- +goose StatementEnd
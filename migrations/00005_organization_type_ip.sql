@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Приведение значения перечисления к models.OrganizationTypeIndividualEntrepreneur
+ALTER TYPE organization_type RENAME VALUE 'IE' TO 'IP';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TYPE organization_type RENAME VALUE 'IP' TO 'IE';
+-- +goose StatementEnd
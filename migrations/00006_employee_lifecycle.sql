@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+ALTER TABLE employee
+    ADD COLUMN deactivated_at TIMESTAMPTZ;
+
+-- Создание таблицы organization_invitation
+CREATE TABLE organization_invitation
+(
+    id              UUID PRIMARY KEY,
+    organization_id UUID              NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    role            organization_role NOT NULL,
+    token_hash      VARCHAR(64)       NOT NULL UNIQUE,
+    created_by      UUID              REFERENCES employee (id) ON DELETE SET NULL,
+    created_at      TIMESTAMPTZ       NOT NULL DEFAULT NOW(),
+    expires_at      TIMESTAMPTZ       NOT NULL,
+    redeemed_at     TIMESTAMPTZ,
+    redeemed_by     UUID              REFERENCES employee (id) ON DELETE SET NULL
+);
+
+-- Индекс на поле organization_id
+CREATE INDEX idx_organization_invitation_organization_id ON organization_invitation (organization_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE organization_invitation CASCADE;
+
+ALTER TABLE employee
+    DROP COLUMN deactivated_at;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Оставляем только первое решение сотрудника по предложению
+DELETE
+FROM bid_decision a
+    USING bid_decision b
+WHERE a.bid_id = b.bid_id
+  AND a.employee_id = b.employee_id
+  AND (a.created_at, a.id) > (b.created_at, b.id);
+
+ALTER TABLE bid_decision
+    ADD CONSTRAINT uq_bid_decision_bid_employee UNIQUE (bid_id, employee_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TABLE bid_decision
+    DROP CONSTRAINT uq_bid_decision_bid_employee;
+-- +goose StatementEnd
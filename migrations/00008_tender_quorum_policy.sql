@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Политика кворума задается при создании тендера, существующие тендеры получают прежнее правило (3 одобрения)
+ALTER TABLE tender
+    ADD COLUMN quorum_policy    VARCHAR(20) NOT NULL DEFAULT 'fixed',
+    ADD COLUMN quorum_threshold INT         NOT NULL DEFAULT 3;
+
+ALTER TABLE tender
+    ADD CONSTRAINT chk_tender_quorum_policy CHECK (quorum_policy IN ('fixed', 'majority', 'unanimity', 'weighted'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TABLE tender
+    DROP CONSTRAINT chk_tender_quorum_policy,
+    DROP COLUMN quorum_threshold,
+    DROP COLUMN quorum_policy;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Сроки тендера: прием предложений, вскрытие предложений и принятие решений. NULL — срок не ограничен
+ALTER TABLE tender
+    ADD COLUMN submission_deadline TIMESTAMPTZ,
+    ADD COLUMN bid_opening_at      TIMESTAMPTZ,
+    ADD COLUMN decision_deadline   TIMESTAMPTZ;
+
+-- Планировщик ищет опубликованные тендеры, срок которых истек
+CREATE INDEX idx_tender_published_closes_at ON tender (COALESCE(decision_deadline, submission_deadline))
+    WHERE status = 'published';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP INDEX idx_tender_published_closes_at;
+
+ALTER TABLE tender
+    DROP COLUMN decision_deadline,
+    DROP COLUMN bid_opening_at,
+    DROP COLUMN submission_deadline;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Суммы хранятся в минимальных единицах валюты (копейки, центы), валюта — код ISO 4217
+ALTER TABLE tender_version
+    ADD COLUMN budget_amount   BIGINT,
+    ADD COLUMN budget_currency CHAR(3);
+
+ALTER TABLE tender_version
+    ADD CONSTRAINT chk_tender_version_budget CHECK (
+        (budget_amount IS NULL AND budget_currency IS NULL) OR
+        (budget_amount >= 0 AND budget_currency IS NOT NULL)
+    );
+
+ALTER TABLE bid_version
+    ADD COLUMN price_amount   BIGINT,
+    ADD COLUMN price_currency CHAR(3);
+
+ALTER TABLE bid_version
+    ADD CONSTRAINT chk_bid_version_price CHECK (
+        (price_amount IS NULL AND price_currency IS NULL) OR
+        (price_amount >= 0 AND price_currency IS NOT NULL)
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TABLE bid_version
+    DROP CONSTRAINT chk_bid_version_price,
+    DROP COLUMN price_currency,
+    DROP COLUMN price_amount;
+
+ALTER TABLE tender_version
+    DROP CONSTRAINT chk_tender_version_budget,
+    DROP COLUMN budget_currency,
+    DROP COLUMN budget_amount;
+-- +goose StatementEnd
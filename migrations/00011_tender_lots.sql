@@ -0,0 +1,92 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Лоты тендера: статус хранится в tender_lot, содержимое версионируется вместе с tender_version
+CREATE TABLE tender_lot
+(
+    id             UUID PRIMARY KEY,
+    tender_id      UUID        NOT NULL,
+    status         VARCHAR(20) NOT NULL DEFAULT 'open',
+    awarded_bid_id UUID,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_tender FOREIGN KEY (tender_id) REFERENCES tender (id),
+    CONSTRAINT fk_awarded_bid FOREIGN KEY (awarded_bid_id) REFERENCES bid (id),
+    CONSTRAINT chk_tender_lot_status CHECK (status IN ('open', 'awarded', 'canceled'))
+);
+
+CREATE INDEX idx_tender_lot_tender_id ON tender_lot (tender_id);
+
+CREATE TABLE tender_lot_version
+(
+    tender_version_id UUID         NOT NULL,
+    lot_id            UUID         NOT NULL,
+    number            INT          NOT NULL,
+    name              VARCHAR(255) NOT NULL,
+    description       TEXT         NOT NULL,
+    budget_amount     BIGINT,
+    budget_currency   CHAR(3),
+    PRIMARY KEY (tender_version_id, lot_id),
+    CONSTRAINT fk_tender_version FOREIGN KEY (tender_version_id) REFERENCES tender_version (id),
+    CONSTRAINT fk_lot FOREIGN KEY (lot_id) REFERENCES tender_lot (id),
+    CONSTRAINT uq_tender_lot_version_number UNIQUE (tender_version_id, number),
+    CONSTRAINT chk_tender_lot_version_budget CHECK (
+        (budget_amount IS NULL AND budget_currency IS NULL) OR
+        (budget_amount >= 0 AND budget_currency IS NOT NULL)
+    )
+);
+
+-- Существующие тендеры получают единственный лот по умолчанию. Лот выигран, если по тендеру есть
+-- одобренное предложение, и отменен, если тендер закрыт без победителя
+INSERT INTO tender_lot (id, tender_id, status, awarded_bid_id, created_at)
+SELECT gen_random_uuid(),
+       t.id,
+       CASE
+           WHEN approved.id IS NOT NULL THEN 'awarded'
+           WHEN t.status = 'closed' THEN 'canceled'
+           ELSE 'open'
+           END,
+       approved.id,
+       t.created_at
+FROM tender t
+         LEFT JOIN LATERAL (
+    SELECT b.id
+    FROM bid b
+    WHERE b.tender_id = t.id
+      AND b.status = 'approved'
+    ORDER BY b.created_at
+    LIMIT 1
+    ) approved ON TRUE;
+
+-- Лот по умолчанию в каждой версии тендера повторяет ее название, описание и бюджет
+INSERT INTO tender_lot_version (tender_version_id, lot_id, number, name, description, budget_amount, budget_currency)
+SELECT tv.id, l.id, 1, tv.name, tv.description, tv.budget_amount, tv.budget_currency
+FROM tender_version tv
+         JOIN tender_lot l ON l.tender_id = tv.tender_id;
+
+-- Предложения подаются на лот
+ALTER TABLE bid
+    ADD COLUMN lot_id UUID;
+
+UPDATE bid b
+SET lot_id = l.id
+FROM tender_lot l
+WHERE l.tender_id = b.tender_id;
+
+ALTER TABLE bid
+    ALTER COLUMN lot_id SET NOT NULL,
+    ADD CONSTRAINT fk_lot FOREIGN KEY (lot_id) REFERENCES tender_lot (id);
+
+CREATE INDEX idx_bid_lot_id ON bid (lot_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TABLE bid
+    DROP COLUMN lot_id;
+
+DROP TABLE tender_lot_version;
+DROP TABLE tender_lot;
+-- +goose StatementEnd
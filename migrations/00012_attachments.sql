@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Вложения тендеров и предложений. Содержимое хранится в BlobStorage по storage_key
+CREATE TABLE attachment
+(
+    id           UUID PRIMARY KEY,
+    owner_type   VARCHAR(20)  NOT NULL,
+    owner_id     UUID         NOT NULL,
+    file_name    VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size         BIGINT       NOT NULL,
+    sha256       CHAR(64)     NOT NULL,
+    storage_key  VARCHAR(255) NOT NULL UNIQUE,
+    uploaded_by  UUID,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_uploaded_by FOREIGN KEY (uploaded_by) REFERENCES employee (id),
+    CONSTRAINT chk_attachment_owner_type CHECK (owner_type IN ('tender', 'bid'))
+);
+
+CREATE INDEX idx_attachment_owner ON attachment (owner_type, owner_id);
+
+-- Вложения привязаны к версиям: новая версия наследует вложения текущей, откат возвращает вложения версии
+CREATE TABLE tender_version_attachment
+(
+    tender_version_id UUID NOT NULL,
+    attachment_id     UUID NOT NULL,
+    PRIMARY KEY (tender_version_id, attachment_id),
+    CONSTRAINT fk_tender_version FOREIGN KEY (tender_version_id) REFERENCES tender_version (id),
+    CONSTRAINT fk_attachment FOREIGN KEY (attachment_id) REFERENCES attachment (id)
+);
+
+CREATE TABLE bid_version_attachment
+(
+    bid_version_id UUID NOT NULL,
+    attachment_id  UUID NOT NULL,
+    PRIMARY KEY (bid_version_id, attachment_id),
+    CONSTRAINT fk_bid_version FOREIGN KEY (bid_version_id) REFERENCES bid_version (id),
+    CONSTRAINT fk_attachment FOREIGN KEY (attachment_id) REFERENCES attachment (id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE bid_version_attachment;
+DROP TABLE tender_version_attachment;
+DROP TABLE attachment;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Иерархический классификатор закупок (например, ОКПД2). Родитель проверяется в конце транзакции,
+-- чтобы при импорте категории можно было загружать в любом порядке
+CREATE TABLE category
+(
+    code        VARCHAR(64) PRIMARY KEY,
+    parent_code VARCHAR(64),
+    name        TEXT        NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_parent FOREIGN KEY (parent_code) REFERENCES category (code) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE INDEX idx_category_parent_code ON category (parent_code);
+
+-- Прежние типы тендеров становятся корневыми категориями
+INSERT INTO category (code, name)
+VALUES ('construction', 'Строительство'),
+       ('delivery', 'Поставка'),
+       ('manufacture', 'Производство');
+
+ALTER TABLE tender_version
+    ADD CONSTRAINT fk_tender_version_category FOREIGN KEY (service_type) REFERENCES category (code);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TABLE tender_version
+    DROP CONSTRAINT fk_tender_version_category;
+
+DROP TABLE category;
+-- +goose StatementEnd
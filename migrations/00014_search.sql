@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Поисковые векторы хранятся в неизменяемых строках версий. Поиск всегда соединяет версию через
+-- current_version_id, поэтому после Update и Rollback индекс не требует пересчета
+ALTER TABLE tender_version
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', name), 'A') ||
+        setweight(to_tsvector('english', name), 'A') ||
+        setweight(to_tsvector('russian', description), 'B') ||
+        setweight(to_tsvector('english', description), 'B')
+    ) STORED;
+
+CREATE INDEX idx_tender_version_search_vector ON tender_version USING GIN (search_vector);
+
+ALTER TABLE bid_version
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', name), 'A') ||
+        setweight(to_tsvector('english', name), 'A') ||
+        setweight(to_tsvector('russian', description), 'B') ||
+        setweight(to_tsvector('english', description), 'B')
+    ) STORED;
+
+CREATE INDEX idx_bid_version_search_vector ON bid_version USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP INDEX idx_bid_version_search_vector;
+ALTER TABLE bid_version
+    DROP COLUMN search_vector;
+
+DROP INDEX idx_tender_version_search_vector;
+ALTER TABLE tender_version
+    DROP COLUMN search_vector;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Автор версии: сотрудник или организация, действующая по API-ключу. NULL — автор не записывался
+ALTER TABLE tender_version
+    ADD COLUMN created_by_type VARCHAR(20),
+    ADD COLUMN created_by_id   UUID,
+    ADD CONSTRAINT tender_version_created_by_check CHECK (
+        (created_by_type IS NULL AND created_by_id IS NULL) OR
+        (created_by_type IN ('employee', 'organization') AND created_by_id IS NOT NULL)
+    );
+
+ALTER TABLE bid_version
+    ADD COLUMN created_by_type VARCHAR(20),
+    ADD COLUMN created_by_id   UUID,
+    ADD CONSTRAINT bid_version_created_by_check CHECK (
+        (created_by_type IS NULL AND created_by_id IS NULL) OR
+        (created_by_type IN ('employee', 'organization') AND created_by_id IS NOT NULL)
+    );
+
+-- Предложения пользователей мог менять только их автор
+UPDATE bid_version bv
+SET created_by_type = 'employee',
+    created_by_id   = b.author_id
+FROM bid b
+WHERE b.id = bv.bid_id
+  AND b.author_type = 'user';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TABLE bid_version
+    DROP CONSTRAINT bid_version_created_by_check,
+    DROP COLUMN created_by_id,
+    DROP COLUMN created_by_type;
+
+ALTER TABLE tender_version
+    DROP CONSTRAINT tender_version_created_by_check,
+    DROP COLUMN created_by_id,
+    DROP COLUMN created_by_type;
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Откат переключал текущую версию назад, и следующие правки могли повторить номер версии.
+-- Повторы получают номера после последней версии в порядке создания
+UPDATE tender_version tv
+SET version = r.version
+FROM (SELECT d.id,
+             d.max_version + ROW_NUMBER() OVER (PARTITION BY d.tender_id ORDER BY d.created_at, d.id) AS version
+      FROM (SELECT id,
+                   tender_id,
+                   created_at,
+                   ROW_NUMBER() OVER (PARTITION BY tender_id, version ORDER BY created_at, id) AS n,
+                   MAX(version) OVER (PARTITION BY tender_id)                                  AS max_version
+            FROM tender_version) d
+      WHERE d.n > 1) r
+WHERE tv.id = r.id;
+
+UPDATE bid_version bv
+SET version = r.version
+FROM (SELECT d.id,
+             d.max_version + ROW_NUMBER() OVER (PARTITION BY d.bid_id ORDER BY d.created_at, d.id) AS version
+      FROM (SELECT id,
+                   bid_id,
+                   created_at,
+                   ROW_NUMBER() OVER (PARTITION BY bid_id, version ORDER BY created_at, id) AS n,
+                   MAX(version) OVER (PARTITION BY bid_id)                               AS max_version
+            FROM bid_version) d
+      WHERE d.n > 1) r
+WHERE bv.id = r.id;
+
+ALTER TABLE tender_version
+    ADD CONSTRAINT uq_tender_version_number UNIQUE (tender_id, version);
+
+ALTER TABLE bid_version
+    ADD CONSTRAINT uq_bid_version_number UNIQUE (bid_id, version);
+
+-- Откат создает новую версию — копию старой, rolled_back_from хранит номер скопированной версии
+ALTER TABLE tender_version
+    ADD COLUMN rolled_back_from INT,
+    ADD CONSTRAINT fk_tender_version_rolled_back_from FOREIGN KEY (tender_id, rolled_back_from)
+        REFERENCES tender_version (tender_id, version);
+
+ALTER TABLE bid_version
+    ADD COLUMN rolled_back_from INT,
+    ADD CONSTRAINT fk_bid_version_rolled_back_from FOREIGN KEY (bid_id, rolled_back_from)
+        REFERENCES bid_version (bid_id, version);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TABLE bid_version
+    DROP CONSTRAINT fk_bid_version_rolled_back_from,
+    DROP COLUMN rolled_back_from,
+    DROP CONSTRAINT uq_bid_version_number;
+
+ALTER TABLE tender_version
+    DROP CONSTRAINT fk_tender_version_rolled_back_from,
+    DROP COLUMN rolled_back_from,
+    DROP CONSTRAINT uq_tender_version_number;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Ключи идемпотентности изменяющих запросов: повтор запроса с тем же ключом получает сохраненный ответ.
+-- scope — вызывающий, ключи разных вызывающих не пересекаются. Ответ равен NULL, пока первый запрос выполняется
+CREATE TABLE idempotency_key
+(
+    scope       VARCHAR(100) NOT NULL,
+    key         VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64)  NOT NULL,
+    status_code INT,
+    header      JSONB,
+    body        BYTEA,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idx_idempotency_key_expires_at ON idempotency_key (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE idempotency_key CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Видимость тендера: public — виден всем, invite_only — только своей организации и приглашенным
+ALTER TABLE tender
+    ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('public', 'invite_only'));
+
+-- Приглашенные в тендер организации и сотрудники. Сотрудники приглашенной организации тоже считаются приглашенными
+CREATE TABLE tender_invitee
+(
+    tender_id    UUID        NOT NULL REFERENCES tender (id) ON DELETE CASCADE,
+    invitee_type VARCHAR(20) NOT NULL CHECK (invitee_type IN ('employee', 'organization')),
+    invitee_id   UUID        NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tender_id, invitee_type, invitee_id)
+);
+
+CREATE INDEX idx_tender_invitee_invitee ON tender_invitee (invitee_type, invitee_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+DROP TABLE tender_invitee CASCADE;
+
+ALTER TABLE tender
+    DROP COLUMN visibility;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+
+-- Ревизия тендера и предложения растет при любом изменении: новой версии, смене статуса, статуса лота
+-- или видимости. Из нее строится ETag, поэтому If-Match замечает и изменения, не создающие версию
+ALTER TABLE tender
+    ADD COLUMN revision BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE bid
+    ADD COLUMN revision BIGINT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+
+ALTER TABLE bid
+    DROP COLUMN revision;
+
+ALTER TABLE tender
+    DROP COLUMN revision;
+-- +goose StatementEnd